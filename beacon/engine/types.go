@@ -357,6 +357,10 @@ type ExecutionPayloadBody struct {
 	Withdrawals     []*types.Withdrawal `json:"withdrawals"`
 }
 
+// MaxBytesPerInclusionList is the maximum total size of the transactions of an
+// inclusion list, see EIP-7805.
+const MaxBytesPerInclusionList = 8192
+
 // Client identifiers to support ClientVersionV1.
 const (
 	ClientCode = "GE"
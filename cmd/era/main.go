@@ -28,7 +28,6 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/era"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
@@ -59,6 +58,10 @@ var (
 		Name:  "txs",
 		Usage: "print full transaction values",
 	}
+	postMergeFlag = &cli.BoolFlag{
+		Name:  "post-merge",
+		Usage: "operate on the post-merge era files (.erae) instead of the era1 files",
+	}
 )
 
 var (
@@ -80,7 +83,7 @@ var (
 	verifyCommand = &cli.Command{
 		Name:      "verify",
 		ArgsUsage: "<expected>",
-		Usage:     "verifies each era1 against expected accumulator root",
+		Usage:     "verifies each era file against expected accumulator root",
 		Action:    verify,
 	}
 )
@@ -95,6 +98,7 @@ func init() {
 		dirFlag,
 		networkFlag,
 		eraSizeFlag,
+		postMergeFlag,
 	}
 }
 
@@ -111,9 +115,9 @@ func block(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("invalid block number: %w", err)
 	}
-	e, err := open(ctx, num/uint64(ctx.Int(eraSizeFlag.Name)))
+	e, err := openByNumber(ctx, num)
 	if err != nil {
-		return fmt.Errorf("error opening era1: %w", err)
+		return fmt.Errorf("error opening era: %w", err)
 	}
 	defer e.Close()
 	// Read block with number.
@@ -146,37 +150,94 @@ func info(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error reading accumulator: %w", err)
 	}
-	td, err := e.InitialTD()
-	if err != nil {
-		return fmt.Errorf("error reading total difficulty: %w", err)
+	var (
+		td        *big.Int
+		firstSlot *uint64
+	)
+	if !e.IsPostMerge() {
+		if td, err = e.InitialTD(); err != nil {
+			return fmt.Errorf("error reading total difficulty: %w", err)
+		}
+	} else {
+		slot, _, err := e.BeaconAnchor()
+		if err != nil {
+			return fmt.Errorf("error reading beacon anchor: %w", err)
+		}
+		firstSlot = &slot
 	}
 	info := struct {
 		Accumulator     common.Hash `json:"accumulator"`
-		TotalDifficulty *big.Int    `json:"totalDifficulty"`
+		TotalDifficulty *big.Int    `json:"totalDifficulty,omitempty"`
+		FirstSlot       *uint64     `json:"firstSlot,omitempty"`
 		StartBlock      uint64      `json:"startBlock"`
 		Count           uint64      `json:"count"`
 	}{
-		acc, td, e.Start(), e.Count(),
+		acc, td, firstSlot, e.Start(), e.Count(),
 	}
 	b, _ := json.MarshalIndent(info, "", "  ")
 	fmt.Println(string(b))
 	return nil
 }
 
-// open opens an era1 file at a certain epoch.
+// open opens an era file at a certain epoch.
 func open(ctx *cli.Context, epoch uint64) (*era.Era, error) {
+	dir := ctx.String(dirFlag.Name)
+	entries, first, err := readDir(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading era dir: %w", err)
+	}
+	if epoch < first || epoch >= first+uint64(len(entries)) {
+		return nil, fmt.Errorf("epoch out-of-bounds: first %d, last %d, want %d", first, first+uint64(len(entries))-1, epoch)
+	}
+	return era.Open(filepath.Join(dir, entries[epoch-first]))
+}
+
+// openByNumber opens the era file containing the given block. The era1 files
+// are located by their block range, whereas the post-merge ones are grouped by
+// slot era and need to be searched.
+func openByNumber(ctx *cli.Context, num uint64) (*era.Era, error) {
+	if !ctx.Bool(postMergeFlag.Name) {
+		return open(ctx, num/uint64(ctx.Int(eraSizeFlag.Name)))
+	}
+	dir := ctx.String(dirFlag.Name)
+	entries, _, err := readDir(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading era dir: %w", err)
+	}
+	for _, name := range entries {
+		e, err := era.Open(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if num >= e.Start() && num < e.Start()+e.Count() {
+			return e, nil
+		}
+		e.Close()
+	}
+	return nil, fmt.Errorf("block %d not found", num)
+}
+
+// readDir returns the era files of the configured network along with the epoch
+// of the first file. The era1 files always start from genesis, whereas the
+// post-merge ones start from the first archived slot era.
+func readDir(ctx *cli.Context) ([]string, uint64, error) {
 	var (
 		dir     = ctx.String(dirFlag.Name)
 		network = ctx.String(networkFlag.Name)
 	)
-	entries, err := era.ReadDir(dir, network)
-	if err != nil {
-		return nil, fmt.Errorf("error reading era dir: %w", err)
+	if !ctx.Bool(postMergeFlag.Name) {
+		entries, err := era.ReadDir(dir, network)
+		return entries, 0, err
+	}
+	entries, err := era.ReadPostMergeDir(dir, network)
+	if err != nil || len(entries) == 0 {
+		return entries, 0, err
 	}
-	if epoch >= uint64(len(entries)) {
-		return nil, fmt.Errorf("epoch out-of-bounds: last %d, want %d", len(entries)-1, epoch)
+	first, err := strconv.ParseUint(strings.Split(entries[0], "-")[1], 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("malformed era filename: %s", entries[0])
 	}
-	return era.Open(filepath.Join(dir, entries[epoch]))
+	return entries, first, nil
 }
 
 // verify checks each era1 file in a directory to ensure it is well-formed and
@@ -193,18 +254,17 @@ func verify(ctx *cli.Context) error {
 
 	var (
 		dir      = ctx.String(dirFlag.Name)
-		network  = ctx.String(networkFlag.Name)
 		start    = time.Now()
 		reported = time.Now()
 	)
 
-	entries, err := era.ReadDir(dir, network)
+	entries, _, err := readDir(ctx)
 	if err != nil {
 		return fmt.Errorf("error reading %s: %w", dir, err)
 	}
 
 	if len(entries) != len(roots) {
-		return errors.New("number of era1 files should match the number of accumulator hashes")
+		return errors.New("number of era files should match the number of accumulator hashes")
 	}
 
 	// Verify each epoch matches the expected root.
@@ -214,7 +274,7 @@ func verify(ctx *cli.Context) error {
 			name := entries[i]
 			e, err := era.Open(filepath.Join(dir, name))
 			if err != nil {
-				return fmt.Errorf("error opening era1 file %s: %w", name, err)
+				return fmt.Errorf("error opening era file %s: %w", name, err)
 			}
 			defer e.Close()
 			// Read accumulator and check against expected.
@@ -224,12 +284,12 @@ func verify(ctx *cli.Context) error {
 				return fmt.Errorf("invalid root %s: got %s, want %s", name, got, want)
 			}
 			// Recompute accumulator.
-			if err := checkAccumulator(e); err != nil {
-				return fmt.Errorf("error verify era1 file %s: %w", name, err)
+			if err := era.Verify(e, trie.NewStackTrie(nil)); err != nil {
+				return fmt.Errorf("error verify era file %s: %w", name, err)
 			}
 			// Give the user some feedback that something is happening.
 			if time.Since(reported) >= 8*time.Second {
-				fmt.Printf("Verifying Era1 files \t\t verified=%d,\t elapsed=%s\n", i, common.PrettyDuration(time.Since(start)))
+				fmt.Printf("Verifying Era files \t\t verified=%d,\t elapsed=%s\n", i, common.PrettyDuration(time.Since(start)))
 				reported = time.Now()
 			}
 			return nil
@@ -242,69 +302,6 @@ func verify(ctx *cli.Context) error {
 	return nil
 }
 
-// checkAccumulator verifies the accumulator matches the data in the Era.
-func checkAccumulator(e *era.Era) error {
-	var (
-		err    error
-		want   common.Hash
-		td     *big.Int
-		tds    = make([]*big.Int, 0)
-		hashes = make([]common.Hash, 0)
-	)
-	if want, err = e.Accumulator(); err != nil {
-		return fmt.Errorf("error reading accumulator: %w", err)
-	}
-	if td, err = e.InitialTD(); err != nil {
-		return fmt.Errorf("error reading total difficulty: %w", err)
-	}
-	it, err := era.NewIterator(e)
-	if err != nil {
-		return fmt.Errorf("error making era iterator: %w", err)
-	}
-	// To fully verify an era the following attributes must be checked:
-	//   1) the block index is constructed correctly
-	//   2) the tx root matches the value in the block
-	//   3) the receipts root matches the value in the block
-	//   4) the starting total difficulty value is correct
-	//   5) the accumulator is correct by recomputing it locally, which verifies
-	//      the blocks are all correct (via hash)
-	//
-	// The attributes 1), 2), and 3) are checked for each block. 4) and 5) require
-	// accumulation across the entire set and are verified at the end.
-	for it.Next() {
-		// 1) next() walks the block index, so we're able to implicitly verify it.
-		if it.Error() != nil {
-			return fmt.Errorf("error reading block %d: %w", it.Number(), err)
-		}
-		block, receipts, err := it.BlockAndReceipts()
-		if it.Error() != nil {
-			return fmt.Errorf("error reading block %d: %w", it.Number(), err)
-		}
-		// 2) recompute tx root and verify against header.
-		tr := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil))
-		if tr != block.TxHash() {
-			return fmt.Errorf("tx root in block %d mismatch: want %s, got %s", block.NumberU64(), block.TxHash(), tr)
-		}
-		// 3) recompute receipt root and check value against block.
-		rr := types.DeriveSha(receipts, trie.NewStackTrie(nil))
-		if rr != block.ReceiptHash() {
-			return fmt.Errorf("receipt root in block %d mismatch: want %s, got %s", block.NumberU64(), block.ReceiptHash(), rr)
-		}
-		hashes = append(hashes, block.Hash())
-		td.Add(td, block.Difficulty())
-		tds = append(tds, new(big.Int).Set(td))
-	}
-	// 4+5) Verify accumulator and total difficulty.
-	got, err := era.ComputeAccumulator(hashes, tds)
-	if err != nil {
-		return fmt.Errorf("error computing accumulator: %w", err)
-	}
-	if got != want {
-		return fmt.Errorf("expected accumulator root does not match calculated: got %s, want %s", got, want)
-	}
-	return nil
-}
-
 // readHashes reads a file of newline-delimited hashes.
 func readHashes(f string) ([]common.Hash, error) {
 	b, err := os.ReadFile(f)
@@ -0,0 +1,383 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package solc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// Config are the configuration options of the Debugger.
+type Config struct {
+	Trace       bool     // Print every executed instruction with its source location
+	Interactive bool     // Stop at the first source line and prompt for commands
+	Breakpoints []string // Source lines to stop at, in the form file:line
+}
+
+// breakpoint is a source line to stop the execution at.
+type breakpoint struct {
+	source *Source
+	line   int
+}
+
+// debugFrame is an active call frame of the debugged execution.
+type debugFrame struct {
+	address  common.Address
+	contract *Contract // Contract executed by the frame, nil if unknown
+	resolved bool      // Whether the contract has been looked up
+	pc       uint64    // Program counter of the last executed instruction
+	location *Location // Source location of the last executed instruction
+	line     int       // Source line of the last executed instruction
+}
+
+// stepMode is the condition for stopping at the next source line.
+type stepMode int
+
+const (
+	stepNone stepMode = iota // only stop at the breakpoints
+	stepInto                 // stop at the next source line
+	stepOver                 // stop at the next source line of the current or a parent frame
+)
+
+// Debugger maps the executed instructions back to the Solidity sources. It can
+// print a source-annotated trace, reports the source locations of reverts and
+// lets the execution be stepped through interactively, by source line.
+type Debugger struct {
+	program     *Program
+	config      Config
+	out         io.Writer
+	in          *bufio.Scanner
+	frames      []*debugFrame
+	breakpoints []breakpoint
+	step        stepMode
+	stepDepth   int  // Depth of the frame the step over was requested from
+	quit        bool // Whether the interactive session was ended
+}
+
+// NewDebugger creates a source-level debugger for the given program, writing
+// to out and reading the interactive commands from in.
+func NewDebugger(program *Program, config Config, in io.Reader, out io.Writer) (*Debugger, error) {
+	d := &Debugger{
+		program: program,
+		config:  config,
+		out:     out,
+		in:      bufio.NewScanner(in),
+	}
+	for _, bp := range config.Breakpoints {
+		if err := d.addBreakpoint(bp); err != nil {
+			return nil, err
+		}
+	}
+	if config.Interactive {
+		d.step = stepInto
+	}
+	return d, nil
+}
+
+// Hooks returns the tracing hooks driving the debugger.
+func (d *Debugger) Hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnEnter:  d.OnEnter,
+		OnExit:   d.OnExit,
+		OnOpcode: d.OnOpcode,
+	}
+}
+
+// OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (d *Debugger) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	d.frames = append(d.frames, &debugFrame{address: to})
+}
+
+// OnExit is called when EVM exits a scope, reporting the source location of
+// the instruction the scope failed at, if any.
+func (d *Debugger) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if len(d.frames) != depth+1 {
+		return
+	}
+	frame := d.frames[len(d.frames)-1]
+	d.frames = d.frames[:len(d.frames)-1]
+
+	if err == nil {
+		return
+	}
+	indent := strings.Repeat("  ", depth)
+	switch {
+	case frame.location != nil:
+		fmt.Fprintf(d.out, "%s%s at %s", indent, err, frame.location)
+		if fn := frame.location.Function(); fn != "" {
+			fmt.Fprintf(d.out, " (%s)", fn)
+		}
+		fmt.Fprintf(d.out, "\n%s  %s\n", indent, frame.location.Text())
+	case frame.contract != nil:
+		fmt.Fprintf(d.out, "%s%s in %s at pc %d\n", indent, err, frame.contract.Name, frame.pc)
+	default:
+		fmt.Fprintf(d.out, "%s%s in %s at pc %d\n", indent, err, frame.address.Hex(), frame.pc)
+	}
+}
+
+// OnOpcode is called before the execution of every instruction.
+func (d *Debugger) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	// The instructions are reported with the depth of the interpreter, which is
+	// one higher than the depth of the frame executing them.
+	if len(d.frames) != depth {
+		return
+	}
+	frame := d.frames[len(d.frames)-1]
+	if !frame.resolved {
+		frame.contract = d.program.Lookup(scope.ContractCode())
+		frame.resolved = true
+	}
+	frame.pc = pc
+
+	var location *Location
+	if frame.contract != nil {
+		location = frame.contract.Location(pc)
+	}
+	if d.config.Trace {
+		d.printStep(frame, pc, vm.OpCode(op), gas, depth, location)
+	}
+	if location == nil {
+		return
+	}
+	line, _ := location.Line()
+	changed := frame.location == nil || frame.location.Source != location.Source || frame.line != line
+	frame.location, frame.line = location, line
+
+	if !changed || d.quit {
+		return
+	}
+	if d.shouldStop(location, line, depth) {
+		d.prompt(frame, scope)
+	}
+}
+
+// printStep prints an executed instruction, along with its source line if it
+// differs from the previous instruction of the frame.
+func (d *Debugger) printStep(frame *debugFrame, pc uint64, op vm.OpCode, gas uint64, depth int, location *Location) {
+	indent := strings.Repeat("  ", depth-1)
+	if location == nil {
+		fmt.Fprintf(d.out, "%s%-5d %-14s gas=%d\n", indent, pc, op, gas)
+		return
+	}
+	fmt.Fprintf(d.out, "%s%-5d %-14s gas=%-10d %s\n", indent, pc, op, gas, location)
+	if line, _ := location.Line(); frame.location == nil || frame.location.Source != location.Source || frame.line != line {
+		fmt.Fprintf(d.out, "%s      | %s\n", indent, location.Text())
+	}
+}
+
+// shouldStop reports whether the execution should stop at the given source line.
+func (d *Debugger) shouldStop(location *Location, line int, depth int) bool {
+	switch d.step {
+	case stepInto:
+		return true
+	case stepOver:
+		if depth <= d.stepDepth {
+			return true
+		}
+	}
+	for _, bp := range d.breakpoints {
+		if bp.source == location.Source && bp.line == line {
+			return true
+		}
+	}
+	return false
+}
+
+// prompt stops the execution and processes the interactive commands until the
+// execution is resumed.
+func (d *Debugger) prompt(frame *debugFrame, scope tracing.OpContext) {
+	d.printLocation(frame)
+	for {
+		fmt.Fprint(d.out, "> ")
+		if !d.in.Scan() {
+			// End of input, run the execution to completion
+			fmt.Fprintln(d.out)
+			d.quit = true
+			return
+		}
+		fields := strings.Fields(d.in.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch cmd, args := fields[0], fields[1:]; cmd {
+		case "s", "step":
+			d.step = stepInto
+			return
+		case "n", "next":
+			d.step, d.stepDepth = stepOver, len(d.frames)
+			return
+		case "c", "continue":
+			d.step = stepNone
+			return
+		case "q", "quit":
+			d.quit = true
+			return
+		case "b", "break":
+			if len(args) == 0 {
+				d.printBreakpoints()
+				continue
+			}
+			for _, arg := range args {
+				if err := d.addBreakpoint(arg); err != nil {
+					fmt.Fprintln(d.out, err)
+				}
+			}
+		case "d", "delete":
+			for _, arg := range args {
+				if err := d.deleteBreakpoint(arg); err != nil {
+					fmt.Fprintln(d.out, err)
+				}
+			}
+		case "l", "list":
+			d.printSource(frame)
+		case "st", "stack":
+			stack := scope.StackData()
+			for i := len(stack) - 1; i >= 0; i-- {
+				fmt.Fprintf(d.out, "%3d: %s\n", len(stack)-1-i, stack[i].Hex())
+			}
+		case "m", "memory":
+			memory := scope.MemoryData()
+			for i := 0; i < len(memory); i += 32 {
+				fmt.Fprintf(d.out, "%04x: %x\n", i, memory[i:min(i+32, len(memory))])
+			}
+		case "bt", "backtrace":
+			d.printBacktrace()
+		case "h", "help":
+			fmt.Fprint(d.out, debuggerHelp)
+		default:
+			fmt.Fprintf(d.out, "unknown command %q, type help for the list of commands\n", cmd)
+		}
+	}
+}
+
+const debuggerHelp = `Commands:
+  s, step              run until the next source line
+  n, next              run until the next source line, stepping over calls
+  c, continue          run until the next breakpoint
+  b, break [file:line] set a breakpoint, or list them without arguments
+  d, delete file:line  delete a breakpoint
+  l, list              show the source around the current line
+  st, stack            show the stack
+  m, memory            show the memory
+  bt, backtrace        show the call frames
+  q, quit              run the execution to completion
+`
+
+// printLocation prints the source line the execution is stopped at.
+func (d *Debugger) printLocation(frame *debugFrame) {
+	fmt.Fprintf(d.out, "%s", frame.location)
+	if fn := frame.location.Function(); fn != "" {
+		fmt.Fprintf(d.out, " (%s)", fn)
+	}
+	fmt.Fprintf(d.out, "\n=> %d\t%s\n", frame.line, frame.location.Text())
+}
+
+// printSource prints the source lines around the current one.
+func (d *Debugger) printSource(frame *debugFrame) {
+	source := frame.location.Source
+	for line := max(1, frame.line-3); line <= min(source.Lines(), frame.line+3); line++ {
+		marker := "  "
+		if line == frame.line {
+			marker = "=>"
+		}
+		fmt.Fprintf(d.out, "%s %d\t%s\n", marker, line, source.Line(line))
+	}
+}
+
+// printBacktrace prints the active call frames, innermost first.
+func (d *Debugger) printBacktrace() {
+	for i := len(d.frames) - 1; i >= 0; i-- {
+		frame := d.frames[i]
+		switch {
+		case frame.location != nil:
+			fmt.Fprintf(d.out, "#%d %s %s", len(d.frames)-1-i, frame.address.Hex(), frame.location)
+			if fn := frame.location.Function(); fn != "" {
+				fmt.Fprintf(d.out, " (%s)", fn)
+			}
+			fmt.Fprintln(d.out)
+		default:
+			fmt.Fprintf(d.out, "#%d %s pc %d\n", len(d.frames)-1-i, frame.address.Hex(), frame.pc)
+		}
+	}
+}
+
+// printBreakpoints prints the configured breakpoints.
+func (d *Debugger) printBreakpoints() {
+	if len(d.breakpoints) == 0 {
+		fmt.Fprintln(d.out, "no breakpoints")
+	}
+	for _, bp := range d.breakpoints {
+		fmt.Fprintf(d.out, "%s:%d\n", bp.source.Name, bp.line)
+	}
+}
+
+// parseBreakpoint parses a source line in the form file:line.
+func (d *Debugger) parseBreakpoint(arg string) (breakpoint, error) {
+	idx := strings.LastIndex(arg, ":")
+	if idx < 0 {
+		return breakpoint{}, fmt.Errorf("invalid breakpoint %q, expected file:line", arg)
+	}
+	line, err := strconv.Atoi(arg[idx+1:])
+	if err != nil || line < 1 {
+		return breakpoint{}, fmt.Errorf("invalid breakpoint line %q", arg[idx+1:])
+	}
+	source := d.program.Source(arg[:idx])
+	if source == nil {
+		return breakpoint{}, fmt.Errorf("unknown source %q", arg[:idx])
+	}
+	if line > source.Lines() {
+		return breakpoint{}, fmt.Errorf("line %d out of range, %s has %d lines", line, source.Name, source.Lines())
+	}
+	return breakpoint{source: source, line: line}, nil
+}
+
+func (d *Debugger) addBreakpoint(arg string) error {
+	bp, err := d.parseBreakpoint(arg)
+	if err != nil {
+		return err
+	}
+	for _, existing := range d.breakpoints {
+		if existing == bp {
+			return nil
+		}
+	}
+	d.breakpoints = append(d.breakpoints, bp)
+	return nil
+}
+
+func (d *Debugger) deleteBreakpoint(arg string) error {
+	bp, err := d.parseBreakpoint(arg)
+	if err != nil {
+		return err
+	}
+	for i, existing := range d.breakpoints {
+		if existing == bp {
+			d.breakpoints = append(d.breakpoints[:i], d.breakpoints[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("no breakpoint at " + arg)
+}
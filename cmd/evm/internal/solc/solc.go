@@ -0,0 +1,411 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// Package solc maps the EVM execution back to the Solidity sources, using the
+// source maps and the ASTs of the solc standard-JSON output.
+package solc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// output is the subset of the solc standard-JSON output used for mapping the
+// bytecode to the sources.
+type output struct {
+	Contracts map[string]map[string]struct {
+		EVM struct {
+			Bytecode         bytecode `json:"bytecode"`
+			DeployedBytecode bytecode `json:"deployedBytecode"`
+		} `json:"evm"`
+	} `json:"contracts"`
+	Sources map[string]struct {
+		ID      int             `json:"id"`
+		AST     json.RawMessage `json:"ast"`
+		Content *string         `json:"content"` // Not part of the output, but accepted if present
+	} `json:"sources"`
+}
+
+type bytecode struct {
+	Object              string                            `json:"object"`
+	SourceMap           string                            `json:"sourceMap"`
+	LinkReferences      map[string]map[string][]codeRange `json:"linkReferences"`
+	ImmutableReferences map[string][]codeRange            `json:"immutableReferences"`
+}
+
+// codeRange is a range of the bytecode filled in at linking or deployment.
+type codeRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// Program is the set of contracts and sources of a solc compilation.
+type Program struct {
+	Contracts []*Contract
+	sources   map[int]*Source
+}
+
+// Contract is the creation or the runtime bytecode of a compiled contract,
+// along with the source locations of its instructions.
+type Contract struct {
+	Name      string // Fully qualified name of the contract, as file:Name
+	Code      []byte // Bytecode with the library addresses and the immutables zeroed
+	Runtime   bool   // Whether the code is the runtime (deployed) bytecode
+	locations map[uint64]*Location
+	variable  []codeRange // Ranges of the library addresses and the immutables
+}
+
+// Location returns the source location of the instruction at the given program
+// counter, or nil if the instruction has no source associated.
+func (c *Contract) Location(pc uint64) *Location {
+	return c.locations[pc]
+}
+
+// Source is a source file of the compilation.
+type Source struct {
+	Name      string
+	content   []byte
+	lines     []int // Offsets of the line starts
+	functions []function
+}
+
+// function is a function or modifier definition in a source file.
+type function struct {
+	name          string
+	start, length int
+}
+
+// Location is a range within a source file.
+type Location struct {
+	Source *Source
+	Start  int
+	Length int
+	Jump   string // Either "i" (into a function), "o" (out of a function) or "-"
+}
+
+// Line returns the 1-based line and column of the location start.
+func (l *Location) Line() (int, int) {
+	idx := sort.Search(len(l.Source.lines), func(i int) bool { return l.Source.lines[i] > l.Start })
+	return idx, l.Start - l.Source.lines[idx-1] + 1
+}
+
+// Text returns the source line containing the location start, with the
+// surrounding whitespace removed.
+func (l *Location) Text() string {
+	line, _ := l.Line()
+	return l.Source.Line(line)
+}
+
+// Function returns the name of the innermost function or modifier containing
+// the location, or an empty string if the location is outside of them.
+func (l *Location) Function() string {
+	var (
+		name   string
+		length = -1
+	)
+	for _, fn := range l.Source.functions {
+		if fn.start <= l.Start && l.Start+l.Length <= fn.start+fn.length && (length < 0 || fn.length < length) {
+			name, length = fn.name, fn.length
+		}
+	}
+	return name
+}
+
+// String implements fmt.Stringer, formatting the location as file:line:column.
+func (l *Location) String() string {
+	line, col := l.Line()
+	return fmt.Sprintf("%s:%d:%d", l.Source.Name, line, col)
+}
+
+// Line returns the given 1-based line of the source, with the surrounding
+// whitespace removed.
+func (s *Source) Line(line int) string {
+	if line < 1 || line > len(s.lines) {
+		return ""
+	}
+	end := len(s.content)
+	if line < len(s.lines) {
+		end = s.lines[line]
+	}
+	return strings.TrimSpace(string(s.content[s.lines[line-1]:end]))
+}
+
+// Lines returns the number of lines in the source.
+func (s *Source) Lines() int {
+	return len(s.lines)
+}
+
+// libraryPlaceholder matches the placeholders of the unlinked library addresses.
+var libraryPlaceholder = regexp.MustCompile(`__\$[0-9a-fA-F]{34}\$__|__[^_][^\s]{36}__`)
+
+// Load reads the solc standard-JSON output from the given file. The source files
+// are read from the paths relative to basePath, unless their contents are also
+// present in the output.
+func Load(file string, basePath string) (*Program, error) {
+	blob, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var out output
+	if err := json.Unmarshal(blob, &out); err != nil {
+		return nil, fmt.Errorf("invalid solc output: %v", err)
+	}
+	program := &Program{sources: make(map[int]*Source)}
+	for name, src := range out.Sources {
+		source := &Source{Name: name}
+		if src.Content != nil {
+			source.content = []byte(*src.Content)
+		} else if source.content, err = os.ReadFile(filepath.Join(basePath, name)); err != nil {
+			return nil, fmt.Errorf("failed to read source: %v", err)
+		}
+		source.lines = []int{0}
+		for i, c := range source.content {
+			if c == '\n' {
+				source.lines = append(source.lines, i+1)
+			}
+		}
+		if len(src.AST) > 0 {
+			var ast interface{}
+			if err := json.Unmarshal(src.AST, &ast); err != nil {
+				return nil, fmt.Errorf("invalid AST of %s: %v", name, err)
+			}
+			source.functions = collectFunctions(ast, src.ID)
+		}
+		program.sources[src.ID] = source
+	}
+	for file, contracts := range out.Contracts {
+		for name, contract := range contracts {
+			for _, code := range []struct {
+				bytecode bytecode
+				runtime  bool
+			}{
+				{contract.EVM.Bytecode, false},
+				{contract.EVM.DeployedBytecode, true},
+			} {
+				if code.bytecode.Object == "" {
+					continue
+				}
+				c, err := program.newContract(file+":"+name, code.bytecode, code.runtime)
+				if err != nil {
+					return nil, fmt.Errorf("contract %s:%s: %v", file, name, err)
+				}
+				program.Contracts = append(program.Contracts, c)
+			}
+		}
+	}
+	sort.Slice(program.Contracts, func(i, j int) bool {
+		if program.Contracts[i].Name != program.Contracts[j].Name {
+			return program.Contracts[i].Name < program.Contracts[j].Name
+		}
+		return !program.Contracts[i].Runtime
+	})
+	return program, nil
+}
+
+// newContract decodes the given bytecode and its source map.
+func (p *Program) newContract(name string, code bytecode, runtime bool) (*Contract, error) {
+	object := libraryPlaceholder.ReplaceAllString(code.Object, strings.Repeat("0", 40))
+	c := &Contract{
+		Name:      name,
+		Code:      common.FromHex(object),
+		Runtime:   runtime,
+		locations: make(map[uint64]*Location),
+	}
+	for _, libs := range code.LinkReferences {
+		for _, refs := range libs {
+			c.variable = append(c.variable, refs...)
+		}
+	}
+	for _, refs := range code.ImmutableReferences {
+		c.variable = append(c.variable, refs...)
+	}
+	for _, r := range c.variable {
+		if r.Start < 0 || r.Length < 0 || r.Start+r.Length > len(c.Code) {
+			return nil, fmt.Errorf("reference [%d, +%d] out of code bounds", r.Start, r.Length)
+		}
+	}
+	c.Code = c.mask(c.Code)
+	entries, err := decodeSourceMap(code.SourceMap)
+	if err != nil {
+		return nil, err
+	}
+	// Map the instructions to the source map entries, skipping the push data
+	var pc uint64
+	for _, entry := range entries {
+		if pc >= uint64(len(c.Code)) {
+			break
+		}
+		if source := p.sources[entry.file]; source != nil && entry.start >= 0 && entry.start+entry.length <= len(source.content) {
+			c.locations[pc] = &Location{Source: source, Start: entry.start, Length: entry.length, Jump: entry.jump}
+		}
+		if op := c.Code[pc]; op >= 0x60 && op <= 0x7f { // PUSH1 .. PUSH32
+			pc += uint64(op - 0x5f)
+		}
+		pc++
+	}
+	return c, nil
+}
+
+// mask returns a copy of the given code with the library addresses and the
+// immutables zeroed, as they are filled in at linking or deployment.
+func (c *Contract) mask(code []byte) []byte {
+	code = bytes.Clone(code)
+	for _, r := range c.variable {
+		if r.Start+r.Length <= len(code) {
+			clear(code[r.Start : r.Start+r.Length])
+		}
+	}
+	return code
+}
+
+// Lookup returns the contract with the given bytecode, ignoring the library
+// addresses and the immutables. The runtime code is matched exactly, whereas
+// the creation code is matched by its prefix, as the constructor arguments are
+// appended to it.
+func (p *Program) Lookup(code []byte) *Contract {
+	for _, c := range p.Contracts {
+		if c.Runtime && bytes.Equal(c.Code, c.mask(code)) {
+			return c
+		}
+	}
+	for _, c := range p.Contracts {
+		if !c.Runtime && len(c.Code) > 0 && len(code) >= len(c.Code) && bytes.Equal(c.Code, c.mask(code[:len(c.Code)])) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Contract returns the contract with the given name, which is either fully
+// qualified as file:Name or only the contract name if it's unambiguous.
+func (p *Program) Contract(name string, runtime bool) (*Contract, error) {
+	var found *Contract
+	for _, c := range p.Contracts {
+		if c.Runtime != runtime {
+			continue
+		}
+		if c.Name == name || strings.HasSuffix(c.Name, ":"+name) {
+			if found != nil {
+				return nil, fmt.Errorf("ambiguous contract name %q", name)
+			}
+			found = c
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("contract %q not found", name)
+	}
+	return found, nil
+}
+
+// Source returns the source with the given name, matching the file name alone
+// if it's unambiguous.
+func (p *Program) Source(name string) *Source {
+	var found *Source
+	for _, s := range p.sources {
+		if s.Name == name {
+			return s
+		}
+		if filepath.Base(s.Name) == name {
+			if found != nil {
+				return nil
+			}
+			found = s
+		}
+	}
+	return found
+}
+
+// sourceMapEntry is a decoded entry of a source map.
+type sourceMapEntry struct {
+	start, length, file int
+	jump                string
+}
+
+// decodeSourceMap decodes the compressed source map of the solc output, where
+// the entries are separated by semicolons and their empty fields are inherited
+// from the previous entry.
+func decodeSourceMap(sourceMap string) ([]sourceMapEntry, error) {
+	if sourceMap == "" {
+		return nil, nil
+	}
+	var (
+		entries []sourceMapEntry
+		last    = sourceMapEntry{file: -1, jump: "-"}
+	)
+	for i, entry := range strings.Split(sourceMap, ";") {
+		fields := strings.Split(entry, ":")
+		for j, field := range fields {
+			if field == "" {
+				continue
+			}
+			switch j {
+			case 0, 1, 2:
+				var n int
+				if _, err := fmt.Sscanf(field, "%d", &n); err != nil {
+					return nil, fmt.Errorf("invalid source map entry %d: %q", i, entry)
+				}
+				switch j {
+				case 0:
+					last.start = n
+				case 1:
+					last.length = n
+				case 2:
+					last.file = n
+				}
+			case 3:
+				last.jump = field
+			}
+		}
+		entries = append(entries, last)
+	}
+	return entries, nil
+}
+
+// collectFunctions gathers the function and modifier definitions of the given
+// source from its AST.
+func collectFunctions(node interface{}, id int) []function {
+	var functions []function
+	switch node := node.(type) {
+	case map[string]interface{}:
+		if typ, _ := node["nodeType"].(string); typ == "FunctionDefinition" || typ == "ModifierDefinition" {
+			src, _ := node["src"].(string)
+			var start, length, file int
+			if _, err := fmt.Sscanf(src, "%d:%d:%d", &start, &length, &file); err == nil && file == id {
+				name, _ := node["name"].(string)
+				if name == "" {
+					name, _ = node["kind"].(string) // constructor, fallback, receive
+				}
+				functions = append(functions, function{name: name, start: start, length: length})
+			}
+		}
+		for _, child := range node {
+			functions = append(functions, collectFunctions(child, id)...)
+		}
+	case []interface{}:
+		for _, child := range node {
+			functions = append(functions, collectFunctions(child, id)...)
+		}
+	}
+	return functions
+}
@@ -0,0 +1,271 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package solc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+)
+
+const testSource = `// SPDX-License-Identifier: GPL-3.0
+pragma solidity ^0.8.0;
+
+contract Test {
+    function run() public {
+        uint x = 1;
+        revert();
+    }
+}
+`
+
+// testCode is PUSH1 1, PUSH1 0, PUSH1 0, REVERT, mapped to the two statements
+// of the test source.
+var testCode = common.FromHex("0x600160006000fd")
+
+// writeTestOutput writes a solc standard-JSON output for the test source and
+// returns its path. The source content is written next to the output unless
+// embed is set.
+func writeTestOutput(t *testing.T, embed bool) string {
+	t.Helper()
+
+	var (
+		dir    = t.TempDir()
+		assign = strings.Index(testSource, "uint x = 1")
+		revert = strings.Index(testSource, "revert()")
+		fn     = strings.Index(testSource, "function run")
+		fnEnd  = strings.Index(testSource, "    }\n}") + 5
+	)
+	source := map[string]interface{}{
+		"id": 0,
+		"ast": map[string]interface{}{
+			"nodeType": "SourceUnit",
+			"src":      fmt.Sprintf("0:%d:0", len(testSource)),
+			"nodes": []interface{}{
+				map[string]interface{}{
+					"nodeType": "ContractDefinition",
+					"name":     "Test",
+					"nodes": []interface{}{
+						map[string]interface{}{
+							"nodeType": "FunctionDefinition",
+							"name":     "run",
+							"src":      fmt.Sprintf("%d:%d:0", fn, fnEnd-fn),
+						},
+					},
+				},
+			},
+		},
+	}
+	if embed {
+		source["content"] = testSource
+	} else if err := os.WriteFile(filepath.Join(dir, "test.sol"), []byte(testSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sourceMap := fmt.Sprintf("%d:10:0:-;%d:8;;", assign, revert)
+	out := map[string]interface{}{
+		"sources": map[string]interface{}{"test.sol": source},
+		"contracts": map[string]interface{}{
+			"test.sol": map[string]interface{}{
+				"Test": map[string]interface{}{
+					"evm": map[string]interface{}{
+						"bytecode": map[string]interface{}{"object": "6000", "sourceMap": ""},
+						"deployedBytecode": map[string]interface{}{
+							"object":              common.Bytes2Hex(testCode),
+							"sourceMap":           sourceMap,
+							"immutableReferences": map[string]interface{}{"3": []interface{}{map[string]int{"start": 1, "length": 1}}},
+						},
+					},
+				},
+			},
+		},
+	}
+	blob, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "output.json")
+	if err := os.WriteFile(path, blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDecodeSourceMap(t *testing.T) {
+	entries, err := decodeSourceMap("1:2:0:-;:3;;4::1:i;5:6:-1:o:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sourceMapEntry{
+		{start: 1, length: 2, file: 0, jump: "-"},
+		{start: 1, length: 3, file: 0, jump: "-"},
+		{start: 1, length: 3, file: 0, jump: "-"},
+		{start: 4, length: 3, file: 1, jump: "i"},
+		{start: 5, length: 6, file: -1, jump: "o"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("entries mismatch: have %+v, want %+v", entries, want)
+	}
+	if _, err := decodeSourceMap("1:x"); err == nil {
+		t.Fatal("expected error for invalid source map")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	for _, embed := range []bool{false, true} {
+		path := writeTestOutput(t, embed)
+		basePath := filepath.Dir(path)
+		if embed {
+			basePath = "nonexistent"
+		}
+		program, err := Load(path, basePath)
+		if err != nil {
+			t.Fatalf("embed %v: failed to load: %v", embed, err)
+		}
+		contract, err := program.Contract("Test", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The push data is skipped when mapping the instructions
+		for pc, want := range map[uint64]string{0: "test.sol:6:9", 2: "test.sol:7:9", 4: "test.sol:7:9", 6: "test.sol:7:9"} {
+			loc := contract.Location(pc)
+			if loc == nil {
+				t.Fatalf("pc %d: missing location", pc)
+			}
+			if loc.String() != want {
+				t.Errorf("pc %d: location mismatch: have %s, want %s", pc, loc, want)
+			}
+			if fn := loc.Function(); fn != "run" {
+				t.Errorf("pc %d: function mismatch: have %q, want run", pc, fn)
+			}
+		}
+		if loc := contract.Location(1); loc != nil {
+			t.Errorf("push data has location %s", loc)
+		}
+		if text := contract.Location(6).Text(); text != "revert();" {
+			t.Errorf("source text mismatch: have %q", text)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	program, err := Load(writeTestOutput(t, true), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		code    []byte
+		name    string
+		runtime bool
+	}{
+		{testCode, "test.sol:Test", true},                           // exact runtime code
+		{common.FromHex("0x60001234"), "test.sol:Test", false},      // creation code with arguments
+		{common.FromHex("0x600260006000fd"), "test.sol:Test", true}, // runtime code with immutables
+		{common.FromHex("0x600160016000fd"), "", false},             // same length, different code
+		{common.FromHex("0x00"), "", false},
+	}
+	for i, tt := range tests {
+		c := program.Lookup(tt.code)
+		if tt.name == "" {
+			if c != nil {
+				t.Errorf("test %d: unexpected match %s", i, c.Name)
+			}
+			continue
+		}
+		if c == nil || c.Name != tt.name || c.Runtime != tt.runtime {
+			t.Errorf("test %d: lookup mismatch: have %+v", i, c)
+		}
+	}
+}
+
+func runDebugger(t *testing.T, config Config, input string) string {
+	t.Helper()
+
+	program, err := Load(writeTestOutput(t, true), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	debugger, err := NewDebugger(program, config, strings.NewReader(input), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = runtime.Execute(testCode, nil, &runtime.Config{EVMConfig: vm.Config{Tracer: debugger.Hooks()}})
+	if err != vm.ErrExecutionReverted {
+		t.Fatalf("unexpected execution error: %v", err)
+	}
+	return out.String()
+}
+
+func TestDebuggerStep(t *testing.T) {
+	out := runDebugger(t, Config{Interactive: true}, "s\nst\nbt\nc\n")
+	for _, want := range []string{
+		"test.sol:6:9 (run)\n=> 6\tuint x = 1;\n",
+		"test.sol:7:9 (run)\n=> 7\trevert();\n",
+		"  0: 0x1\n",
+		"#0 " + common.BytesToAddress([]byte("contract")).Hex() + " test.sol:7:9 (run)\n",
+		"execution reverted at test.sol:7:9 (run)\n  revert();\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestDebuggerBreakpoint(t *testing.T) {
+	if _, err := NewDebugger(&Program{}, Config{Breakpoints: []string{"test.sol:7"}}, nil, nil); err == nil {
+		t.Fatal("expected error for unknown source")
+	}
+	out := runDebugger(t, Config{Breakpoints: []string{"test.sol:7"}}, "l\nd test.sol:7\nb\nq\n")
+	if strings.Contains(out, "=> 6\t") {
+		t.Errorf("stopped before the breakpoint:\n%s", out)
+	}
+	for _, want := range []string{
+		"=> 7\trevert();\n",
+		"   6\tuint x = 1;\n",
+		"no breakpoints\n",
+		"execution reverted at test.sol:7:9",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestDebuggerTrace(t *testing.T) {
+	out := runDebugger(t, Config{Trace: true}, "")
+	for _, want := range []string{
+		"0     PUSH1",
+		"test.sol:6:9\n      | uint x = 1;\n",
+		"6     REVERT",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "| revert();") != 1 {
+		t.Errorf("source line not printed once per change:\n%s", out)
+	}
+}
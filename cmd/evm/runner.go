@@ -30,6 +30,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/cmd/evm/internal/solc"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -39,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/triedb"
@@ -61,8 +63,14 @@ var runCommand = &cli.Command{
 		InputFlag,
 		InputFileFlag,
 		PriceFlag,
+		ProfileFlag,
 		ReceiverFlag,
 		SenderFlag,
+		SolcFlag,
+		SolcBasePathFlag,
+		SolcContractFlag,
+		SolcInteractiveFlag,
+		SolcBreakpointFlag,
 		ValueFlag,
 		StatDumpFlag,
 		DumpFlag,
@@ -107,6 +115,11 @@ var (
 		Value:    new(big.Int),
 		Category: flags.VMCategory,
 	}
+	ProfileFlag = &cli.StringFlag{
+		Name:     "profile",
+		Usage:    "File to write the gas profile to, in folded stack format for flamegraph tools",
+		Category: flags.VMCategory,
+	}
 	ReceiverFlag = &cli.StringFlag{
 		Name:     "receiver",
 		Usage:    "The transaction receiver (execution context)",
@@ -117,6 +130,31 @@ var (
 		Usage:    "The transaction origin",
 		Category: flags.VMCategory,
 	}
+	SolcFlag = &cli.StringFlag{
+		Name:     "solc",
+		Usage:    "File containing the solc standard-JSON output, to map the execution to the Solidity sources (--trace prints a source-annotated trace)",
+		Category: flags.VMCategory,
+	}
+	SolcBasePathFlag = &cli.StringFlag{
+		Name:     "solc.basepath",
+		Usage:    "Directory the source paths of the solc output are relative to",
+		Category: flags.VMCategory,
+	}
+	SolcContractFlag = &cli.StringFlag{
+		Name:     "solc.contract",
+		Usage:    "Contract of the solc output to run if no code is given, as file:Name or Name",
+		Category: flags.VMCategory,
+	}
+	SolcInteractiveFlag = &cli.BoolFlag{
+		Name:     "solc.interactive",
+		Usage:    "Step through the execution interactively, by source line",
+		Category: flags.VMCategory,
+	}
+	SolcBreakpointFlag = &cli.StringSliceFlag{
+		Name:     "solc.break",
+		Usage:    "Source line to stop the execution at for interactive debugging, as file:line",
+		Category: flags.VMCategory,
+	}
 	ValueFlag = &flags.BigFlag{
 		Name:     "value",
 		Usage:    "Value set for the evm",
@@ -207,7 +245,12 @@ func runCmd(ctx *cli.Context) error {
 		blobHashes  []common.Hash  // TODO (MariusVanDerWijden) implement blob hashes in state tests
 		blobBaseFee = new(big.Int) // TODO (MariusVanDerWijden) implement blob fee in state tests
 	)
-	tracer = tracerFromFlags(ctx)
+	program, debugger := debuggerFromFlags(ctx)
+	if debugger != nil {
+		tracer = debugger.Hooks()
+	} else {
+		tracer = tracerFromFlags(ctx)
+	}
 	initialGas := ctx.Uint64(GasFlag.Name)
 	genesisConfig := new(core.Genesis)
 	genesisConfig.GasLimit = initialGas
@@ -270,6 +313,20 @@ func runCmd(ctx *cli.Context) error {
 	}
 	code = common.FromHex(hexcode)
 
+	// Run the compiled contract if no code is given explicitly
+	if name := ctx.String(SolcContractFlag.Name); name != "" && len(code) == 0 {
+		if program == nil {
+			fmt.Println("--solc.contract requires --solc")
+			os.Exit(1)
+		}
+		contract, err := program.Contract(name, !ctx.Bool(CreateFlag.Name))
+		if err != nil {
+			fmt.Printf("Could not load code from solc output: %v\n", err)
+			os.Exit(1)
+		}
+		code = contract.Code
+	}
+
 	runtimeConfig := runtime.Config{
 		Origin:      sender,
 		State:       prestate,
@@ -294,6 +351,22 @@ func runCmd(ctx *cli.Context) error {
 		runtimeConfig.ChainConfig = params.AllEthashProtocolChanges
 	}
 
+	// Profile the gas usage of the execution if requested. The profiler can't
+	// be combined with the other tracers, nor accumulate the benchmark runs.
+	var profiler *tracers.Tracer
+	if ctx.String(ProfileFlag.Name) != "" {
+		if tracer != nil || ctx.Bool(BenchFlag.Name) {
+			fmt.Println("--profile can't be combined with tracing or benchmarking")
+			os.Exit(1)
+		}
+		var err error
+		if profiler, err = tracers.DefaultDirectory.New("gasProfiler", new(tracers.Context), nil, runtimeConfig.ChainConfig); err != nil {
+			fmt.Printf("Failed to create gas profiler: %v\n", err)
+			os.Exit(1)
+		}
+		runtimeConfig.EVMConfig.Tracer = profiler.Hooks
+	}
+
 	var hexInput []byte
 	if inputFileFlag := ctx.String(InputFileFlag.Name); inputFileFlag != "" {
 		var err error
@@ -335,6 +408,13 @@ func runCmd(ctx *cli.Context) error {
 	bench := ctx.Bool(BenchFlag.Name)
 	output, stats, err := timedExec(bench, execFunc)
 
+	if profiler != nil {
+		if err := writeProfile(profiler, ctx.String(ProfileFlag.Name)); err != nil {
+			fmt.Printf("Failed to write gas profile: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	if ctx.Bool(DumpFlag.Name) {
 		root, err := runtimeConfig.State.Commit(genesisConfig.Number, true, false)
 		if err != nil {
@@ -363,7 +443,7 @@ allocations:     %d
 allocated bytes: %d
 `, stats.GasUsed, stats.Time, stats.Allocs, stats.BytesAllocated)
 	}
-	if tracer == nil {
+	if tracer == nil || debugger != nil {
 		fmt.Printf("%#x\n", output)
 		if err != nil {
 			fmt.Printf(" error: %v\n", err)
@@ -373,6 +453,52 @@ allocated bytes: %d
 	return nil
 }
 
+// debuggerFromFlags loads the solc output and creates the source-level debugger
+// if requested, returning nils otherwise.
+func debuggerFromFlags(ctx *cli.Context) (*solc.Program, *solc.Debugger) {
+	if ctx.String(SolcFlag.Name) == "" {
+		if ctx.Bool(SolcInteractiveFlag.Name) || len(ctx.StringSlice(SolcBreakpointFlag.Name)) > 0 {
+			fmt.Println("source-level debugging requires --solc")
+			os.Exit(1)
+		}
+		return nil, nil
+	}
+	program, err := solc.Load(ctx.String(SolcFlag.Name), ctx.String(SolcBasePathFlag.Name))
+	if err != nil {
+		fmt.Printf("Could not load solc output: %v\n", err)
+		os.Exit(1)
+	}
+	config := solc.Config{
+		Trace:       ctx.Bool(TraceFlag.Name),
+		Interactive: ctx.Bool(SolcInteractiveFlag.Name),
+		Breakpoints: ctx.StringSlice(SolcBreakpointFlag.Name),
+	}
+	if (config.Interactive || len(config.Breakpoints) > 0) && ctx.String(CodeFileFlag.Name) == "-" {
+		fmt.Println("interactive debugging can't read the code from stdin")
+		os.Exit(1)
+	}
+	debugger, err := solc.NewDebugger(program, config, os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Printf("Could not create debugger: %v\n", err)
+		os.Exit(1)
+	}
+	return program, debugger
+}
+
+// writeProfile writes the folded stacks collected by the gas profiler to the
+// given file.
+func writeProfile(profiler *tracers.Tracer, path string) error {
+	result, err := profiler.GetResult()
+	if err != nil {
+		return err
+	}
+	var folded string
+	if err := json.Unmarshal(result, &folded); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(folded), 0644)
+}
+
 // writeLogs writes vm logs in a readable format to the given writer
 func writeLogs(writer io.Writer, logs []*types.Log) {
 	for _, log := range logs {
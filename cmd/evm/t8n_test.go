@@ -726,6 +726,30 @@ func TestEvmRunRegEx(t *testing.T) {
 	}
 }
 
+// TestEvmRunProfile tests that the gas profile of the run command is written
+// in the folded stack format.
+func TestEvmRunProfile(t *testing.T) {
+	t.Parallel()
+	tt := cmdtest.NewTestCmd(t, nil)
+	profile := filepath.Join(t.TempDir(), "profile.txt")
+
+	// SSTORE(0, 0x2a), MSTORE(0, 0x2a), RETURN(0, 32)
+	tt.Run("evm-test", "run", "--profile", profile, "--input", "0xa9059cbb", "602a600055602a60005260206000f3")
+	tt.WaitExit()
+
+	have, err := os.ReadFile(profile)
+	if err != nil {
+		t.Fatalf("could not read profile: %v", err)
+	}
+	want := `0x0000000000000000000000007265636569766572:0xa9059cbb;MSTORE 6
+0x0000000000000000000000007265636569766572:0xa9059cbb;PUSH1 18
+0x0000000000000000000000007265636569766572:0xa9059cbb;SSTORE 22100
+`
+	if string(have) != want {
+		t.Fatalf("profile mismatch, have\n%s\nwant\n%s", have, want)
+	}
+}
+
 // cmpJson compares the JSON in two byte slices.
 func cmpJson(a, b []byte) (bool, error) {
 	var j, j2 interface{}
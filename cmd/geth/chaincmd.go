@@ -30,6 +30,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	bparams "github.com/ethereum/go-ethereum/beacon/params"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -92,6 +93,7 @@ if one is set.  Otherwise it prints the genesis from the datadir.`,
 			utils.CacheGCFlag,
 			utils.CacheSnapshotFlag,
 			utils.CacheNoPrefetchFlag,
+			utils.ParallelExecutionFlag,
 			utils.CachePreimagesFlag,
 			utils.NoCompactionFlag,
 			utils.MetricsEnabledFlag,
@@ -111,6 +113,7 @@ if one is set.  Otherwise it prints the genesis from the datadir.`,
 			utils.TxLookupLimitFlag,
 			utils.VMTraceFlag,
 			utils.VMTraceJsonConfigFlag,
+			utils.VMBlockAccessListFlag,
 			utils.TransactionHistoryFlag,
 			utils.LogHistoryFlag,
 			utils.LogNoHistoryFlag,
@@ -147,10 +150,13 @@ be gzipped.`,
 		Name:      "import-history",
 		Usage:     "Import an Era archive",
 		ArgsUsage: "<dir>",
-		Flags:     slices.Concat([]cli.Flag{utils.TxLookupLimitFlag, utils.TransactionHistoryFlag}, utils.DatabaseFlags, utils.NetworkFlags),
+		Flags:     slices.Concat([]cli.Flag{utils.TxLookupLimitFlag, utils.TransactionHistoryFlag, utils.EraRootsFlag}, utils.DatabaseFlags, utils.NetworkFlags),
 		Description: `
 The import-history command will import blocks and their corresponding receipts
-from Era archives.
+from Era archives. The history is read from the Era1 files (.era1), followed by
+the post-merge Era files (.erae) if present. The accumulator roots of the files
+are verified against the trusted roots listed in the --era.roots file, in the
+same order as the files.
 `,
 	}
 	exportHistoryCommand = &cli.Command{
@@ -161,7 +167,10 @@ from Era archives.
 		Flags:     utils.DatabaseFlags,
 		Description: `
 The export-history command will export blocks and their corresponding receipts
-into Era archives. Eras are typically packaged in steps of 8192 blocks.
+into Era archives. The blocks are written to Era1 files (.era1) in steps of 8192
+blocks, up to the first slot era of the beacon chain in which every block records
+its parent beacon root. The later blocks are written to post-merge Era files
+(.erae), one per complete slot era of 8192 slots.
 `,
 	}
 	importPreimagesCommand = &cli.Command{
@@ -200,11 +209,17 @@ This command dumps out the state for a given block (or latest, if none provided)
 		Name:      "prune-history",
 		Usage:     "Prune blockchain history (block bodies and receipts) up to the merge block",
 		ArgsUsage: "",
-		Flags:     utils.DatabaseFlags,
+		Flags: slices.Concat(utils.DatabaseFlags, []cli.Flag{
+			utils.ChainHistoryFlag,
+			utils.ChainHistoryRecentFlag,
+		}),
 		Description: `
 The prune-history command removes historical block bodies and receipts from the
 blockchain database up to the merge block, while preserving block headers. This
-helps reduce storage requirements for nodes that don't need full historical data.`,
+helps reduce storage requirements for nodes that don't need full historical data.
+
+If --history.chain=recent is specified, the history is pruned up to the start of
+the retention window configured by --history.chain.recent instead.`,
 	}
 
 	downloadEraCommand = &cli.Command{
@@ -502,7 +517,14 @@ func importHistory(ctx *cli.Context) error {
 		network = networks[0]
 	}
 
-	if err := utils.ImportHistory(chain, dir, network); err != nil {
+	if !ctx.IsSet(utils.EraRootsFlag.Name) {
+		return fmt.Errorf("missing trusted era roots, use --%s", utils.EraRootsFlag.Name)
+	}
+	roots, err := utils.ReadHistoryRoots(ctx.String(utils.EraRootsFlag.Name))
+	if err != nil {
+		return fmt.Errorf("error reading era roots: %w", err)
+	}
+	if err := utils.ImportHistory(chain, dir, network, roots); err != nil {
 		return err
 	}
 	fmt.Printf("Import done in %v\n", time.Since(start))
@@ -536,7 +558,20 @@ func exportHistory(ctx *cli.Context) error {
 	if head := chain.CurrentSnapBlock(); uint64(last) > head.Number.Uint64() {
 		utils.Fatalf("Export error: block number %d larger than head block %d\n", uint64(last), head.Number.Uint64())
 	}
-	err := utils.ExportHistory(chain, dir, uint64(first), uint64(last), uint64(era.MaxEra1Size))
+	// The beacon chain genesis is needed to group the post-merge blocks by slot
+	// era, it's only known for the preset networks.
+	var beaconGenesis uint64
+	switch chain.Config().ChainID.Uint64() {
+	case params.MainnetChainConfig.ChainID.Uint64():
+		beaconGenesis = bparams.MainnetLightConfig.GenesisTime
+	case params.SepoliaChainConfig.ChainID.Uint64():
+		beaconGenesis = bparams.SepoliaLightConfig.GenesisTime
+	case params.HoleskyChainConfig.ChainID.Uint64():
+		beaconGenesis = bparams.HoleskyLightConfig.GenesisTime
+	case params.HoodiChainConfig.ChainID.Uint64():
+		beaconGenesis = bparams.HoodiLightConfig.GenesisTime
+	}
+	err := utils.ExportHistory(chain, dir, uint64(first), uint64(last), uint64(era.MaxEra1Size), beaconGenesis)
 	if err != nil {
 		utils.Fatalf("Export error: %v\n", err)
 	}
@@ -665,6 +700,14 @@ func pruneHistory(ctx *cli.Context) error {
 	defer chaindb.Close()
 	defer chain.Stop()
 
+	currentHeader := chain.CurrentHeader()
+	if currentHeader == nil {
+		return errors.New("current header not found")
+	}
+	if ctx.String(utils.ChainHistoryFlag.Name) == history.KeepRecent.String() {
+		return pruneRecentHistory(ctx, chaindb, currentHeader.Number.Uint64())
+	}
+
 	// Determine the prune point. This will be the first PoS block.
 	prunePoint, ok := history.PrunePoints[chain.Genesis().Hash()]
 	if !ok || prunePoint == nil {
@@ -676,10 +719,6 @@ func pruneHistory(ctx *cli.Context) error {
 	)
 
 	// Check we're far enough past merge to ensure all data is in freezer
-	currentHeader := chain.CurrentHeader()
-	if currentHeader == nil {
-		return errors.New("current header not found")
-	}
 	if currentHeader.Number.Uint64() < mergeBlock+params.FullImmutabilityThreshold {
 		return fmt.Errorf("chain not far enough past merge block, need %d more blocks",
 			mergeBlock+params.FullImmutabilityThreshold-currentHeader.Number.Uint64())
@@ -704,6 +743,41 @@ func pruneHistory(ctx *cli.Context) error {
 	return nil
 }
 
+// pruneRecentHistory prunes the chain history up to the start of the configured
+// retention window, keeping only the bodies and receipts of the recent blocks.
+func pruneRecentHistory(ctx *cli.Context, chaindb ethdb.Database, head uint64) error {
+	limit := ctx.Uint64(utils.ChainHistoryRecentFlag.Name)
+	if limit < params.FullImmutabilityThreshold {
+		return fmt.Errorf("history retention window %d is below the immutability threshold %d", limit, params.FullImmutabilityThreshold)
+	}
+	if head+1 <= limit {
+		return fmt.Errorf("chain not long enough to prune, need %d more blocks", limit-head-1)
+	}
+	// Only the blocks already moved into the freezer can be pruned.
+	frozen, err := chaindb.Ancients()
+	if err != nil {
+		return err
+	}
+	target := min(head+1-limit, frozen)
+
+	tail, err := chaindb.Tail()
+	if err != nil {
+		return err
+	}
+	if target <= tail {
+		log.Info("Chain history is already pruned", "tail", tail)
+		return nil
+	}
+	log.Info("Starting history pruning", "head", head, "tail", target, "window", limit)
+	start := time.Now()
+	rawdb.PruneTransactionIndex(chaindb, target)
+	if _, err := chaindb.TruncateTail(target); err != nil {
+		return fmt.Errorf("failed to truncate ancient data: %v", err)
+	}
+	log.Info("History pruning completed", "tail", target, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
 // downladEra is the era1 file downloader tool.
 func downloadEra(ctx *cli.Context) error {
 	flags.CheckExclusive(ctx, eraBlockFlag, eraEpochFlag, eraAllFlag)
@@ -0,0 +1,151 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	statelessGenesisFlag = &cli.StringFlag{
+		Name:  "genesis",
+		Usage: "Genesis file of the chain the block belongs to, overriding the network flags",
+	}
+
+	statelessCommand = &cli.Command{
+		Name:  "stateless",
+		Usage: "A set of commands for the stateless block execution",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "verify",
+				Usage:     "Re-execute a block against its witness without any database",
+				ArgsUsage: "<block> <witness>",
+				Action:    verifyStateless,
+				Flags:     slices.Concat([]cli.Flag{statelessGenesisFlag}, utils.NetworkFlags),
+				Description: `
+geth stateless verify <block> <witness>
+
+This command re-executes the given block purely based on the supplied witness,
+without accessing any local database, and reports the computed state root and
+receipt root along with whether they match the ones in the block header.
+
+The block and the witness are both expected to be RLP encoded, either as raw
+binary or as hex strings. The witness may also be given in the JSON format as
+returned by debug_executionWitness. The chain configuration is selected by the network
+flags (mainnet by default) or loaded from the genesis file if specified.`,
+			},
+		},
+	}
+)
+
+// verifyStateless re-executes a block statelessly based on the given witness.
+func verifyStateless(ctx *cli.Context) error {
+	if ctx.Args().Len() != 2 {
+		utils.Fatalf("This command requires two arguments.")
+	}
+	config, err := statelessChainConfig(ctx)
+	if err != nil {
+		return err
+	}
+	block := new(types.Block)
+	if err := readRLPFile(ctx.Args().Get(0), block); err != nil {
+		return fmt.Errorf("failed to load block: %v", err)
+	}
+	witness, err := readWitnessFile(ctx.Args().Get(1))
+	if err != nil {
+		return fmt.Errorf("failed to load witness: %v", err)
+	}
+	stateRoot, receiptRoot, err := core.VerifyStateless(config, vm.Config{}, block, witness)
+
+	fmt.Printf("Block:        %d (%#x)\n", block.NumberU64(), block.Hash())
+	fmt.Printf("State root:   %#x (header: %#x)\n", stateRoot, block.Root())
+	fmt.Printf("Receipt root: %#x (header: %#x)\n", receiptRoot, block.ReceiptHash())
+	if err != nil {
+		return fmt.Errorf("stateless verification failed: %v", err)
+	}
+	fmt.Println("Stateless verification succeeded")
+	return nil
+}
+
+// statelessChainConfig returns the chain configuration to execute the block
+// with, loaded from the genesis file or selected by the network flags.
+func statelessChainConfig(ctx *cli.Context) (*params.ChainConfig, error) {
+	if path := ctx.String(statelessGenesisFlag.Name); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		genesis := new(core.Genesis)
+		if err := json.NewDecoder(file).Decode(genesis); err != nil {
+			return nil, fmt.Errorf("invalid genesis file: %v", err)
+		}
+		return genesis.Config, nil
+	}
+	if genesis := utils.MakeGenesis(ctx); genesis != nil {
+		return genesis.Config, nil
+	}
+	return params.MainnetChainConfig, nil
+}
+
+// readRLPFile decodes the RLP content of the given file, which is either raw
+// binary or hex encoded.
+func readRLPFile(path string, val interface{}) error {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if text := bytes.TrimSpace(blob); bytes.HasPrefix(text, []byte("0x")) {
+		if blob, err = hexutil.Decode(string(text)); err != nil {
+			return err
+		}
+	}
+	return rlp.DecodeBytes(blob, val)
+}
+
+// readWitnessFile loads the witness from the given file, which is either in the
+// JSON format or RLP encoded.
+func readWitnessFile(path string) (*stateless.Witness, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	witness := new(stateless.Witness)
+	if !bytes.HasPrefix(bytes.TrimSpace(blob), []byte("{")) {
+		return witness, readRLPFile(path, witness)
+	}
+	var ext stateless.ExtWitness
+	if err := json.Unmarshal(blob, &ext); err != nil {
+		return nil, err
+	}
+	return witness, witness.FromExtWitness(&ext)
+}
@@ -35,6 +35,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
@@ -48,6 +49,7 @@ import (
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/urfave/cli/v2"
 )
 
@@ -245,10 +247,33 @@ func readList(filename string) ([]string, error) {
 	return strings.Split(string(b), "\n"), nil
 }
 
+// ReadHistoryRoots reads the trusted accumulator roots of the Era files to be
+// imported from a file, one hex encoded root per line.
+func ReadHistoryRoots(filename string) ([]common.Hash, error) {
+	lines, err := readList(filename)
+	if err != nil {
+		return nil, err
+	}
+	var roots []common.Hash
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		b, err := hexutil.Decode(line)
+		if err != nil || len(b) != common.HashLength {
+			return nil, fmt.Errorf("invalid root at line %d: %q", i+1, line)
+		}
+		roots = append(roots, common.BytesToHash(b))
+	}
+	return roots, nil
+}
+
 // ImportHistory imports Era1 files containing historical block information,
-// starting from genesis. The assumption is held that the provided chain
-// segment in Era1 file should all be canonical and verified.
-func ImportHistory(chain *core.BlockChain, dir string, network string) error {
+// starting from genesis, followed by the post-merge Era files if present. The
+// accumulator of each file is checked against the given trusted roots, ordered
+// as the files, and the contents of the file are verified against it.
+func ImportHistory(chain *core.BlockChain, dir string, network string, roots []common.Hash) error {
 	if chain.CurrentSnapBlock().Number.BitLen() != 0 {
 		return errors.New("history import only supported when starting from genesis")
 	}
@@ -263,6 +288,26 @@ func ImportHistory(chain *core.BlockChain, dir string, network string) error {
 	if len(checksums) != len(entries) {
 		return fmt.Errorf("expected equal number of checksums and entries, have: %d checksums, %d entries", len(checksums), len(entries))
 	}
+	// The post-merge history is archived separately, following the pre-merge
+	// one. It's optional, import it only if exists.
+	postMergeEntries, err := era.ReadPostMergeDir(dir, network)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", dir, err)
+	}
+	if len(postMergeEntries) > 0 {
+		postMergeChecksums, err := readList(filepath.Join(dir, "checksums_erae.txt"))
+		if err != nil {
+			return fmt.Errorf("unable to read checksums_erae.txt: %w", err)
+		}
+		if len(postMergeChecksums) != len(postMergeEntries) {
+			return fmt.Errorf("expected equal number of post-merge checksums and entries, have: %d checksums, %d entries", len(postMergeChecksums), len(postMergeEntries))
+		}
+		entries = append(entries, postMergeEntries...)
+		checksums = append(checksums, postMergeChecksums...)
+	}
+	if len(roots) != len(entries) {
+		return fmt.Errorf("expected equal number of trusted roots and entries, have: %d roots, %d entries", len(roots), len(entries))
+	}
 	var (
 		start    = time.Now()
 		reported = time.Now()
@@ -288,11 +333,23 @@ func ImportHistory(chain *core.BlockChain, dir string, network string) error {
 			h.Reset()
 			buf.Reset()
 
-			// Import all block data from Era1.
+			// Validate the accumulator against the trusted root, and the
+			// contents against the accumulator.
 			e, err := era.From(f)
 			if err != nil {
 				return fmt.Errorf("error opening era: %w", err)
 			}
+			root, err := e.Accumulator()
+			if err != nil {
+				return fmt.Errorf("error reading accumulator: %w", err)
+			}
+			if root != roots[i] {
+				return fmt.Errorf("accumulator mismatch in %s: have %s, want %s", filename, root, roots[i])
+			}
+			if err := era.Verify(e, trie.NewStackTrie(nil)); err != nil {
+				return fmt.Errorf("error verifying %s: %w", filename, err)
+			}
+			// Import all block data from Era.
 			it, err := era.NewIterator(e)
 			if err != nil {
 				return fmt.Errorf("error making era reader: %w", err)
@@ -402,8 +459,16 @@ func ExportAppendChain(blockchain *core.BlockChain, fn string, first uint64, las
 }
 
 // ExportHistory exports blockchain history into the specified directory,
-// following the Era format.
-func ExportHistory(bc *core.BlockChain, dir string, first, last, step uint64) error {
+// following the Era format. The blocks are archived in Era1 files up to the
+// first slot era in which every block records its parent beacon root, since
+// then they are archived per slot era in the post-merge Era files. Only the
+// complete slot eras are exported, as the beacon root of the last block of an
+// era is recorded by the first block of the next one.
+//
+// The genesis time of the beacon chain is required to place the post-merge
+// blocks in their slot eras. If it's unknown (zero), all the blocks are archived
+// in Era1 files.
+func ExportHistory(bc *core.BlockChain, dir string, first, last, step, beaconGenesis uint64) error {
 	log.Info("Exporting blockchain history", "dir", dir)
 	if head := bc.CurrentBlock().Number.Uint64(); head < last {
 		log.Warn("Last block beyond head, setting last = head", "head", head, "last", last)
@@ -416,79 +481,193 @@ func ExportHistory(bc *core.BlockChain, dir string, first, last, step uint64) er
 	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
 		return fmt.Errorf("error creating output directory: %w", err)
 	}
+	split, err := historySplit(bc, first, last, beaconGenesis)
+	if err != nil {
+		return err
+	}
 	var (
-		start     = time.Now()
-		reported  = time.Now()
-		h         = sha256.New()
-		buf       = bytes.NewBuffer(nil)
-		checksums []string
+		start              = time.Now()
+		reported           = time.Now()
+		checksums          []string
+		postMergeChecksums []string
 	)
 	td := new(big.Int)
 	for i := uint64(0); i < first; i++ {
 		td.Add(td, bc.GetHeaderByNumber(i).Difficulty)
 	}
-	for i := first; i <= last; i += step {
-		err := func() error {
-			filename := filepath.Join(dir, era.Filename(network, int(i/step), common.Hash{}))
-			f, err := os.Create(filename)
-			if err != nil {
-				return fmt.Errorf("could not create era file: %w", err)
-			}
-			defer f.Close()
+	// Export the blocks preceding the split into Era1 files.
+	for i := first; i < split; i += step {
+		epoch := int(i / step)
+		checksum, err := exportEra(bc, dir, func(root common.Hash) string {
+			return era.Filename(network, epoch, root)
+		}, era.NewBuilder, i, min(i+step-1, split-1), td)
+		if err != nil {
+			return err
+		}
+		checksums = append(checksums, checksum)
 
-			w := era.NewBuilder(f)
-			for j := uint64(0); j < step && j <= last-i; j++ {
-				var (
-					n     = i + j
-					block = bc.GetBlockByNumber(n)
-				)
-				if block == nil {
-					return fmt.Errorf("export failed on #%d: not found", n)
-				}
-				receipts := bc.GetReceiptsByHash(block.Hash())
-				if receipts == nil {
-					return fmt.Errorf("export failed on #%d: receipts not found", n)
-				}
-				td.Add(td, block.Difficulty())
-				if err := w.Add(block, receipts, new(big.Int).Set(td)); err != nil {
-					return err
-				}
+		if time.Since(reported) >= 8*time.Second {
+			log.Info("Exporting blocks", "exported", i, "elapsed", common.PrettyDuration(time.Since(start)))
+			reported = time.Now()
+		}
+	}
+	// Export the remaining blocks into post-merge Era files, one per slot era.
+	for i := split; i <= last; {
+		header := bc.GetHeaderByNumber(i)
+		if header == nil {
+			return fmt.Errorf("export failed on #%d: not found", i)
+		}
+		slot, err := headerSlot(header, beaconGenesis)
+		if err != nil {
+			return err
+		}
+		// Find the first block of the next slot era, which records the beacon
+		// root of the last block of this one.
+		var (
+			epoch = slot / era.SlotsPerEra
+			end   = i
+			next  *types.Header
+		)
+		for {
+			if next = bc.GetHeaderByNumber(end + 1); next == nil {
+				break
 			}
-			root, err := w.Finalize()
+			nextSlot, err := headerSlot(next, beaconGenesis)
 			if err != nil {
-				return fmt.Errorf("export failed to finalize %d: %w", step/i, err)
-			}
-			// Set correct filename with root.
-			os.Rename(filename, filepath.Join(dir, era.Filename(network, int(i/step), root)))
-
-			// Compute checksum of entire Era1.
-			if _, err := f.Seek(0, io.SeekStart); err != nil {
 				return err
 			}
-			if _, err := io.Copy(h, f); err != nil {
-				return fmt.Errorf("unable to calculate checksum: %w", err)
+			if nextSlot/era.SlotsPerEra != epoch || end == last {
+				break
 			}
-			checksums = append(checksums, common.BytesToHash(h.Sum(buf.Bytes()[:])).Hex())
-			h.Reset()
-			buf.Reset()
-			return nil
-		}()
+			end++
+		}
+		if next == nil || next.Time < beaconGenesis+(epoch+1)*era.SlotsPerEra*era.SecondsPerSlot {
+			log.Warn("Skipping incomplete slot era", "epoch", epoch, "first", i, "last", end)
+			break
+		}
+		if next.ParentBeaconRoot == nil {
+			return fmt.Errorf("export failed on #%d: parent beacon root not found", next.Number)
+		}
+		newBuilder := func(w io.Writer) *era.Builder {
+			b := era.NewPostMergeBuilder(w, slot)
+			b.SetLastBeaconRoot(*next.ParentBeaconRoot)
+			return b
+		}
+		checksum, err := exportEra(bc, dir, func(root common.Hash) string {
+			return era.PostMergeFilename(network, int(epoch), root)
+		}, newBuilder, i, end, nil)
 		if err != nil {
 			return err
 		}
+		postMergeChecksums = append(postMergeChecksums, checksum)
+		i = end + 1
+
 		if time.Since(reported) >= 8*time.Second {
 			log.Info("Exporting blocks", "exported", i, "elapsed", common.PrettyDuration(time.Since(start)))
 			reported = time.Now()
 		}
 	}
 
-	os.WriteFile(filepath.Join(dir, "checksums.txt"), []byte(strings.Join(checksums, "\n")), os.ModePerm)
-
+	if len(checksums) > 0 {
+		os.WriteFile(filepath.Join(dir, "checksums.txt"), []byte(strings.Join(checksums, "\n")), os.ModePerm)
+	}
+	if len(postMergeChecksums) > 0 {
+		os.WriteFile(filepath.Join(dir, "checksums_erae.txt"), []byte(strings.Join(postMergeChecksums, "\n")), os.ModePerm)
+	}
 	log.Info("Exported blockchain to", "dir", dir)
 
 	return nil
 }
 
+// historySplit returns the first block in range [first, last] starting a slot
+// era in which every block records its parent beacon root, or last+1 if there's
+// no such block.
+func historySplit(bc *core.BlockChain, first, last, beaconGenesis uint64) (uint64, error) {
+	if beaconGenesis == 0 {
+		return last + 1, nil
+	}
+	for n := first; n <= last; n++ {
+		header := bc.GetHeaderByNumber(n)
+		if header == nil {
+			return 0, fmt.Errorf("export failed on #%d: not found", n)
+		}
+		if header.ParentBeaconRoot == nil {
+			continue
+		}
+		slot, err := headerSlot(header, beaconGenesis)
+		if err != nil {
+			return 0, err
+		}
+		// The block summary root can only be reconstructed for the whole slot
+		// era, skip the blocks sharing the era with a preceding one.
+		if n > 0 {
+			parent := bc.GetHeaderByNumber(n - 1)
+			if parent.Time >= beaconGenesis && (parent.Time-beaconGenesis)/era.SecondsPerSlot/era.SlotsPerEra == slot/era.SlotsPerEra {
+				continue
+			}
+		}
+		return n, nil
+	}
+	return last + 1, nil
+}
+
+// headerSlot returns the beacon chain slot of a post-merge block.
+func headerSlot(header *types.Header, beaconGenesis uint64) (uint64, error) {
+	if header.Time < beaconGenesis || (header.Time-beaconGenesis)%era.SecondsPerSlot != 0 {
+		return 0, fmt.Errorf("block %d timestamp %d not on a slot boundary", header.Number, header.Time)
+	}
+	return (header.Time - beaconGenesis) / era.SecondsPerSlot, nil
+}
+
+// exportEra archives the blocks in range [first, last] into a single Era file
+// named by the given function and written by the given builder, returning the
+// checksum of the file. The total difficulty is accumulated into td for
+// pre-merge archives, while nil td indicates a post-merge archive.
+func exportEra(bc *core.BlockChain, dir string, name func(root common.Hash) string, newBuilder func(w io.Writer) *era.Builder, first, last uint64, td *big.Int) (string, error) {
+	filename := filepath.Join(dir, name(common.Hash{}))
+	f, err := os.Create(filename)
+	if err != nil {
+		return "", fmt.Errorf("could not create era file: %w", err)
+	}
+	defer f.Close()
+
+	w := newBuilder(f)
+	for n := first; n <= last; n++ {
+		block := bc.GetBlockByNumber(n)
+		if block == nil {
+			return "", fmt.Errorf("export failed on #%d: not found", n)
+		}
+		receipts := bc.GetReceiptsByHash(block.Hash())
+		if receipts == nil {
+			return "", fmt.Errorf("export failed on #%d: receipts not found", n)
+		}
+		var btd *big.Int
+		if td != nil {
+			td.Add(td, block.Difficulty())
+			btd = new(big.Int).Set(td)
+		}
+		if err := w.Add(block, receipts, btd); err != nil {
+			return "", err
+		}
+	}
+	root, err := w.Finalize()
+	if err != nil {
+		return "", fmt.Errorf("export failed to finalize %d: %w", first, err)
+	}
+	// Set correct filename with root.
+	os.Rename(filename, filepath.Join(dir, name(root)))
+
+	// Compute checksum of entire Era.
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("unable to calculate checksum: %w", err)
+	}
+	return common.BytesToHash(h.Sum(nil)).Hex(), nil
+}
+
 // ImportPreimages imports a batch of exported hash preimages into the database.
 // It's a part of the deprecated functionality, should be removed in the future.
 func ImportPreimages(db ethdb.Database, fn string) error {
@@ -65,6 +65,7 @@ import (
 	"github.com/ethereum/go-ethereum/metrics/exp"
 	"github.com/ethereum/go-ethereum/metrics/influxdb"
 	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/miner/builder"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -116,6 +117,12 @@ var (
 		Usage:    "Root directory for era1 history (default = inside ancient/chain)",
 		Category: flags.EthCategory,
 	}
+	EraRootsFlag = &cli.StringFlag{
+		Name:      "era.roots",
+		Usage:     "File with the trusted accumulator roots of the imported era files, one per line",
+		TakesFile: true,
+		Category:  flags.EthCategory,
+	}
 	MinFreeDiskSpaceFlag = &flags.DirectoryFlag{
 		Name:     "datadir.minfreedisk",
 		Usage:    "Minimum free disk space in MB, once reached triggers auto shut down (default = --cache.gc converted to MB, 0 = disabled)",
@@ -261,7 +268,7 @@ var (
 	}
 	GCModeFlag = &cli.StringFlag{
 		Name:     "gcmode",
-		Usage:    `Blockchain garbage collection mode, only relevant in state.scheme=hash ("full", "archive")`,
+		Usage:    `Blockchain garbage collection mode ("full", "archive"), archive mode in state.scheme=path serves historical state from the indexed state histories`,
 		Value:    "full",
 		Category: flags.StateCategory,
 	}
@@ -272,10 +279,16 @@ var (
 	}
 	StateHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.state",
-		Usage:    "Number of recent blocks to retain state history for, only relevant in state.scheme=path (default = 90,000 blocks, 0 = entire chain)",
+		Usage:    "Number of recent blocks to retain state history for, only relevant in state.scheme=path; also bounds the historical state served in archive mode (default = 90,000 blocks, 0 = entire chain)",
 		Value:    ethconfig.Defaults.StateHistory,
 		Category: flags.StateCategory,
 	}
+	WitnessHistoryFlag = &cli.Uint64Flag{
+		Name:     "history.witness",
+		Usage:    "Number of recent blocks to archive the execution witnesses for (default = 0, disabled)",
+		Value:    ethconfig.Defaults.WitnessHistory,
+		Category: flags.StateCategory,
+	}
 	TransactionHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.transactions",
 		Usage:    "Number of recent blocks to maintain transactions index for (default = about one year, 0 = entire chain)",
@@ -284,10 +297,16 @@ var (
 	}
 	ChainHistoryFlag = &cli.StringFlag{
 		Name:     "history.chain",
-		Usage:    `Blockchain history retention ("all" or "postmerge")`,
+		Usage:    `Blockchain history retention ("all", "postmerge" or "recent")`,
 		Value:    ethconfig.Defaults.HistoryMode.String(),
 		Category: flags.StateCategory,
 	}
+	ChainHistoryRecentFlag = &cli.Uint64Flag{
+		Name:     "history.chain.recent",
+		Usage:    "Number of recent blocks to retain bodies and receipts for, only relevant in history.chain=recent (default = about one year)",
+		Value:    ethconfig.Defaults.HistoryRecent,
+		Category: flags.StateCategory,
+	}
 	LogHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.logs",
 		Usage:    "Number of recent blocks to maintain log search index for (default = about one year, 0 = entire chain)",
@@ -427,6 +446,34 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolSnapshotFlag = &cli.StringFlag{
+		Name:     "txpool.snapshot",
+		Usage:    "Disk snapshot of all pooled non-blob transactions to survive node restarts (blob transactions persist in the blobpool datadir)",
+		Value:    ethconfig.Defaults.TxPool.Snapshot,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSnapshotIntervalFlag = &cli.DurationFlag{
+		Name:     "txpool.snapshotinterval",
+		Usage:    "Time interval to regenerate the transaction pool snapshot (0 = only at shutdown)",
+		Value:    ethconfig.Defaults.TxPool.SnapshotInterval,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolPrivateBuildersFlag = &cli.StringSliceFlag{
+		Name:     "txpool.privatebuilders",
+		Usage:    "Comma separated RPC endpoints of the block builders to forward private transactions to",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolPeerSlotsFlag = &cli.Uint64Flag{
+		Name:     "txpool.peerslots",
+		Usage:    "Maximum number of transactions admitted from a single peer residing in the pool (0 = unlimited)",
+		Value:    ethconfig.Defaults.TxPool.PeerSlots,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolCostEvictionFlag = &cli.BoolFlag{
+		Name:     "txpool.costeviction",
+		Usage:    "Evict the senders paying the lowest average tip per gas first when the pool is full, instead of equalizing the senders",
+		Category: flags.TxPoolCategory,
+	}
 	// Blob transaction pool settings
 	BlobPoolDataDirFlag = &cli.StringFlag{
 		Name:     "blobpool.datadir",
@@ -482,6 +529,11 @@ var (
 		Usage:    "Disable heuristic state prefetch during block import (less CPU and disk IO, more time waiting for data)",
 		Category: flags.PerfCategory,
 	}
+	ParallelExecutionFlag = &cli.BoolFlag{
+		Name:     "parallel",
+		Usage:    "Execute the block transactions optimistically in parallel during block import",
+		Category: flags.PerfCategory,
+	}
 	CachePreimagesFlag = &cli.BoolFlag{
 		Name:     "cache.preimages",
 		Usage:    "Enable recording the SHA3/keccak preimages of trie keys",
@@ -529,11 +581,27 @@ var (
 		Value:    ethconfig.Defaults.Miner.Recommit,
 		Category: flags.MinerCategory,
 	}
+	MinerStrategyFlag = &cli.StringFlag{
+		Name:     "miner.strategy",
+		Usage:    "Block building strategy ordering the included transactions (greedy, profit)",
+		Value:    ethconfig.Defaults.Miner.Strategy,
+		Category: flags.MinerCategory,
+	}
 	MinerPendingFeeRecipientFlag = &cli.StringFlag{
 		Name:     "miner.pending.feeRecipient",
 		Usage:    "0x prefixed public address for the pending block producer (not used for actual block production)",
 		Category: flags.MinerCategory,
 	}
+	BuilderRelaysFlag = &cli.StringSliceFlag{
+		Name:     "builder.relays",
+		Usage:    "Comma separated list of relay endpoints to submit the built payloads to",
+		Category: flags.MinerCategory,
+	}
+	BuilderSecretKeyFlag = &cli.PathFlag{
+		Name:     "builder.secretkey",
+		Usage:    "File containing the hex encoded BLS secret key signing the builder bids",
+		Category: flags.MinerCategory,
+	}
 
 	// Account settings
 	PasswordFileFlag = &cli.PathFlag{
@@ -556,7 +624,7 @@ var (
 	}
 	VMTraceFlag = &cli.StringFlag{
 		Name:     "vmtrace",
-		Usage:    "Name of tracer which should record internal VM operations (costly)",
+		Usage:    "Name of tracer which should record internal VM operations (costly), or path of a Go plugin (.so) exporting one",
 		Category: flags.VMCategory,
 	}
 	VMTraceJsonConfigFlag = &cli.StringFlag{
@@ -565,6 +633,11 @@ var (
 		Value:    "{}",
 		Category: flags.VMCategory,
 	}
+	VMBlockAccessListFlag = &cli.BoolFlag{
+		Name:     "vm.blockaccesslist",
+		Usage:    "Generate block access lists (EIP-7928) on import and validate them against the execution (costly)",
+		Category: flags.VMCategory,
+	}
 	// API options.
 	RPCGlobalGasCapFlag = &cli.Uint64Flag{
 		Name:     "rpc.gascap",
@@ -698,6 +771,12 @@ var (
 		Value:    strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
 		Category: flags.APICategory,
 	}
+	GraphQLCostLimitFlag = &cli.Uint64Flag{
+		Name:     "graphql.costlimit",
+		Usage:    "Maximum cost of a GraphQL query, expensive fields like traces weigh more (0 = no limit)",
+		Value:    node.DefaultConfig.GraphQLCostLimit,
+		Category: flags.APICategory,
+	}
 	WSEnabledFlag = &cli.BoolFlag{
 		Name:     "ws",
 		Usage:    "Enable the WS-RPC server",
@@ -760,6 +839,26 @@ var (
 		Value:    node.DefaultConfig.BatchResponseMaxSize,
 		Category: flags.APICategory,
 	}
+	RPCRateLimitFlag = &cli.StringFlag{
+		Name:     "rpc.ratelimit",
+		Usage:    "Comma separated list of per-client limits on the HTTP and WebSocket endpoints as namespace=rate[:burst[:concurrency]] ('*' for all other namespaces)",
+		Category: flags.APICategory,
+	}
+	RPCRateLimitCostsFlag = &cli.StringFlag{
+		Name:     "rpc.ratelimit.costs",
+		Usage:    "Comma separated list of rate limit tokens taken by specific methods as method=cost (others take one)",
+		Category: flags.APICategory,
+	}
+	RPCRateLimitKeyHeaderFlag = &cli.StringFlag{
+		Name:     "rpc.ratelimit.keyheader",
+		Usage:    "HTTP header carrying the API key identifying clients for rate limiting (default: client IP)",
+		Category: flags.APICategory,
+	}
+	RPCRateLimitKeysFlag = &cli.StringFlag{
+		Name:     "rpc.ratelimit.keys",
+		Usage:    "File containing the API keys accepted in the rate limit key header, one per line (others are limited by client IP)",
+		Category: flags.APICategory,
+	}
 
 	// Network Settings
 	MaxPeersFlag = &cli.IntFlag{
@@ -1215,6 +1314,90 @@ func setGraphQL(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(GraphQLVirtualHostsFlag.Name) {
 		cfg.GraphQLVirtualHosts = SplitAndTrim(ctx.String(GraphQLVirtualHostsFlag.Name))
 	}
+	if ctx.IsSet(GraphQLCostLimitFlag.Name) {
+		cfg.GraphQLCostLimit = ctx.Uint64(GraphQLCostLimitFlag.Name)
+	}
+}
+
+// setRPCRateLimits configures the rate limiting of the RPC endpoints from the
+// set command line flags.
+func setRPCRateLimits(ctx *cli.Context, cfg *node.Config) {
+	if ctx.IsSet(RPCRateLimitFlag.Name) {
+		cfg.RPCRateLimits.Namespaces = nil
+		for _, entry := range SplitAndTrim(ctx.String(RPCRateLimitFlag.Name)) {
+			namespace, spec, ok := strings.Cut(entry, "=")
+			if !ok || namespace == "" {
+				Fatalf("Invalid --%s entry: %s", RPCRateLimitFlag.Name, entry)
+			}
+			limit, err := parseRateLimit(spec)
+			if err != nil {
+				Fatalf("Invalid --%s entry %s: %v", RPCRateLimitFlag.Name, entry, err)
+			}
+			if namespace == "*" {
+				cfg.RPCRateLimits.Default = limit
+				continue
+			}
+			if cfg.RPCRateLimits.Namespaces == nil {
+				cfg.RPCRateLimits.Namespaces = make(map[string]rpc.RateLimit)
+			}
+			cfg.RPCRateLimits.Namespaces[namespace] = limit
+		}
+	}
+	if ctx.IsSet(RPCRateLimitCostsFlag.Name) {
+		cfg.RPCRateLimits.Costs = make(map[string]int)
+		for _, entry := range SplitAndTrim(ctx.String(RPCRateLimitCostsFlag.Name)) {
+			method, value, ok := strings.Cut(entry, "=")
+			if !ok || method == "" {
+				Fatalf("Invalid --%s entry: %s", RPCRateLimitCostsFlag.Name, entry)
+			}
+			cost, err := strconv.Atoi(value)
+			if err != nil || cost <= 0 {
+				Fatalf("Invalid --%s cost for %s: %s", RPCRateLimitCostsFlag.Name, method, value)
+			}
+			cfg.RPCRateLimits.Costs[method] = cost
+		}
+	}
+	if ctx.IsSet(RPCRateLimitKeyHeaderFlag.Name) {
+		cfg.RPCRateLimits.KeyHeader = ctx.String(RPCRateLimitKeyHeaderFlag.Name)
+	}
+	if ctx.IsSet(RPCRateLimitKeysFlag.Name) {
+		data, err := os.ReadFile(ctx.String(RPCRateLimitKeysFlag.Name))
+		if err != nil {
+			Fatalf("Failed to read --%s: %v", RPCRateLimitKeysFlag.Name, err)
+		}
+		cfg.RPCRateLimits.Keys = nil
+		for _, line := range strings.Split(string(data), "\n") {
+			if key := strings.TrimSpace(line); key != "" && !strings.HasPrefix(key, "#") {
+				cfg.RPCRateLimits.Keys = append(cfg.RPCRateLimits.Keys, key)
+			}
+		}
+	}
+}
+
+// parseRateLimit parses a rate limit given as rate[:burst[:concurrency]].
+func parseRateLimit(spec string) (rpc.RateLimit, error) {
+	var (
+		limit rpc.RateLimit
+		parts = strings.Split(spec, ":")
+		err   error
+	)
+	if len(parts) > 3 {
+		return limit, errors.New("too many fields")
+	}
+	if limit.Rate, err = strconv.ParseFloat(parts[0], 64); err != nil || limit.Rate < 0 {
+		return limit, fmt.Errorf("invalid rate %q", parts[0])
+	}
+	if len(parts) > 1 {
+		if limit.Burst, err = strconv.Atoi(parts[1]); err != nil || limit.Burst < 0 {
+			return limit, fmt.Errorf("invalid burst %q", parts[1])
+		}
+	}
+	if len(parts) > 2 {
+		if limit.Concurrency, err = strconv.Atoi(parts[2]); err != nil || limit.Concurrency < 0 {
+			return limit, fmt.Errorf("invalid concurrency %q", parts[2])
+		}
+	}
+	return limit, nil
 }
 
 // setWS creates the WebSocket RPC listener interface string from the set
@@ -1354,6 +1537,7 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	setHTTP(ctx, cfg)
 	setGraphQL(ctx, cfg)
 	setWS(ctx, cfg)
+	setRPCRateLimits(ctx, cfg)
 	setNodeUserIdent(ctx, cfg)
 	SetDataDir(ctx, cfg)
 	setSmartCard(ctx, cfg)
@@ -1495,6 +1679,18 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.IsSet(TxPoolSnapshotFlag.Name) {
+		cfg.Snapshot = ctx.String(TxPoolSnapshotFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSnapshotIntervalFlag.Name) {
+		cfg.SnapshotInterval = ctx.Duration(TxPoolSnapshotIntervalFlag.Name)
+	}
+	if ctx.IsSet(TxPoolPeerSlotsFlag.Name) {
+		cfg.PeerSlots = ctx.Uint64(TxPoolPeerSlotsFlag.Name)
+	}
+	if ctx.IsSet(TxPoolCostEvictionFlag.Name) {
+		cfg.CostEviction = ctx.Bool(TxPoolCostEvictionFlag.Name)
+	}
 }
 
 func setBlobPool(ctx *cli.Context, cfg *blobpool.Config) {
@@ -1525,12 +1721,50 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerRecommitIntervalFlag.Name) {
 		cfg.Recommit = ctx.Duration(MinerRecommitIntervalFlag.Name)
 	}
+	if ctx.IsSet(MinerStrategyFlag.Name) {
+		cfg.Strategy = ctx.String(MinerStrategyFlag.Name)
+		if _, err := miner.NewStrategy(cfg.Strategy); err != nil {
+			Fatalf("Invalid --%s: %v", MinerStrategyFlag.Name, err)
+		}
+	}
 	if ctx.IsSet(MinerNewPayloadTimeoutFlag.Name) {
 		log.Warn("The flag --miner.newpayload-timeout is deprecated and will be removed, please use --miner.recommit")
 		cfg.Recommit = ctx.Duration(MinerNewPayloadTimeoutFlag.Name)
 	}
 }
 
+func setBuilder(ctx *cli.Context, cfg *builder.Config) {
+	if !ctx.IsSet(BuilderRelaysFlag.Name) {
+		return
+	}
+	cfg.Relays = ctx.StringSlice(BuilderRelaysFlag.Name)
+
+	path := ctx.Path(BuilderSecretKeyFlag.Name)
+	if path == "" {
+		Fatalf("Builder relays are specified but --%s is missing", BuilderSecretKeyFlag.Name)
+	}
+	key, err := os.ReadFile(path)
+	if err != nil {
+		Fatalf("Failed to read builder secret key: %v", err)
+	}
+	cfg.SecretKey = strings.TrimSpace(string(key))
+
+	// The bids are signed for the beacon chain of the selected network
+	var beacon *bparams.ChainConfig
+	switch {
+	case ctx.Bool(SepoliaFlag.Name):
+		beacon = bparams.SepoliaLightConfig
+	case ctx.Bool(HoleskyFlag.Name):
+		beacon = bparams.HoleskyLightConfig
+	case ctx.Bool(HoodiFlag.Name):
+		beacon = bparams.HoodiLightConfig
+	default:
+		beacon = bparams.MainnetLightConfig
+	}
+	cfg.GenesisTime = beacon.GenesisTime
+	cfg.GenesisForkVersion = beacon.Forks[0].Version
+}
+
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
 	requiredBlocks := ctx.String(EthRequiredBlocksFlag.Name)
 	if requiredBlocks == "" {
@@ -1571,6 +1805,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	setTxPool(ctx, &cfg.TxPool)
 	setBlobPool(ctx, &cfg.BlobPool)
 	setMiner(ctx, &cfg.Miner)
+	setBuilder(ctx, &cfg.Builder)
 	setRequiredBlocks(ctx, cfg)
 
 	// Cap the cache allowance and tune the garbage collector
@@ -1608,6 +1843,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 			Fatalf("--%s: %v", ChainHistoryFlag.Name, err)
 		}
 	}
+	if ctx.IsSet(ChainHistoryRecentFlag.Name) {
+		cfg.HistoryRecent = ctx.Uint64(ChainHistoryRecentFlag.Name)
+	}
 
 	if ctx.IsSet(NetworkIdFlag.Name) {
 		cfg.NetworkId = ctx.Uint64(NetworkIdFlag.Name)
@@ -1632,6 +1870,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(CacheNoPrefetchFlag.Name) {
 		cfg.NoPrefetch = ctx.Bool(CacheNoPrefetchFlag.Name)
 	}
+	if ctx.IsSet(ParallelExecutionFlag.Name) {
+		cfg.Parallel = ctx.Bool(ParallelExecutionFlag.Name)
+	}
 	// Read the value from the flag no matter if it's set or not.
 	cfg.Preimages = ctx.Bool(CachePreimagesFlag.Name)
 	if cfg.NoPruning && !cfg.Preimages {
@@ -1641,6 +1882,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(StateHistoryFlag.Name) {
 		cfg.StateHistory = ctx.Uint64(StateHistoryFlag.Name)
 	}
+	if ctx.IsSet(WitnessHistoryFlag.Name) {
+		cfg.WitnessHistory = ctx.Uint64(WitnessHistoryFlag.Name)
+	}
 	if ctx.IsSet(StateSchemeFlag.Name) {
 		cfg.StateScheme = ctx.String(StateSchemeFlag.Name)
 	}
@@ -1701,6 +1945,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(VMEnableDebugFlag.Name) {
 		cfg.EnablePreimageRecording = ctx.Bool(VMEnableDebugFlag.Name)
 	}
+	if ctx.IsSet(VMBlockAccessListFlag.Name) {
+		cfg.BlockAccessList = ctx.Bool(VMBlockAccessListFlag.Name)
+	}
 
 	if ctx.IsSet(RPCGlobalGasCapFlag.Name) {
 		cfg.RPCGasCap = ctx.Uint64(RPCGlobalGasCapFlag.Name)
@@ -1716,6 +1963,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.IsSet(TxPoolPrivateBuildersFlag.Name) {
+		cfg.PrivateTxBuilders = ctx.StringSlice(TxPoolPrivateBuildersFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs, cfg.SnapDiscoveryURLs = []string{}, []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
@@ -1966,7 +2216,7 @@ func RegisterEthService(stack *node.Node, cfg *ethconfig.Config) (*eth.EthAPIBac
 	if err != nil {
 		Fatalf("Failed to register the Ethereum service: %v", err)
 	}
-	stack.RegisterAPIs(tracers.APIs(backend.APIBackend))
+	stack.RegisterAPIs(tracers.APIs(backend.APIBackend, backend.TraceArchive()))
 	return backend.APIBackend, backend
 }
 
@@ -1979,7 +2229,7 @@ func RegisterEthStatsService(stack *node.Node, backend *eth.EthAPIBackend, url s
 
 // RegisterGraphQLService adds the GraphQL API to the node.
 func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cfg *node.Config) {
-	err := graphql.New(stack, backend, filterSystem, cfg.GraphQLCors, cfg.GraphQLVirtualHosts)
+	err := graphql.New(stack, backend, filterSystem, cfg.GraphQLCors, cfg.GraphQLVirtualHosts, cfg.GraphQLCostLimit)
 	if err != nil {
 		Fatalf("Failed to register the GraphQL service: %v", err)
 	}
@@ -2189,6 +2439,7 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 	options := &core.BlockChainConfig{
 		TrieCleanLimit: ethconfig.Defaults.TrieCleanCache,
 		NoPrefetch:     ctx.Bool(CacheNoPrefetchFlag.Name),
+		Parallel:       ctx.Bool(ParallelExecutionFlag.Name),
 		TrieDirtyLimit: ethconfig.Defaults.TrieDirtyCache,
 		ArchiveMode:    ctx.String(GCModeFlag.Name) == "archive",
 		TrieTimeLimit:  ethconfig.Defaults.TrieTimeout,
@@ -2199,6 +2450,12 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		// Disable transaction indexing/unindexing.
 		TxLookupLimit: -1,
 	}
+	if ctx.IsSet(ChainHistoryFlag.Name) {
+		if err := options.ChainHistoryMode.UnmarshalText([]byte(ctx.String(ChainHistoryFlag.Name))); err != nil {
+			Fatalf("--%s: %v", ChainHistoryFlag.Name, err)
+		}
+		options.ChainHistoryRecent = ctx.Uint64(ChainHistoryRecentFlag.Name)
+	}
 	if options.ArchiveMode && !options.Preimages {
 		options.Preimages = true
 		log.Info("Enabling recording of key preimages since archive mode is used")
@@ -2221,6 +2478,7 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 	}
 	vmcfg := vm.Config{
 		EnablePreimageRecording: ctx.Bool(VMEnableDebugFlag.Name),
+		BlockAccessList:         ctx.Bool(VMBlockAccessListFlag.Name),
 	}
 	if ctx.IsSet(VMTraceFlag.Name) {
 		if name := ctx.String(VMTraceFlag.Name); name != "" {
@@ -23,10 +23,12 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -89,7 +91,7 @@ func TestHistoryImportAndExport(t *testing.T) {
 	dir := t.TempDir()
 
 	// Export history to temp directory.
-	if err := ExportHistory(chain, dir, 0, count, step); err != nil {
+	if err := ExportHistory(chain, dir, 0, count, step, 0); err != nil {
 		t.Fatalf("error exporting history: %v", err)
 	}
 
@@ -170,10 +172,188 @@ func TestHistoryImportAndExport(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to initialize chain: %v", err)
 	}
-	if err := ImportHistory(imported, dir, "mainnet"); err != nil {
+	if err := ImportHistory(imported, dir, "mainnet", historyRoots(t, chain, dir, 0)); err != nil {
 		t.Fatalf("failed to import chain: %v", err)
 	}
 	if have, want := imported.CurrentHeader(), chain.CurrentHeader(); have.Hash() != want.Hash() {
 		t.Fatalf("imported chain does not match expected, have (%d, %s) want (%d, %s)", have.Number, have.Hash(), want.Number, want.Hash())
 	}
 }
+
+// historyRoots computes the accumulator roots of the era files exported into
+// the directory from the given chain, to be used as the trusted roots.
+func historyRoots(t *testing.T, chain *core.BlockChain, dir string, beaconGenesis uint64) []common.Hash {
+	entries, err := era.ReadDir(dir, "mainnet")
+	if err != nil {
+		t.Fatalf("error reading era1 files: %v", err)
+	}
+	postMergeEntries, err := era.ReadPostMergeDir(dir, "mainnet")
+	if err != nil {
+		t.Fatalf("error reading post-merge era files: %v", err)
+	}
+	var roots []common.Hash
+	for _, filename := range append(entries, postMergeEntries...) {
+		e, err := era.Open(filepath.Join(dir, filename))
+		if err != nil {
+			t.Fatalf("error opening era: %v", err)
+		}
+		var root common.Hash
+		if !e.IsPostMerge() {
+			var (
+				td     = new(big.Int)
+				hashes []common.Hash
+				tds    []*big.Int
+			)
+			for n := uint64(0); n < e.Start()+e.Count(); n++ {
+				header := chain.GetHeaderByNumber(n)
+				td.Add(td, header.Difficulty)
+				if n >= e.Start() {
+					hashes = append(hashes, header.Hash())
+					tds = append(tds, new(big.Int).Set(td))
+				}
+			}
+			root, err = era.ComputeAccumulator(hashes, tds)
+		} else {
+			var (
+				slots   []uint64
+				parents []common.Hash
+			)
+			for n := e.Start(); n < e.Start()+e.Count(); n++ {
+				header := chain.GetHeaderByNumber(n)
+				slots = append(slots, (header.Time-beaconGenesis)/era.SecondsPerSlot)
+				parents = append(parents, *header.ParentBeaconRoot)
+			}
+			next := chain.GetHeaderByNumber(e.Start() + e.Count())
+			root, err = era.ComputeBlockSummaryRoot(slots, parents, *next.ParentBeaconRoot)
+		}
+		if err != nil {
+			t.Fatalf("error computing accumulator: %v", err)
+		}
+		roots = append(roots, root)
+		e.Close()
+	}
+	return roots
+}
+
+func TestHistoryImportAndExportPostMerge(t *testing.T) {
+	var (
+		config  = *params.TestChainConfig
+		engine  = beacon.New(ethash.NewFaker())
+		genesis = &core.Genesis{
+			Config:    &config,
+			BaseFee:   big.NewInt(params.InitialBaseFee),
+			Timestamp: 1_000_000,
+		}
+		beaconGenesis = genesis.Timestamp
+		mergeBlock    = 40
+		cancunBlock   = 60
+		slotGap       = era.SlotsPerEra / 4
+	)
+	// Activate Cancun a while after the merge, from then on the blocks are
+	// placed on slot boundaries, four of them in each slot era.
+	cancunTime := genesis.Timestamp + uint64(cancunBlock)*10
+	config.ShanghaiTime = &cancunTime
+	config.CancunTime = &cancunTime
+	config.BlobScheduleConfig = &params.BlobScheduleConfig{Cancun: params.DefaultCancunBlobConfig}
+
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, int(count), func(i int, g *core.BlockGen) {
+		if i+1 >= mergeBlock {
+			g.SetPoS()
+		}
+		if i+1 >= cancunBlock {
+			if i+1 > cancunBlock {
+				g.OffsetTime(int64(slotGap*era.SecondsPerSlot) - 10)
+			}
+			g.SetParentBeaconRoot(common.Hash{byte(i)})
+		}
+	})
+	ttd := new(big.Int).Set(params.GenesisDifficulty)
+	for _, block := range blocks[:mergeBlock-1] {
+		ttd.Add(ttd, block.Difficulty())
+	}
+	config.TerminalTotalDifficulty = ttd
+
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), genesis, engine, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("error inserting chain: %v", err)
+	}
+
+	// Export history. The first Cancun blocks share the slot era with the
+	// earlier ones and are archived in Era1 files along with them, as is the
+	// merge transition. The last slot era is incomplete and skipped.
+	var (
+		split = uint64(cancunBlock) + 4
+		last  = count - 1
+	)
+	dir := t.TempDir()
+	if err := ExportHistory(chain, dir, 0, count, step, beaconGenesis); err != nil {
+		t.Fatalf("error exporting history: %v", err)
+	}
+	entries, err := era.ReadDir(dir, "mainnet")
+	if err != nil {
+		t.Fatalf("error reading era1 files: %v", err)
+	}
+	if want := int(split / step); len(entries) != want {
+		t.Fatalf("unexpected number of era1 files: have %d, want %d", len(entries), want)
+	}
+	postMergeEntries, err := era.ReadPostMergeDir(dir, "mainnet")
+	if err != nil {
+		t.Fatalf("error reading post-merge era files: %v", err)
+	}
+	if want := int(last-split+1) / 4; len(postMergeEntries) != want {
+		t.Fatalf("unexpected number of post-merge era files: have %d, want %d", len(postMergeEntries), want)
+	}
+	next := split
+	for _, filename := range postMergeEntries {
+		e, err := era.Open(filepath.Join(dir, filename))
+		if err != nil {
+			t.Fatalf("error opening era: %v", err)
+		}
+		if !e.IsPostMerge() {
+			t.Fatalf("era %s is not post-merge", filename)
+		}
+		if e.Start() != next || e.Count() != 4 {
+			t.Fatalf("unexpected range of era %s: have [%d, +%d], want [%d, +4]", filename, e.Start(), e.Count(), next)
+		}
+		if err := era.Verify(e, trie.NewStackTrie(nil)); err != nil {
+			t.Fatalf("error verifying era %s: %v", filename, err)
+		}
+		next += e.Count()
+		e.Close()
+	}
+
+	// Import the history into an empty chain, the archives must match the
+	// trusted roots.
+	db2, err := rawdb.Open(rawdb.NewMemoryDatabase(), rawdb.OpenOptions{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		db2.Close()
+	})
+	imported, err := core.NewBlockChain(db2, genesis, engine, nil)
+	if err != nil {
+		t.Fatalf("unable to initialize chain: %v", err)
+	}
+	defer imported.Stop()
+
+	roots := historyRoots(t, chain, dir, beaconGenesis)
+	if err := ImportHistory(imported, dir, "mainnet", roots[1:]); err == nil {
+		t.Fatal("expected error importing with missing roots")
+	}
+	bad := slices.Clone(roots)
+	bad[0] = common.Hash{0x01}
+	if err := ImportHistory(imported, dir, "mainnet", bad); err == nil {
+		t.Fatal("expected error importing with mismatching roots")
+	}
+	if err := ImportHistory(imported, dir, "mainnet", roots); err != nil {
+		t.Fatalf("failed to import chain: %v", err)
+	}
+	if have, want := imported.CurrentHeader(), chain.GetHeaderByNumber(last); have.Hash() != want.Hash() {
+		t.Fatalf("imported chain does not match expected, have (%d, %s) want (%d, %s)", have.Number, have.Hash(), want.Number, want.Hash())
+	}
+}
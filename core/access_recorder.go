@@ -0,0 +1,288 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
+)
+
+// accountSnapshot is the account metadata observed by a transaction.
+type accountSnapshot struct {
+	exist    bool
+	balance  uint256.Int
+	nonce    uint64
+	codeHash common.Hash
+	root     common.Hash
+}
+
+// newAccountSnapshot retrieves the metadata of the given account.
+func newAccountSnapshot(statedb vm.StateDB, addr common.Address) accountSnapshot {
+	return accountSnapshot{
+		exist:    statedb.Exist(addr),
+		balance:  *statedb.GetBalance(addr),
+		nonce:    statedb.GetNonce(addr),
+		codeHash: statedb.GetCodeHash(addr),
+		root:     statedb.GetStorageRoot(addr),
+	}
+}
+
+// accessRecorder wraps the state used in the execution, tracking the accounts
+// and storage slots accessed, along with the values before the execution, and
+// the ones modified by the execution.
+//
+// The balance of the block coinbase is credited by nearly all transactions,
+// which would otherwise make every speculatively executed transaction conflict
+// with its predecessor. If the coinbase is specified, the credits made without
+// observing it are therefore accumulated separately, to be added onto the
+// actual coinbase balance at commit time.
+type accessRecorder struct {
+	vm.StateDB
+
+	accounts map[common.Address]accountSnapshot             // Accounts accessed, with the values before the transaction
+	slots    map[common.Address]map[common.Hash]common.Hash // Storage slots accessed, with the values before the transaction
+	dirties  map[common.Address]map[common.Hash]struct{}    // Accounts modified and their storage slots modified
+
+	coinbase     *common.Address // Coinbase whose credits are tracked separately, nil if disabled
+	coinbaseBase accountSnapshot // Coinbase before the transaction
+	coinbaseFee  *uint256.Int    // Coinbase credits made without observing it, nil if none
+}
+
+// newAccessRecorder constructs the access recorder on top of the given state.
+func newAccessRecorder(statedb vm.StateDB, coinbase *common.Address) *accessRecorder {
+	r := &accessRecorder{
+		StateDB:  statedb,
+		coinbase: coinbase,
+	}
+	r.reset()
+	return r
+}
+
+// reset discards the recorded accesses, preparing the recorder for tracking
+// the next execution.
+func (r *accessRecorder) reset() {
+	r.accounts = make(map[common.Address]accountSnapshot)
+	r.slots = make(map[common.Address]map[common.Hash]common.Hash)
+	r.dirties = make(map[common.Address]map[common.Hash]struct{})
+	r.coinbaseFee = nil
+	if r.coinbase != nil {
+		r.coinbaseBase = newAccountSnapshot(r.StateDB, *r.coinbase)
+	}
+}
+
+// touch records the account access, it must be invoked before the access
+// is made for tracking the value before the transaction.
+func (r *accessRecorder) touch(addr common.Address) {
+	if _, ok := r.accounts[addr]; ok {
+		return
+	}
+	if r.coinbase != nil && addr == *r.coinbase {
+		// The coinbase is observed by the transaction, the credits made so far
+		// are no longer commutative and the account is tracked as usual.
+		r.accounts[addr] = r.coinbaseBase
+		if r.coinbaseFee != nil {
+			r.dirties[addr] = make(map[common.Hash]struct{})
+			r.coinbaseFee = nil
+		}
+	} else {
+		r.accounts[addr] = newAccountSnapshot(r.StateDB, addr)
+	}
+}
+
+// touchSlot records the storage slot access.
+func (r *accessRecorder) touchSlot(addr common.Address, key common.Hash) {
+	r.touch(addr)
+	if _, ok := r.slots[addr][key]; ok {
+		return
+	}
+	if r.slots[addr] == nil {
+		r.slots[addr] = make(map[common.Hash]common.Hash)
+	}
+	r.slots[addr][key] = r.StateDB.GetState(addr, key)
+}
+
+// markDirty records the account modification.
+func (r *accessRecorder) markDirty(addr common.Address) {
+	r.touch(addr)
+	if r.dirties[addr] == nil {
+		r.dirties[addr] = make(map[common.Hash]struct{})
+	}
+}
+
+func (r *accessRecorder) CreateAccount(addr common.Address) {
+	r.markDirty(addr)
+	r.StateDB.CreateAccount(addr)
+}
+
+func (r *accessRecorder) CreateContract(addr common.Address) {
+	r.markDirty(addr)
+	r.StateDB.CreateContract(addr)
+}
+
+func (r *accessRecorder) SubBalance(addr common.Address, amount *uint256.Int, reason tracing.BalanceChangeReason) uint256.Int {
+	r.markDirty(addr)
+	return r.StateDB.SubBalance(addr, amount, reason)
+}
+
+func (r *accessRecorder) AddBalance(addr common.Address, amount *uint256.Int, reason tracing.BalanceChangeReason) uint256.Int {
+	if _, ok := r.accounts[addr]; !ok && r.coinbase != nil && addr == *r.coinbase {
+		if r.coinbaseFee == nil {
+			r.coinbaseFee = new(uint256.Int)
+		}
+		r.coinbaseFee.Add(r.coinbaseFee, amount)
+	} else {
+		r.markDirty(addr)
+	}
+	return r.StateDB.AddBalance(addr, amount, reason)
+}
+
+func (r *accessRecorder) GetBalance(addr common.Address) *uint256.Int {
+	r.touch(addr)
+	return r.StateDB.GetBalance(addr)
+}
+
+func (r *accessRecorder) GetNonce(addr common.Address) uint64 {
+	r.touch(addr)
+	return r.StateDB.GetNonce(addr)
+}
+
+func (r *accessRecorder) SetNonce(addr common.Address, nonce uint64, reason tracing.NonceChangeReason) {
+	r.markDirty(addr)
+	r.StateDB.SetNonce(addr, nonce, reason)
+}
+
+func (r *accessRecorder) GetCodeHash(addr common.Address) common.Hash {
+	r.touch(addr)
+	return r.StateDB.GetCodeHash(addr)
+}
+
+func (r *accessRecorder) GetCode(addr common.Address) []byte {
+	r.touch(addr)
+	return r.StateDB.GetCode(addr)
+}
+
+func (r *accessRecorder) SetCode(addr common.Address, code []byte) []byte {
+	r.markDirty(addr)
+	return r.StateDB.SetCode(addr, code)
+}
+
+func (r *accessRecorder) GetCodeSize(addr common.Address) int {
+	r.touch(addr)
+	return r.StateDB.GetCodeSize(addr)
+}
+
+func (r *accessRecorder) GetCommittedState(addr common.Address, key common.Hash) common.Hash {
+	r.touchSlot(addr, key)
+	return r.StateDB.GetCommittedState(addr, key)
+}
+
+func (r *accessRecorder) GetState(addr common.Address, key common.Hash) common.Hash {
+	r.touchSlot(addr, key)
+	return r.StateDB.GetState(addr, key)
+}
+
+func (r *accessRecorder) SetState(addr common.Address, key common.Hash, value common.Hash) common.Hash {
+	r.touchSlot(addr, key)
+	r.markDirty(addr)
+	r.dirties[addr][key] = struct{}{}
+	return r.StateDB.SetState(addr, key, value)
+}
+
+func (r *accessRecorder) GetStorageRoot(addr common.Address) common.Hash {
+	r.touch(addr)
+	return r.StateDB.GetStorageRoot(addr)
+}
+
+func (r *accessRecorder) SelfDestruct(addr common.Address) uint256.Int {
+	r.markDirty(addr)
+	return r.StateDB.SelfDestruct(addr)
+}
+
+func (r *accessRecorder) HasSelfDestructed(addr common.Address) bool {
+	r.touch(addr)
+	return r.StateDB.HasSelfDestructed(addr)
+}
+
+func (r *accessRecorder) SelfDestruct6780(addr common.Address) (uint256.Int, bool) {
+	r.markDirty(addr)
+	return r.StateDB.SelfDestruct6780(addr)
+}
+
+func (r *accessRecorder) Exist(addr common.Address) bool {
+	r.touch(addr)
+	return r.StateDB.Exist(addr)
+}
+
+func (r *accessRecorder) Empty(addr common.Address) bool {
+	r.touch(addr)
+	return r.StateDB.Empty(addr)
+}
+
+// validate reports whether the state accessed by the transaction is unchanged
+// in the given state, in which case the transaction execution on top of the
+// given state is deterministically identical to the speculative one.
+func (r *accessRecorder) validate(statedb *state.StateDB) bool {
+	for addr, account := range r.accounts {
+		if newAccountSnapshot(statedb, addr) != account {
+			return false
+		}
+	}
+	for addr, slots := range r.slots {
+		for key, val := range slots {
+			if statedb.GetState(addr, key) != val {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// apply transfers the modifications made by the transaction from the given
+// post-transaction state onto the provided state.
+func (r *accessRecorder) apply(statedb *state.StateDB, post *state.StateDB) {
+	for addr, slots := range r.dirties {
+		// The account is deleted by the transaction, either self-destructed or
+		// removed as an empty account.
+		if !post.Exist(addr) {
+			if statedb.Exist(addr) {
+				statedb.SelfDestruct(addr)
+			}
+			continue
+		}
+		if !statedb.Exist(addr) {
+			statedb.CreateAccount(addr)
+		}
+		if balance := post.GetBalance(addr); statedb.GetBalance(addr).Cmp(balance) != 0 {
+			statedb.SetBalance(addr, balance, tracing.BalanceChangeUnspecified)
+		}
+		if nonce := post.GetNonce(addr); statedb.GetNonce(addr) != nonce {
+			statedb.SetNonce(addr, nonce, tracing.NonceChangeUnspecified)
+		}
+		if post.GetCodeHash(addr) != statedb.GetCodeHash(addr) {
+			statedb.SetCode(addr, post.GetCode(addr))
+		}
+		for key := range slots {
+			statedb.SetState(addr, key, post.GetState(addr, key))
+		}
+	}
+	if r.coinbaseFee != nil {
+		statedb.AddBalance(*r.coinbase, r.coinbaseFee, tracing.BalanceIncreaseRewardTransactionFee)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
+)
+
+// accountAccesses is the accesses made to an account within a block.
+type accountAccesses struct {
+	reads   map[common.Hash]struct{}
+	writes  map[common.Hash][]types.StorageChange
+	balance []types.BalanceChange
+	nonce   []types.NonceChange
+	code    []types.CodeChange
+}
+
+// accessListBuilder constructs the block access list along with the execution
+// of a block. The state accesses are recorded per block access, e.g. system
+// call or transaction, and the post-values of the modified states are resolved
+// once the block access is finished.
+type accessListBuilder struct {
+	recorder *accessRecorder
+	accounts map[common.Address]*accountAccesses
+}
+
+// newAccessListBuilder constructs the builder on top of the given state, the
+// recorder of the builder must be used in the execution instead.
+func newAccessListBuilder(statedb vm.StateDB) *accessListBuilder {
+	return &accessListBuilder{
+		recorder: newAccessRecorder(statedb, nil),
+		accounts: make(map[common.Address]*accountAccesses),
+	}
+}
+
+// account returns the accesses of the given account, creating it if absent.
+func (b *accessListBuilder) account(addr common.Address) *accountAccesses {
+	acc := b.accounts[addr]
+	if acc == nil {
+		acc = &accountAccesses{
+			reads:  make(map[common.Hash]struct{}),
+			writes: make(map[common.Hash][]types.StorageChange),
+		}
+		b.accounts[addr] = acc
+	}
+	return acc
+}
+
+// collect resolves the modifications made in the finished block access with
+// the given index and resets the recorder for the next one.
+func (b *accessListBuilder) collect(index uint16) {
+	var (
+		r        = b.recorder
+		codeHash = func(hash common.Hash) common.Hash {
+			// Non-existent accounts are regarded as having no code
+			if hash == (common.Hash{}) {
+				return types.EmptyCodeHash
+			}
+			return hash
+		}
+	)
+	for addr, prev := range r.accounts {
+		var (
+			acc  = b.account(addr)
+			post = newAccountSnapshot(r.StateDB, addr)
+		)
+		if post.balance != prev.balance {
+			acc.balance = append(acc.balance, types.BalanceChange{Index: index, Balance: new(uint256.Int).Set(&post.balance)})
+		}
+		if post.nonce != prev.nonce {
+			acc.nonce = append(acc.nonce, types.NonceChange{Index: index, Nonce: post.nonce})
+		}
+		if codeHash(post.codeHash) != codeHash(prev.codeHash) {
+			acc.code = append(acc.code, types.CodeChange{Index: index, Code: bytes.Clone(r.StateDB.GetCode(addr))})
+		}
+	}
+	for addr, slots := range r.slots {
+		acc := b.account(addr)
+		for key, prev := range slots {
+			if val := r.StateDB.GetState(addr, key); val != prev {
+				acc.writes[key] = append(acc.writes[key], types.StorageChange{Index: index, Value: val})
+			} else {
+				acc.reads[key] = struct{}{}
+			}
+		}
+	}
+	r.reset()
+}
+
+// build returns the constructed block access list. The storage slots which
+// are modified by any block access are excluded from the reads.
+func (b *accessListBuilder) build() *types.BlockAccessList {
+	bal := &types.BlockAccessList{Accounts: make([]types.AccountAccess, 0, len(b.accounts))}
+	for _, addr := range slices.SortedFunc(maps.Keys(b.accounts), common.Address.Cmp) {
+		acc := b.accounts[addr]
+		access := types.AccountAccess{
+			Address:        addr,
+			StorageChanges: make([]types.SlotChanges, 0, len(acc.writes)),
+			StorageReads:   make([]common.Hash, 0, len(acc.reads)),
+			BalanceChanges: append([]types.BalanceChange{}, acc.balance...),
+			NonceChanges:   append([]types.NonceChange{}, acc.nonce...),
+			CodeChanges:    append([]types.CodeChange{}, acc.code...),
+		}
+		for _, key := range slices.SortedFunc(maps.Keys(acc.writes), common.Hash.Cmp) {
+			access.StorageChanges = append(access.StorageChanges, types.SlotChanges{Slot: key, Changes: acc.writes[key]})
+		}
+		for _, key := range slices.SortedFunc(maps.Keys(acc.reads), common.Hash.Cmp) {
+			if _, ok := acc.writes[key]; !ok {
+				access.StorageReads = append(access.StorageReads, key)
+			}
+		}
+		bal.Accounts = append(bal.Accounts, access)
+	}
+	return bal
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests the block access list generation along with the block execution and
+// the validation of it during the block import.
+func TestBlockAccessList(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender   = crypto.PubkeyToAddress(key.PublicKey)
+		counter  = common.Address{0xc0} // SSTORE(0, SLOAD(0)+1), SLOAD(1)
+		receiver = common.Address{0xee}
+		gspec    = &Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender:                    {Balance: big.NewInt(params.Ether)},
+				params.BeaconRootsAddress: {Code: params.BeaconRootsCode},
+				counter: {Code: []byte{
+					byte(vm.PUSH1), 0x00, byte(vm.SLOAD), byte(vm.PUSH1), 0x01, byte(vm.ADD),
+					byte(vm.PUSH1), 0x00, byte(vm.SSTORE),
+					byte(vm.PUSH1), 0x01, byte(vm.SLOAD), byte(vm.POP),
+				}},
+			},
+		}
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 4, func(i int, b *BlockGen) {
+		b.SetParentBeaconRoot(common.Hash{byte(i + 1)})
+		for j := 0; j < 3; j++ {
+			b.AddTx(types.MustSignNewTx(key, b.Signer(), &types.LegacyTx{
+				Nonce:    b.TxNonce(sender),
+				To:       &counter,
+				Gas:      100000,
+				GasPrice: b.BaseFee(),
+			}))
+		}
+		b.AddWithdrawal(&types.Withdrawal{Validator: 42, Address: receiver, Amount: 1})
+	})
+	// Import the chain with the block access lists validated
+	options := DefaultConfig()
+	options.VmConfig = vm.Config{BlockAccessList: true}
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, options)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert chain: %v", err)
+	}
+	// Generate the access list of the last block and check the content
+	block := blocks[len(blocks)-1]
+	statedb, err := state.New(blocks[len(blocks)-2].Root(), chain.statedb)
+	if err != nil {
+		t.Fatalf("Failed to open parent state: %v", err)
+	}
+	res, err := chain.Processor().Process(block, statedb, vm.Config{BlockAccessList: true})
+	if err != nil {
+		t.Fatalf("Failed to process block: %v", err)
+	}
+	bal := res.AccessList
+
+	accounts := make(map[common.Address]types.AccountAccess)
+	for _, account := range bal.Accounts {
+		accounts[account.Address] = account
+	}
+	// The beacon root is stored by the system call prior to the transactions
+	if changes := accounts[params.BeaconRootsAddress].StorageChanges; len(changes) != 2 || changes[0].Changes[0].Index != 0 {
+		t.Fatalf("Unexpected beacon root changes: %v", changes)
+	}
+	// The counter is modified by all transactions, with the other slot read only
+	counterAccess := accounts[counter]
+	if len(counterAccess.StorageChanges) != 1 || len(counterAccess.StorageChanges[0].Changes) != 3 {
+		t.Fatalf("Unexpected counter changes: %v", counterAccess.StorageChanges)
+	}
+	for i, change := range counterAccess.StorageChanges[0].Changes {
+		if change.Index != uint16(i+1) || change.Value != common.BigToHash(big.NewInt(int64(len(blocks)*3-2+i))) {
+			t.Fatalf("Unexpected counter change %d: %v", i, change)
+		}
+	}
+	if !reflect.DeepEqual(counterAccess.StorageReads, []common.Hash{common.BigToHash(common.Big1)}) {
+		t.Fatalf("Unexpected counter reads: %v", counterAccess.StorageReads)
+	}
+	if changes := accounts[sender].NonceChanges; len(changes) != 3 || changes[2].Nonce != uint64(len(blocks)*3) {
+		t.Fatalf("Unexpected sender nonce changes: %v", changes)
+	}
+	// The withdrawal is processed after the transactions
+	if changes := accounts[receiver].BalanceChanges; len(changes) != 1 || changes[0].Index != 4 {
+		t.Fatalf("Unexpected withdrawal changes: %v", changes)
+	}
+	// Check the validation against the state mutations, the list must cover
+	// all the modifications with the correct post-values
+	statedb.IntermediateRoot(true)
+	parent, _ := state.New(blocks[len(blocks)-2].Root(), chain.statedb)
+	if err := chain.validator.ValidateAccessList(block, parent, statedb, bal); err != nil {
+		t.Fatalf("Failed to validate access list: %v", err)
+	}
+	for i, account := range bal.Accounts {
+		if account.Address != counter {
+			continue
+		}
+		tampered := *bal
+		tampered.Accounts = slices.Clone(bal.Accounts)
+		tampered.Accounts[i].StorageChanges = nil
+		if err := chain.validator.ValidateAccessList(block, parent, statedb, &tampered); err == nil {
+			t.Fatal("Access list with missing storage change validated")
+		}
+		changes := slices.Clone(account.StorageChanges[0].Changes)
+		changes[len(changes)-1].Value = common.Hash{0xff}
+		tampered.Accounts[i].StorageChanges = []types.SlotChanges{{Slot: account.StorageChanges[0].Slot, Changes: changes}}
+		if err := chain.validator.ValidateAccessList(block, parent, statedb, &tampered); err == nil {
+			t.Fatal("Access list with wrong post-value validated")
+		}
+	}
+	// Check the RLP encoding round-trip
+	enc, err := rlp.EncodeToBytes(bal)
+	if err != nil {
+		t.Fatalf("Failed to encode access list: %v", err)
+	}
+	var dec types.BlockAccessList
+	if err := rlp.DecodeBytes(enc, &dec); err != nil {
+		t.Fatalf("Failed to decode access list: %v", err)
+	}
+	if dec.Hash() != bal.Hash() {
+		t.Fatalf("Access list hash mismatch after round-trip")
+	}
+}
@@ -17,9 +17,12 @@
 package core
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"slices"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -164,6 +167,75 @@ func (v *BlockValidator) ValidateState(block *types.Block, statedb *state.StateD
 	return nil
 }
 
+// ValidateAccessList validates the block access list generated along with the
+// execution of the block against the state mutations tracked by the state
+// database during the same execution. The post-values in the list must match
+// the post-state, and every account field and storage slot changed by the block
+// must be present in the list.
+//
+// Note, the account deletions are not expressed in the access list. Prior to
+// Cancun, the storage of self-destructed contracts is wiped without being
+// accessed, so the validation is only meaningful for Cancun blocks onwards.
+func (v *BlockValidator) ValidateAccessList(block *types.Block, parent, post *state.StateDB, bal *types.BlockAccessList) error {
+	accounts := make(map[common.Address]*types.AccountAccess, len(bal.Accounts))
+	for i := range bal.Accounts {
+		account := &bal.Accounts[i]
+		addr := account.Address
+		accounts[addr] = account
+
+		if n := len(account.BalanceChanges); n > 0 && account.BalanceChanges[n-1].Balance.Cmp(post.GetBalance(addr)) != 0 {
+			return fmt.Errorf("invalid block access list: balance mismatch for %x", addr)
+		}
+		if n := len(account.NonceChanges); n > 0 && account.NonceChanges[n-1].Nonce != post.GetNonce(addr) {
+			return fmt.Errorf("invalid block access list: nonce mismatch for %x", addr)
+		}
+		if n := len(account.CodeChanges); n > 0 && !bytes.Equal(account.CodeChanges[n-1].Code, post.GetCode(addr)) {
+			return fmt.Errorf("invalid block access list: code mismatch for %x", addr)
+		}
+		for _, slot := range account.StorageChanges {
+			if slot.Changes[len(slot.Changes)-1].Value != post.GetState(addr, slot.Slot) {
+				return fmt.Errorf("invalid block access list: storage mismatch for %x slot %x", addr, slot.Slot)
+			}
+		}
+	}
+	for addr, slots := range post.Mutations() {
+		account := accounts[addr]
+		if account == nil {
+			account = new(types.AccountAccess)
+		}
+		if post.GetBalance(addr).Cmp(parent.GetBalance(addr)) != 0 && len(account.BalanceChanges) == 0 {
+			return fmt.Errorf("invalid block access list: missing balance change for %x", addr)
+		}
+		if post.GetNonce(addr) != parent.GetNonce(addr) && len(account.NonceChanges) == 0 {
+			return fmt.Errorf("invalid block access list: missing nonce change for %x", addr)
+		}
+		if codeHash(post, addr) != codeHash(parent, addr) && len(account.CodeChanges) == 0 {
+			return fmt.Errorf("invalid block access list: missing code change for %x", addr)
+		}
+		for _, key := range slots {
+			if post.GetState(addr, key) == parent.GetState(addr, key) {
+				continue
+			}
+			_, found := slices.BinarySearchFunc(account.StorageChanges, key, func(slot types.SlotChanges, key common.Hash) int {
+				return slot.Slot.Cmp(key)
+			})
+			if !found {
+				return fmt.Errorf("invalid block access list: missing storage change for %x slot %x", addr, key)
+			}
+		}
+	}
+	return nil
+}
+
+// codeHash returns the code hash of the account, regarding the non-existent
+// accounts as having no code.
+func codeHash(statedb *state.StateDB, addr common.Address) common.Hash {
+	if hash := statedb.GetCodeHash(addr); hash != (common.Hash{}) {
+		return hash
+	}
+	return types.EmptyCodeHash
+}
+
 // CalcGasLimit computes the gas limit of the next block after parent. It aims
 // to keep the baseline gas close to the provided target, and increase it towards
 // the target if the baseline gas is lower.
@@ -181,10 +181,16 @@ type BlockChainConfig struct {
 	// Blocks before this number may be unavailable in the chain database.
 	ChainHistoryMode history.HistoryMode
 
+	// ChainHistoryRecent is the number of recent blocks whose bodies and receipts
+	// are retained, only relevant in history.KeepRecent mode.
+	ChainHistoryRecent uint64
+
 	// Misc options
-	NoPrefetch bool            // Whether to disable heuristic state prefetching when processing blocks
-	Overrides  *ChainOverrides // Optional chain config overrides
-	VmConfig   vm.Config       // Config options for the EVM Interpreter
+	NoPrefetch     bool            // Whether to disable heuristic state prefetching when processing blocks
+	Parallel       bool            // Whether to execute the transactions optimistically in parallel
+	WitnessHistory uint64          // Number of recent blocks whose execution witnesses are archived (0 = disabled)
+	Overrides      *ChainOverrides // Optional chain config overrides
+	VmConfig       vm.Config       // Config options for the EVM Interpreter
 
 	// TxLookupLimit specifies the maximum number of blocks from head for which
 	// transaction hashes will be indexed.
@@ -291,6 +297,8 @@ type BlockChain struct {
 	triedb        *triedb.Database                 // The database handler for maintaining trie nodes.
 	statedb       *state.CachingDB                 // State database to reuse between imports (contains state cache)
 	txIndexer     *txIndexer                       // Transaction indexer, might be nil if not enabled
+	historyPruner *historyPruner                   // Rolling history pruner, might be nil if not enabled
+	witnesses     *witnessArchive                  // Execution witness archive, might be nil if not enabled
 
 	hc               *HeaderChain
 	rmLogsFeed       event.Feed
@@ -386,7 +394,11 @@ func NewBlockChain(db ethdb.Database, genesis *Genesis, engine consensus.Engine,
 	bc.statedb = state.NewDatabase(bc.triedb, nil)
 	bc.validator = NewBlockValidator(chainConfig, bc)
 	bc.prefetcher = newStatePrefetcher(chainConfig, bc.hc)
-	bc.processor = NewStateProcessor(chainConfig, bc.hc)
+	if cfg.Parallel {
+		bc.processor = NewParallelStateProcessor(chainConfig, bc.hc)
+	} else {
+		bc.processor = NewStateProcessor(chainConfig, bc.hc)
+	}
 
 	genesisHeader := bc.GetHeaderByNumber(0)
 	if genesisHeader == nil {
@@ -517,10 +529,21 @@ func NewBlockChain(db ethdb.Database, genesis *Genesis, engine consensus.Engine,
 		rawdb.WriteChainConfig(db, genesisHash, chainConfig)
 	}
 
+	// Open the execution witness archive if it's enabled.
+	if bc.cfg.WitnessHistory > 0 {
+		bc.witnesses, err = newWitnessArchive(db, bc.cfg.WitnessHistory)
+		if err != nil {
+			return nil, err
+		}
+	}
 	// Start tx indexer if it's enabled.
 	if bc.cfg.TxLookupLimit >= 0 {
 		bc.txIndexer = newTxIndexer(uint64(bc.cfg.TxLookupLimit), bc)
 	}
+	// Start the rolling history pruner if it's enabled.
+	if bc.cfg.ChainHistoryMode == history.KeepRecent {
+		bc.historyPruner = newHistoryPruner(bc.cfg.ChainHistoryRecent, bc)
+	}
 	return bc, nil
 }
 
@@ -662,6 +685,9 @@ func (bc *BlockChain) loadLastState() error {
 	}
 	if pruning := bc.historyPrunePoint.Load(); pruning != nil {
 		log.Info("Chain history is pruned", "earliest", pruning.BlockNumber, "hash", pruning.BlockHash)
+		if tail := bc.HistoryRetrievalCutoff(); tail < pruning.BlockNumber {
+			log.Info("Pruned chain history is served from era store", "earliest", tail)
+		}
 	}
 	return nil
 }
@@ -706,6 +732,25 @@ func (bc *BlockChain) initializeHistoryPruning(latest uint64) error {
 		bc.historyPrunePoint.Store(predefinedPoint)
 		return nil
 
+	case history.KeepRecent:
+		if bc.cfg.ChainHistoryRecent == 0 {
+			return errors.New("history retention window is not configured")
+		}
+		// The pruning point is tracked by the freezer tail, which is moved
+		// forward by the history pruner along with the chain head.
+		if freezerTail == 0 {
+			return nil
+		}
+		hash := rawdb.ReadCanonicalHash(bc.db, freezerTail)
+		if hash == (common.Hash{}) {
+			return fmt.Errorf("canonical hash missing for history tail %d", freezerTail)
+		}
+		if latest+1 > bc.cfg.ChainHistoryRecent && freezerTail > latest+1-bc.cfg.ChainHistoryRecent {
+			log.Warn("Chain history is pruned beyond the configured window", "tail", freezerTail, "window", bc.cfg.ChainHistoryRecent)
+		}
+		bc.historyPrunePoint.Store(&history.PrunePoint{BlockNumber: freezerTail, BlockHash: hash})
+		return nil
+
 	default:
 		return fmt.Errorf("invalid history mode: %d", bc.cfg.ChainHistoryMode)
 	}
@@ -1241,6 +1286,10 @@ func (bc *BlockChain) stopWithoutSaving() {
 	if bc.txIndexer != nil {
 		bc.txIndexer.close()
 	}
+	// Signal shutdown history pruner.
+	if bc.historyPruner != nil {
+		bc.historyPruner.close()
+	}
 	// Unsubscribe all subscriptions registered from blockchain.
 	bc.scope.Close()
 
@@ -1312,6 +1361,9 @@ func (bc *BlockChain) Stop() {
 	if bc.logger != nil && bc.logger.OnClose != nil {
 		bc.logger.OnClose()
 	}
+	if bc.witnesses != nil {
+		bc.witnesses.close()
+	}
 	// Close the trie database, release all the held resources as the last step.
 	if err := bc.triedb.Close(); err != nil {
 		log.Error("Failed to close trie database", "err", err)
@@ -2011,10 +2063,11 @@ func (bc *BlockChain) processBlock(parentRoot common.Hash, block *types.Block, s
 	// useless due to the intermediate root hashing after each transaction.
 	var witness *stateless.Witness
 	if bc.chainConfig.IsByzantium(block.Number()) {
-		// Generate witnesses either if we're self-testing, or if it's the
-		// only block being inserted. A bit crude, but witnesses are huge,
-		// so we refuse to make an entire chain of them.
-		if bc.cfg.VmConfig.StatelessSelfValidation || makeWitness {
+		// Generate witnesses either if we're self-testing, archiving them, or
+		// if it's the only block being inserted. A bit crude, but witnesses
+		// are huge, so we refuse to make an entire chain of them otherwise.
+		archive := bc.witnesses != nil && bc.witnesses.wants(block.NumberU64(), bc.CurrentBlock().Number.Uint64())
+		if bc.cfg.VmConfig.StatelessSelfValidation || archive || makeWitness {
 			witness, err = stateless.NewWitness(block.Header(), bc)
 			if err != nil {
 				return nil, err
@@ -2051,6 +2104,19 @@ func (bc *BlockChain) processBlock(parentRoot common.Hash, block *types.Block, s
 		bc.reportBlock(block, res, err)
 		return nil, err
 	}
+	// If the block access list was generated, cross-check it against the
+	// execution. The check is skipped prior to Cancun, where the contract
+	// destruction can't be expressed by the access list.
+	if res.AccessList != nil && bc.chainConfig.IsCancun(block.Number(), block.Time()) {
+		parent, err := state.New(parentRoot, bc.statedb)
+		if err != nil {
+			return nil, err
+		}
+		if err := bc.validator.ValidateAccessList(block, parent, statedb, res.AccessList); err != nil {
+			bc.reportBlock(block, res, err)
+			return nil, err
+		}
+	}
 	vtime := time.Since(vstart)
 
 	// If witnesses was generated and stateless self-validation requested, do
@@ -2116,6 +2182,11 @@ func (bc *BlockChain) processBlock(parentRoot common.Hash, block *types.Block, s
 	if err != nil {
 		return nil, err
 	}
+	if witness != nil && bc.witnesses != nil {
+		if err := bc.witnesses.write(block, witness); err != nil {
+			log.Error("Failed to archive execution witness", "number", block.Number(), "hash", block.Hash(), "err", err)
+		}
+	}
 	// Update the metrics touched during block commit
 	accountCommitTimer.Update(statedb.AccountCommits)   // Account commits are complete, we can mark them
 	storageCommitTimer.Update(statedb.StorageCommits)   // Storage commits are complete, we can mark them
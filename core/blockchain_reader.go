@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/stateless"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/event"
@@ -370,6 +371,15 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 	return state.New(root, bc.statedb)
 }
 
+// GetWitness retrieves the archived execution witness of the given block, or
+// nil if the witness archive is disabled or the witness is not available.
+func (bc *BlockChain) GetWitness(hash common.Hash, number uint64) *stateless.Witness {
+	if bc.witnesses == nil {
+		return nil
+	}
+	return bc.witnesses.read(hash, number)
+}
+
 // HistoricState returns a historic state specified by the given root.
 // Live states are not available and won't be served, please use `State`
 // or `StateAt` instead.
@@ -441,6 +451,15 @@ func (bc *BlockChain) HistoryPruningCutoff() (uint64, common.Hash) {
 	return pt.BlockNumber, pt.BlockHash
 }
 
+// HistoryRetrievalCutoff returns the first block whose body and receipts can
+// be retrieved locally. It's below the history pruning point if the pruned chain
+// segment is served by the era store.
+func (bc *BlockChain) HistoryRetrievalCutoff() uint64 {
+	cutoff, _ := bc.HistoryPruningCutoff()
+	tail, _ := rawdb.ReadHistoryTail(bc.db)
+	return min(cutoff, tail)
+}
+
 // TrieDB retrieves the low level trie database used for data storage.
 func (bc *BlockChain) TrieDB() *triedb.Database {
 	return bc.triedb
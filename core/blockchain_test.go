@@ -4407,3 +4407,170 @@ func testInsertChainWithCutoff(t *testing.T, cutoff uint64, ancientLimit uint64,
 		}
 	}
 }
+
+// Tests that the chain history is truncated along with the chain head if the
+// chain is configured to retain the recent blocks only.
+func TestRecentHistoryPruning(t *testing.T) {
+	const (
+		chainLength = 3000
+		recent      = 512
+	)
+	var (
+		gspec = &Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, receipts := GenerateChainWithGenesis(gspec, engine, chainLength, func(i int, block *BlockGen) {
+		block.SetCoinbase(common.Address{0x00})
+	})
+	db, _ := rawdb.Open(rawdb.NewMemoryDatabase(), rawdb.OpenOptions{})
+	defer db.Close()
+
+	options := DefaultConfig().WithStateScheme(rawdb.PathScheme)
+	options.ChainHistoryMode = history.KeepRecent
+	options.ChainHistoryRecent = recent
+
+	chain, err := NewBlockChain(db, gspec, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertReceiptChain(blocks, types.EncodeBlockReceiptLists(receipts), chainLength); err != nil {
+		t.Fatalf("failed to insert receipt %d: %v", n, err)
+	}
+	// Nothing should be pruned if the tail is within the batch of the window.
+	chain.historyPruner.prune(historyPruneBatch + recent - 2)
+	if tail, _ := db.Tail(); tail != 0 {
+		t.Fatalf("unexpected chain tail, want: 0, got: %d", tail)
+	}
+	chain.historyPruner.prune(chainLength)
+
+	cutoff := uint64(chainLength + 1 - recent)
+	if tail, _ := db.Tail(); tail != cutoff {
+		t.Fatalf("unexpected chain tail, want: %d, got: %d", cutoff, tail)
+	}
+	if number, hash := chain.HistoryPruningCutoff(); number != cutoff || hash != blocks[cutoff-1].Hash() {
+		t.Fatalf("unexpected pruning cutoff, want: %d (%x), got: %d (%x)", cutoff, blocks[cutoff-1].Hash(), number, hash)
+	}
+	for _, block := range blocks {
+		num, hash := block.NumberU64(), block.Hash()
+		if header := chain.GetHeaderByNumber(num); header == nil || header.Hash() != hash {
+			t.Fatalf("block #%d: header missing", num)
+		}
+		if body := chain.GetBody(hash); (body != nil) != (num >= cutoff) {
+			t.Fatalf("block #%d: unexpected body presence, cutoff: %d", num, cutoff)
+		}
+	}
+}
+
+// Tests that the historical states are served from the indexed state histories
+// in path scheme archive mode, and the pruned ones are rejected explicitly. The
+// tries of the historical states are reconstructed from the state histories.
+func TestHistoricState(t *testing.T) {
+	const (
+		chainLength  = 300
+		stateHistory = 100
+	)
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address  = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.Address{0xaa}
+		gspec    = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				address: {Balance: big.NewInt(params.Ether)},
+				// SSTORE(0, NUMBER)
+				contract: {Balance: common.Big0, Code: []byte{byte(vm.NUMBER), byte(vm.PUSH1), 0x00, byte(vm.SSTORE)}},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, chainLength, func(i int, b *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(address), contract, big.NewInt(1), 50000, b.header.BaseFee, nil), signer, key)
+		b.AddTx(tx)
+	})
+	// State history indexing requires the freezer, create a persistent database.
+	datadir := t.TempDir()
+	pdb, err := pebble.New(datadir, 0, 0, "", false)
+	if err != nil {
+		t.Fatalf("Failed to create persistent key-value database: %v", err)
+	}
+	db, err := rawdb.Open(pdb, rawdb.OpenOptions{Ancient: path.Join(datadir, "ancient")})
+	if err != nil {
+		t.Fatalf("Failed to create persistent freezer database: %v", err)
+	}
+	defer db.Close()
+
+	options := DefaultConfig().WithStateScheme(rawdb.PathScheme).WithArchive(true)
+	options.StateHistory = stateHistory
+	chain, err := NewBlockChain(db, gspec, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	var (
+		head   = uint64(chainLength)
+		oldest = head - state.TriesInMemory - stateHistory + 1 // the oldest state with retained history
+	)
+	// Wait until the state histories are fully indexed.
+	for {
+		if _, err := chain.HistoricState(chain.GetHeaderByNumber(oldest).Root); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for number := uint64(0); number <= head; number++ {
+		root := chain.GetHeaderByNumber(number).Root
+		if _, err := chain.StateAt(root); err == nil {
+			continue // live state
+		}
+		statedb, err := chain.HistoricState(root)
+		if number < oldest {
+			if err == nil {
+				t.Fatalf("block #%d: pruned historical state is available", number)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("block #%d: failed to open historical state: %v", number, err)
+		}
+		if have, want := statedb.GetNonce(address), number; have != want {
+			t.Fatalf("block #%d: unexpected nonce, have %d, want %d", number, have, want)
+		}
+		if have, want := statedb.GetBalance(contract).Uint64(), number; have != want {
+			t.Fatalf("block #%d: unexpected balance, have %d, want %d", number, have, want)
+		}
+		if have, want := statedb.GetState(contract, common.Hash{}), common.BigToHash(new(big.Int).SetUint64(number)); have != want {
+			t.Fatalf("block #%d: unexpected storage, have %x, want %x", number, have, want)
+		}
+		if code := statedb.GetCode(contract); len(code) != 4 {
+			t.Fatalf("block #%d: unexpected code %x", number, code)
+		}
+		if err := statedb.Error(); err != nil {
+			t.Fatalf("block #%d: failed to read historical state: %v", number, err)
+		}
+		// The tries of the historical states are reconstructed on demand.
+		tr, err := statedb.Database().OpenTrie(root)
+		if err != nil {
+			t.Fatalf("block #%d: failed to open historical trie: %v", number, err)
+		}
+		if tr.Hash() != root {
+			t.Fatalf("block #%d: unexpected trie root, have %x, want %x", number, tr.Hash(), root)
+		}
+		st, err := statedb.Database().OpenStorageTrie(root, contract, statedb.GetStorageRoot(contract), tr)
+		if err != nil {
+			t.Fatalf("block #%d: failed to open historical storage trie: %v", number, err)
+		}
+		if slot, err := st.GetStorage(contract, common.Hash{}.Bytes()); err != nil || new(big.Int).SetBytes(slot).Uint64() != number {
+			t.Fatalf("block #%d: unexpected storage in trie, have %x, err: %v", number, slot, err)
+		}
+	}
+}
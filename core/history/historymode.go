@@ -32,10 +32,18 @@ const (
 
 	// KeepPostMerge sets the history pruning point to the merge activation block.
 	KeepPostMerge
+
+	// KeepRecent retains the bodies and receipts of a configured number of recent
+	// blocks only. The history pruning point moves forward along with the chain head.
+	KeepRecent
 )
 
+// DefaultRecentBlocks is the default number of blocks retained in KeepRecent
+// mode, which is approximately one year worth of blocks at a 12s block time.
+const DefaultRecentBlocks = 2628000
+
 func (m HistoryMode) IsValid() bool {
-	return m <= KeepPostMerge
+	return m <= KeepRecent
 }
 
 func (m HistoryMode) String() string {
@@ -44,6 +52,8 @@ func (m HistoryMode) String() string {
 		return "all"
 	case KeepPostMerge:
 		return "postmerge"
+	case KeepRecent:
+		return "recent"
 	default:
 		return fmt.Sprintf("invalid HistoryMode(%d)", m)
 	}
@@ -64,8 +74,10 @@ func (m *HistoryMode) UnmarshalText(text []byte) error {
 		*m = KeepAll
 	case "postmerge":
 		*m = KeepPostMerge
+	case "recent":
+		*m = KeepRecent
 	default:
-		return fmt.Errorf(`unknown sync mode %q, want "all" or "postmerge"`, text)
+		return fmt.Errorf(`unknown history mode %q, want "all", "postmerge" or "recent"`, text)
 	}
 	return nil
 }
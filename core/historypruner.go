@@ -0,0 +1,144 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// historyPruneBatch is the minimum number of blocks the history tail must lag
+// behind the retention window before a truncation is performed. It avoids
+// thrashing the freezer with tiny tail truncations on every new block.
+const historyPruneBatch = 2048
+
+// historyPruner is the module responsible for truncating the ancient chain
+// history when the chain is configured to retain recent blocks only (see
+// history.KeepRecent). The retention window moves forward along with the chain
+// head and the bodies and receipts falling outside of it are discarded from the
+// freezer.
+type historyPruner struct {
+	// limit is the number of recent blocks whose bodies and receipts are
+	// retained, [HEAD-limit+1, HEAD].
+	limit  uint64
+	chain  *BlockChain
+	term   chan chan struct{}
+	closed chan struct{}
+}
+
+// newHistoryPruner initializes the rolling history pruner.
+func newHistoryPruner(limit uint64, chain *BlockChain) *historyPruner {
+	pruner := &historyPruner{
+		limit:  limit,
+		chain:  chain,
+		term:   make(chan chan struct{}),
+		closed: make(chan struct{}),
+	}
+	go pruner.loop()
+
+	log.Info("Initialized chain history pruner", "range", limit)
+	return pruner
+}
+
+// target returns the block number below which the chain history should be
+// discarded for the given chain head. Only the blocks already moved into the
+// freezer are eligible for truncation. Additionally, the blocks whose transaction
+// indexes are still present are retained, as unindexing requires the bodies.
+func (p *historyPruner) target(head uint64) uint64 {
+	if head+1 <= p.limit {
+		return 0
+	}
+	target := head + 1 - p.limit
+
+	frozen, _ := p.chain.db.Ancients() // no error will occur, safe to ignore
+	target = min(target, frozen)
+
+	if p.chain.txIndexer != nil {
+		tail := rawdb.ReadTxIndexTail(p.chain.db)
+		if tail == nil {
+			return 0 // transaction indexing has not been started yet
+		}
+		target = min(target, *tail)
+	}
+	return target
+}
+
+// prune truncates the chain history below the retention window of the given
+// chain head.
+func (p *historyPruner) prune(head uint64) {
+	tail, err := p.chain.db.Tail()
+	if err != nil {
+		log.Error("Failed to retrieve chain history tail", "err", err)
+		return
+	}
+	target := p.target(head)
+	if target < tail+historyPruneBatch {
+		return
+	}
+	hash := rawdb.ReadCanonicalHash(p.chain.db, target)
+	if hash == (common.Hash{}) {
+		log.Error("Canonical hash of history pruning point is missing", "number", target)
+		return
+	}
+	// Move the pruning point forward prior to truncating the data, ensuring the
+	// APIs reject the requests with the pruned history error rather than fail
+	// with missing data.
+	start := time.Now()
+	p.chain.historyPrunePoint.Store(&history.PrunePoint{BlockNumber: target, BlockHash: hash})
+	if _, err := p.chain.db.TruncateTail(target); err != nil {
+		log.Error("Failed to truncate chain history", "tail", tail, "target", target, "err", err)
+		return
+	}
+	log.Debug("Pruned chain history", "from", tail, "to", target, "elapsed", common.PrettyDuration(time.Since(start)))
+}
+
+// loop is the scheduler of the pruner, truncating the chain history as the
+// chain head moves forward.
+func (p *historyPruner) loop() {
+	defer close(p.closed)
+
+	var (
+		headCh = make(chan ChainHeadEvent)
+		sub    = p.chain.SubscribeChainHeadEvent(headCh)
+	)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case h := <-headCh:
+			p.prune(h.Header.Number.Uint64())
+
+		case ch := <-p.term:
+			close(ch)
+			return
+		}
+	}
+}
+
+// close shuts down the pruner. Safe to be called for multiple times.
+func (p *historyPruner) close() {
+	ch := make(chan struct{})
+	select {
+	case p.term <- ch:
+		<-ch
+	case <-p.closed:
+	}
+}
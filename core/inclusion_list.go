@@ -0,0 +1,77 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrInclusionListUnsatisfied is returned if a block leaves out a transaction of
+// the inclusion list which it could have included.
+var ErrInclusionListUnsatisfied = errors.New("inclusion list unsatisfied")
+
+// VerifyInclusionList checks whether a block satisfies an inclusion list, as per
+// EIP-7805. Every transaction of the list has to be either included in the block,
+// or not includable at the end of it: not fitting into the remaining gas, or not
+// valid against the post-state because of its nonce, fee cap or the balance of
+// its sender.
+//
+// The given state must be the post-state of the block, and is not modified.
+func VerifyInclusionList(config *params.ChainConfig, block *types.Block, statedb *state.StateDB, inclusionList types.Transactions) error {
+	included := make(map[common.Hash]bool, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		included[tx.Hash()] = true
+	}
+	var (
+		signer  = types.MakeSigner(config, block.Number(), block.Time())
+		gasLeft = block.GasLimit() - block.GasUsed()
+	)
+	for _, tx := range inclusionList {
+		if included[tx.Hash()] {
+			continue
+		}
+		if tx.Gas() > gasLeft {
+			continue
+		}
+		// Blob transactions can't be in inclusion lists as their sidecars aren't
+		// propagated along, consider them not includable
+		if tx.Type() == types.BlobTxType {
+			continue
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		if statedb.GetNonce(from) != tx.Nonce() {
+			continue
+		}
+		if block.BaseFee() != nil && tx.GasFeeCapIntCmp(block.BaseFee()) < 0 {
+			continue
+		}
+		if statedb.GetBalance(from).ToBig().Cmp(tx.Cost()) < 0 {
+			continue
+		}
+		return fmt.Errorf("%w: missing transaction %#x", ErrInclusionListUnsatisfied, tx.Hash())
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	parallelSpeculativeMeter = metrics.NewRegisteredMeter("chain/parallel/speculative", nil)
+	parallelReexecMeter      = metrics.NewRegisteredMeter("chain/parallel/reexecuted", nil)
+)
+
+// NewParallelStateProcessor initialises a new StateProcessor which executes the
+// transactions of a block optimistically in parallel.
+//
+// The transactions are first executed speculatively, each on its own copy of
+// the state at the beginning of the block, with the state accesses recorded.
+// The speculative results are then validated and committed one by one in the
+// order of the transactions: if any state read by a transaction has since been
+// changed by the preceding ones, the transaction is re-executed sequentially on
+// the actual state. The receipts, logs and the state are therefore identical to
+// the ones produced by the sequential execution.
+func NewParallelStateProcessor(config *params.ChainConfig, chain *HeaderChain) *StateProcessor {
+	return &StateProcessor{
+		config:   config,
+		chain:    chain,
+		parallel: true,
+	}
+}
+
+// canProcessParallel reports whether the transactions in the given block are
+// eligible for parallel execution. The features relying on observing the state
+// transition step by step (tracing, witness collection, preimage recording,
+// block access list generation and the per-transaction state roots before
+// Byzantium) are only supported by the sequential execution.
+func (p *StateProcessor) canProcessParallel(block *types.Block, statedb *state.StateDB, cfg vm.Config) bool {
+	if !p.parallel || len(block.Transactions()) < 2 {
+		return false
+	}
+	if cfg.Tracer != nil || cfg.EnablePreimageRecording || cfg.BlockAccessList {
+		return false
+	}
+	if !p.config.IsByzantium(block.Number()) || p.config.IsVerkle(block.Number(), block.Time()) {
+		return false
+	}
+	return statedb.Witness() == nil && !statedb.Database().TrieDB().IsVerkle()
+}
+
+// processParallel applies the transactions of the block onto the given state
+// by executing them optimistically in parallel. The provided evm is used for
+// the sequential re-execution of the conflicting transactions.
+func (p *StateProcessor) processParallel(block *types.Block, statedb *state.StateDB, cfg vm.Config, evm *vm.EVM, gp *GasPool, usedGas *uint64) (types.Receipts, []*types.Log, error) {
+	var (
+		header      = block.Header()
+		blockHash   = block.Hash()
+		blockNumber = block.Number()
+		txs         = block.Transactions()
+		signer      = types.MakeSigner(p.config, header.Number, header.Time)
+
+		msgs  = make([]*Message, len(txs))
+		errs  = make([]error, len(txs))
+		specs = make([]*speculation, len(txs))
+		done  = make([]chan struct{}, len(txs))
+		tasks = make(chan int, len(txs))
+
+		base      = statedb.Copy() // state shared by the speculations, never mutated
+		blockCtx  = evm.Context
+		interrupt atomic.Bool
+		wg        sync.WaitGroup
+	)
+	for i, tx := range txs {
+		msgs[i], errs[i] = TransactionToMessage(tx, signer, header.BaseFee)
+		done[i] = make(chan struct{})
+		tasks <- i
+	}
+	close(tasks)
+
+	// Spin up the workers for executing the transactions speculatively. The
+	// workers are terminated once the processing is finished, regardless of
+	// whether the remaining speculations are still needed.
+	defer func() {
+		interrupt.Store(true)
+		wg.Wait()
+	}()
+	for n := 0; n < min(runtime.NumCPU(), len(txs)); n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range tasks {
+				if !interrupt.Load() && errs[i] == nil {
+					specs[i] = p.speculate(base, blockCtx, cfg, header, txs[i], i, msgs[i])
+				}
+				close(done[i])
+			}
+		}()
+	}
+	// Validate and commit the speculative results in order, re-executing the
+	// transactions whose speculation is invalidated by the preceding ones.
+	var (
+		receipts types.Receipts
+		allLogs  []*types.Log
+		reexec   int
+	)
+	for i, tx := range txs {
+		if errs[i] != nil {
+			return nil, nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), errs[i])
+		}
+		<-done[i]
+		statedb.SetTxContext(tx.Hash(), i)
+
+		receipt := specs[i].commit(statedb, evm, gp, usedGas, blockNumber, blockHash, header.Time, tx)
+		if receipt == nil {
+			var err error
+			receipt, err = ApplyTransactionWithEVM(msgs[i], gp, statedb, blockNumber, blockHash, header.Time, tx, usedGas, evm)
+			if err != nil {
+				return nil, nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			}
+			reexec++
+		}
+		receipts = append(receipts, receipt)
+		allLogs = append(allLogs, receipt.Logs...)
+	}
+	parallelSpeculativeMeter.Mark(int64(len(txs)))
+	parallelReexecMeter.Mark(int64(reexec))
+	return receipts, allLogs, nil
+}
+
+// speculation is the result of the speculative execution of a transaction.
+type speculation struct {
+	msg    *Message
+	result *ExecutionResult
+	err    error
+	state  *state.StateDB  // State after the speculative execution
+	access *accessRecorder // State accesses made by the transaction
+}
+
+// speculate executes the transaction on a private copy of the given base state,
+// recording the state accessed during the execution.
+func (p *StateProcessor) speculate(base *state.StateDB, blockCtx vm.BlockContext, cfg vm.Config, header *types.Header, tx *types.Transaction, index int, msg *Message) *speculation {
+	statedb := base.Copy()
+	statedb.SetTxContext(tx.Hash(), index)
+
+	// The block hash lookup caches the resolved hashes and thus is not safe for
+	// concurrent use, construct a dedicated one for each execution.
+	blockCtx.GetHash = GetHashFn(header, p.chain)
+
+	var (
+		access = newAccessRecorder(statedb, &blockCtx.Coinbase)
+		evm    = vm.NewEVM(blockCtx, access, p.config, cfg)
+		gp     = new(GasPool).AddGas(header.GasLimit)
+	)
+	result, err := ApplyMessage(evm, msg, gp)
+	if err == nil {
+		statedb.Finalise(true)
+	}
+	return &speculation{
+		msg:    msg,
+		result: result,
+		err:    err,
+		state:  statedb,
+		access: access,
+	}
+}
+
+// commit validates the speculative execution against the given state, which
+// contains the changes of all preceding transactions. If the speculation is
+// still valid, its state changes are applied onto the state and the receipt is
+// returned. Nil is returned if the transaction must be re-executed.
+func (s *speculation) commit(statedb *state.StateDB, evm *vm.EVM, gp *GasPool, usedGas *uint64, blockNumber *big.Int, blockHash common.Hash, blockTime uint64, tx *types.Transaction) *types.Receipt {
+	if s == nil || s.err != nil || s.state.Error() != nil {
+		return nil
+	}
+	if !s.access.validate(statedb) {
+		return nil
+	}
+	// The block gas limit is enforced on the actual gas pool, leave it to the
+	// sequential execution to report the error if it's exceeded.
+	if gp.SubGas(s.msg.GasLimit) != nil {
+		return nil
+	}
+	gp.AddGas(s.msg.GasLimit - s.result.UsedGas)
+
+	s.access.apply(statedb, s.state)
+	for _, l := range s.state.GetLogs(tx.Hash(), 0, common.Hash{}, 0) {
+		statedb.AddLog(&types.Log{Address: l.Address, Topics: l.Topics, Data: l.Data})
+	}
+	statedb.Finalise(true)
+	*usedGas += s.result.UsedGas
+
+	evm.SetTxContext(NewEVMTxContext(s.msg))
+	return MakeReceipt(evm, s.result, statedb, blockNumber, blockHash, blockTime, tx, *usedGas, nil)
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the optimistic parallel execution produces the identical state,
+// receipts and logs as the sequential execution, with both independent and
+// conflicting transactions in the blocks.
+func TestParallelProcessor(t *testing.T) {
+	var (
+		keys     []*ecdsa.PrivateKey
+		addrs    []common.Address
+		coinbase = common.Address{0xcb}
+		counter  = common.Address{0xc0} // SSTORE(0, SLOAD(0)+1)
+		logger   = common.Address{0x10} // LOG1(0, 0, CALLER)
+		observer = common.Address{0x0b} // SSTORE(0, BALANCE(COINBASE))
+		alloc    = types.GenesisAlloc{
+			coinbase: {Balance: big.NewInt(params.Ether)},
+			counter: {Code: []byte{
+				byte(vm.PUSH1), 0x00, byte(vm.SLOAD), byte(vm.PUSH1), 0x01, byte(vm.ADD),
+				byte(vm.PUSH1), 0x00, byte(vm.SSTORE),
+			}},
+			logger: {Code: []byte{
+				byte(vm.CALLER), byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.LOG1),
+			}},
+			observer: {Code: []byte{
+				byte(vm.COINBASE), byte(vm.BALANCE), byte(vm.PUSH1), 0x00, byte(vm.SSTORE),
+			}},
+		}
+		// Init code deploying a contract which self-destructs within the creation.
+		destructor = []byte{byte(vm.PUSH1), 0xbb, byte(vm.SELFDESTRUCT)}
+	)
+	for i := 0; i < 16; i++ {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+		addrs = append(addrs, crypto.PubkeyToAddress(key.PublicKey))
+		alloc[addrs[i]] = types.Account{Balance: big.NewInt(params.Ether)}
+	}
+	var (
+		gspec  = &Genesis{Config: params.TestChainConfig, Alloc: alloc}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 8, func(n int, b *BlockGen) {
+		b.SetCoinbase(coinbase)
+		gasPrice := new(big.Int).Mul(b.BaseFee(), big.NewInt(2))
+
+		for i, key := range keys {
+			tx := &types.LegacyTx{
+				Nonce:    b.TxNonce(addrs[i]),
+				To:       &addrs[(i+n)%len(addrs)],
+				Value:    big.NewInt(int64(1000 + i)),
+				Gas:      100000,
+				GasPrice: gasPrice,
+			}
+			switch (i + n) % 8 {
+			case 1:
+				tx.To = &counter
+			case 2:
+				tx.To = &logger
+			case 3:
+				tx.To = &common.Address{0xee, byte(n), byte(i)} // fresh account
+			case 4:
+				tx.To, tx.Value = &common.Address{0xdd}, new(big.Int) // empty account touched
+			case 5:
+				tx.To = &observer
+			case 6:
+				tx.To = &coinbase
+			case 7:
+				tx.To, tx.Data = nil, destructor
+			}
+			b.AddTx(types.MustSignNewTx(key, signer, tx))
+		}
+		// Chain of transactions from the same sender
+		for i := 0; i < 4; i++ {
+			b.AddTx(types.MustSignNewTx(keys[0], signer, &types.LegacyTx{
+				Nonce:    b.TxNonce(addrs[0]),
+				To:       &counter,
+				Value:    big.NewInt(1),
+				Gas:      100000,
+				GasPrice: gasPrice,
+			}))
+		}
+	})
+	sequential, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	defer sequential.Stop()
+
+	options := DefaultConfig()
+	options.Parallel = true
+	parallel, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, options)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	defer parallel.Stop()
+
+	if _, err := sequential.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert chain sequentially: %v", err)
+	}
+	if _, err := parallel.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert chain in parallel: %v", err)
+	}
+	for _, block := range blocks {
+		want := sequential.GetReceiptsByHash(block.Hash())
+		have := parallel.GetReceiptsByHash(block.Hash())
+		if !reflect.DeepEqual(have, want) {
+			t.Fatalf("Receipts mismatch in block %d", block.NumberU64())
+		}
+	}
+}
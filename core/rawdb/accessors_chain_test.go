@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -468,6 +469,45 @@ func TestAncientStorage(t *testing.T) {
 	}
 }
 
+// Tests that the history tail takes the era store backing the pruned chain
+// segment into account.
+func TestReadHistoryTail(t *testing.T) {
+	eradir, err := filepath.Abs(filepath.Join("eradb", "testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := Open(NewMemoryDatabase(), OpenOptions{Ancient: t.TempDir(), Era: eradir})
+	if err != nil {
+		t.Fatalf("failed to create database with ancient backend")
+	}
+	defer db.Close()
+
+	blocks := makeTestBlocks(8200, 0)
+	if _, err := WriteAncientBlocks(db, blocks, types.EncodeBlockReceiptLists(makeTestReceipts(len(blocks), 0))); err != nil {
+		t.Fatalf("failed to write ancient blocks: %v", err)
+	}
+	// The testdata directory contains the era1 files of epoch 0 and 21.
+	for _, tt := range []struct {
+		tail uint64
+		want uint64
+	}{
+		{0, 0},
+		{8192, 0},
+		{8195, 8195},
+	} {
+		if _, err := db.TruncateTail(tt.tail); err != nil {
+			t.Fatalf("failed to truncate tail: %v", err)
+		}
+		tail, err := ReadHistoryTail(db)
+		if err != nil {
+			t.Fatalf("failed to read history tail: %v", err)
+		}
+		if tail != tt.want {
+			t.Fatalf("history tail mismatch, tail: %d, want: %d, got: %d", tt.tail, tt.want, tail)
+		}
+	}
+}
+
 func TestWriteAncientHeaderChain(t *testing.T) {
 	db, err := Open(NewMemoryDatabase(), OpenOptions{Ancient: t.TempDir()})
 	if err != nil {
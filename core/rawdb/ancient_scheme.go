@@ -75,15 +75,42 @@ var stateFreezerTableConfigs = map[string]freezerTableConfig{
 	stateHistoryStorageData:  {noSnappy: false, prunable: true},
 }
 
+const (
+	// witnessTableSize defines the maximum size of freezer data files.
+	witnessTableSize = 2 * 1000 * 1000 * 1000
+
+	// witnessTable indicates the name of the freezer execution witness table.
+	witnessTable = "witnesses"
+)
+
+// witnessFreezerTableConfigs configures the settings for tables in the witness freezer.
+var witnessFreezerTableConfigs = map[string]freezerTableConfig{
+	witnessTable: {noSnappy: false, prunable: true},
+}
+
+const (
+	// traceTableSize defines the maximum size of freezer data files.
+	traceTableSize = 2 * 1000 * 1000 * 1000
+
+	// traceTable indicates the name of the freezer block trace table.
+	traceTable = "traces"
+)
+
+// traceFreezerTableConfigs configures the settings for tables in the trace freezer.
+var traceFreezerTableConfigs = map[string]freezerTableConfig{
+	traceTable: {noSnappy: false, prunable: true},
+}
+
 // The list of identifiers of ancient stores.
 var (
 	ChainFreezerName       = "chain"        // the folder name of chain segment ancient store.
 	MerkleStateFreezerName = "state"        // the folder name of state history ancient store.
 	VerkleStateFreezerName = "state_verkle" // the folder name of state history ancient store.
+	WitnessFreezerName     = "witness"      // the folder name of execution witness ancient store.
 )
 
 // freezers the collections of all builtin freezers.
-var freezers = []string{ChainFreezerName, MerkleStateFreezerName, VerkleStateFreezerName}
+var freezers = []string{ChainFreezerName, MerkleStateFreezerName, VerkleStateFreezerName, WitnessFreezerName}
 
 // NewStateFreezer initializes the ancient store for state history.
 //
@@ -103,3 +130,23 @@ func NewStateFreezer(ancientDir string, verkle bool, readOnly bool) (ethdb.Reset
 	}
 	return newResettableFreezer(name, "eth/db/state", readOnly, stateHistoryTableSize, stateFreezerTableConfigs)
 }
+
+// NewWitnessFreezer initializes the ancient store for execution witnesses.
+//
+//   - if the empty directory is given, initializes the pure in-memory
+//     witness freezer.
+//   - if non-empty directory is given, initializes the regular file-based
+//     witness freezer.
+func NewWitnessFreezer(ancientDir string, readOnly bool) (ethdb.ResettableAncientStore, error) {
+	if ancientDir == "" {
+		return NewMemoryFreezer(readOnly, witnessFreezerTableConfigs), nil
+	}
+	return newResettableFreezer(filepath.Join(ancientDir, WitnessFreezerName), "eth/db/witness", readOnly, witnessTableSize, witnessFreezerTableConfigs)
+}
+
+// NewTraceFreezer initializes the ancient store for the block traces archived
+// by the live tracers. Unlike the other freezers, the trace freezer is located
+// in the directory specified by the tracer.
+func NewTraceFreezer(datadir string, readOnly bool) (ethdb.ResettableAncientStore, error) {
+	return newResettableFreezer(datadir, "eth/tracers/archive", readOnly, traceTableSize, traceFreezerTableConfigs)
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// blockArchiveForks is the number of items of the blocks not in the archived
+// chain which are retained in memory, in case their fork overtakes the archived
+// chain.
+const blockArchiveForks = 16
+
+// blockArchiveItem is an item stored in the block archive. The placeholders
+// filling the gaps in the archived chain have no hash and data.
+type blockArchiveItem struct {
+	Number uint64
+	Hash   common.Hash
+	Parent common.Hash
+	Data   []byte
+}
+
+// BlockArchive maintains an item of data per block of the recent blocks, e.g.
+// their execution witnesses or traces, in a freezer table.
+//
+// The items are stored sequentially by block number, the freezer item at
+// position i belongs to the block with number offset+i. As the items are
+// written at import time, the archived chain follows the blocks as they are
+// imported: the items of other forks are kept aside in memory and only replace
+// the archived ones once their fork extends beyond the archived chain, namely
+// upon an actual reorg. The gaps in the block numbers are filled with empty
+// placeholders, so no archived item is dropped until it leaves the retention
+// window.
+type BlockArchive struct {
+	freezer ethdb.ResettableAncientStore
+	table   string
+	limit   uint64 // Number of recent blocks whose items are retained, zero means all
+	offset  uint64 // Block number of the first item in the freezer
+
+	forks lru.BasicLRU[common.Hash, *blockArchiveItem] // Recent items of the blocks not in the archived chain
+	lock  sync.RWMutex
+}
+
+// NewWitnessArchive opens the archive of execution witnesses on top of the given
+// witness freezer.
+func NewWitnessArchive(freezer ethdb.ResettableAncientStore, limit uint64) (*BlockArchive, error) {
+	return newBlockArchive(freezer, witnessTable, limit)
+}
+
+// NewTraceArchive opens the archive of block traces on top of the given trace
+// freezer.
+func NewTraceArchive(freezer ethdb.ResettableAncientStore, limit uint64) (*BlockArchive, error) {
+	return newBlockArchive(freezer, traceTable, limit)
+}
+
+func newBlockArchive(freezer ethdb.ResettableAncientStore, table string, limit uint64) (*BlockArchive, error) {
+	a := &BlockArchive{
+		freezer: freezer,
+		table:   table,
+		limit:   limit,
+		forks:   lru.NewBasicLRU[common.Hash, *blockArchiveItem](blockArchiveForks),
+	}
+	// Resolve the block number of the first archived item
+	tail, head, err := a.bounds()
+	if err != nil {
+		return nil, err
+	}
+	if tail < head {
+		item := a.item(tail)
+		if item == nil || item.Number < tail {
+			log.Warn("Resetting corrupted block archive", "table", table)
+			if err := freezer.Reset(); err != nil {
+				return nil, err
+			}
+		} else {
+			a.offset = item.Number - tail
+		}
+	}
+	return a, nil
+}
+
+// bounds returns the positions of the first and the next item in the freezer.
+func (a *BlockArchive) bounds() (uint64, uint64, error) {
+	tail, err := a.freezer.Tail()
+	if err != nil {
+		return 0, 0, err
+	}
+	head, err := a.freezer.Ancients()
+	if err != nil {
+		return 0, 0, err
+	}
+	return tail, head, nil
+}
+
+// item retrieves the item at the given position of the freezer.
+func (a *BlockArchive) item(id uint64) *blockArchiveItem {
+	blob, err := a.freezer.Ancient(a.table, id)
+	if err != nil || len(blob) == 0 {
+		return nil
+	}
+	item := new(blockArchiveItem)
+	if err := rlp.DecodeBytes(blob, item); err != nil {
+		log.Error("Failed to decode archived block item", "table", a.table, "id", id, "err", err)
+		return nil
+	}
+	return item
+}
+
+// append adds the given items to the head of the freezer, discarding the ones
+// falling out of the retention window.
+func (a *BlockArchive) append(items []*blockArchiveItem) error {
+	_, head, err := a.bounds()
+	if err != nil {
+		return err
+	}
+	_, err = a.freezer.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		for i, item := range items {
+			blob, err := rlp.EncodeToBytes(item)
+			if err != nil {
+				return err
+			}
+			if err := op.AppendRaw(a.table, head+uint64(i), blob); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	head += uint64(len(items))
+	if a.limit != 0 && head > a.limit {
+		if _, err := a.freezer.TruncateTail(head - a.limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write archives the item of the given block.
+func (a *BlockArchive) Write(number uint64, hash common.Hash, parent common.Hash, data []byte) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	tail, head, err := a.bounds()
+	if err != nil {
+		return err
+	}
+	var (
+		item = &blockArchiveItem{Number: number, Hash: hash, Parent: parent, Data: data}
+		next = a.offset + head
+	)
+	switch {
+	case tail == head:
+		// The archive is empty, start it from the given block
+		if err := a.freezer.Reset(); err != nil {
+			return err
+		}
+		a.offset = number
+		return a.append([]*blockArchiveItem{item})
+
+	case number < a.offset+tail:
+		// The block is out of the retention window
+		return nil
+
+	case number < next:
+		// The block is either re-imported or belongs to another fork, keep the
+		// item aside until the fork overtakes the archived chain.
+		if archived := a.item(number - a.offset); archived != nil && archived.Hash == hash {
+			return nil
+		}
+		a.forks.Add(hash, item)
+		return nil
+
+	case number == next:
+		if last := a.item(head - 1); last == nil || last.Hash == (common.Hash{}) || last.Hash == parent {
+			return a.append([]*blockArchiveItem{item})
+		}
+		return a.reorg(item)
+
+	default:
+		// There is a gap between the archived chain and the block, restart the
+		// archive if nothing retained would be left, otherwise fill the gap.
+		if a.limit != 0 && number-next >= a.limit {
+			if err := a.freezer.Reset(); err != nil {
+				return err
+			}
+			a.offset = number
+			return a.append([]*blockArchiveItem{item})
+		}
+		items := make([]*blockArchiveItem, 0, number-next+1)
+		for n := next; n < number; n++ {
+			items = append(items, &blockArchiveItem{Number: n})
+		}
+		return a.append(append(items, item))
+	}
+}
+
+// reorg replaces the archived chain from the fork point onwards with the fork
+// of the given item, which extends beyond the archived chain. The ancestors of
+// the item are resolved from the retained fork items, the replaced items are
+// kept aside in turn.
+func (a *BlockArchive) reorg(item *blockArchiveItem) error {
+	tail, _, err := a.bounds()
+	if err != nil {
+		return err
+	}
+	chain := []*blockArchiveItem{item}
+	for {
+		last := chain[len(chain)-1]
+		if last.Number == a.offset+tail {
+			break // the fork point is out of the retention window
+		}
+		if archived := a.item(last.Number - 1 - a.offset); archived != nil && archived.Hash == last.Parent {
+			break // the fork point is found
+		}
+		parent, ok := a.forks.Get(last.Parent)
+		if !ok || parent.Number != last.Number-1 {
+			break // the ancestors are unknown, replace as much as possible
+		}
+		chain = append(chain, parent)
+	}
+	first := chain[len(chain)-1].Number
+	for n := first; n < item.Number; n++ {
+		if replaced := a.item(n - a.offset); replaced != nil && replaced.Hash != (common.Hash{}) {
+			a.forks.Add(replaced.Hash, replaced)
+		}
+	}
+	if _, err := a.freezer.TruncateHead(first - a.offset); err != nil {
+		return err
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	for _, item := range chain {
+		a.forks.Remove(item.Hash)
+	}
+	return a.append(chain)
+}
+
+// Read retrieves the archived item of the given block, or nil if it's not
+// available.
+func (a *BlockArchive) Read(number uint64, hash common.Hash) []byte {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if number >= a.offset {
+		if item := a.item(number - a.offset); item != nil && item.Number == number && item.Hash == hash {
+			return item.Data
+		}
+	}
+	if item, ok := a.forks.Peek(hash); ok && item.Number == number {
+		return item.Data
+	}
+	return nil
+}
+
+// Close closes the underlying freezer of the archive.
+func (a *BlockArchive) Close() error {
+	return a.freezer.Close()
+}
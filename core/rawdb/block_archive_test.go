@@ -0,0 +1,102 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testArchiveBlock is a block whose item is archived in the tests.
+type testArchiveBlock struct {
+	number       uint64
+	hash, parent common.Hash
+}
+
+// makeArchiveChain creates a chain of blocks with the given fork identifier,
+// starting from the given parent.
+func makeArchiveChain(parent testArchiveBlock, n int, fork byte) []testArchiveBlock {
+	chain := make([]testArchiveBlock, n)
+	for i := range chain {
+		chain[i] = testArchiveBlock{
+			number: parent.number + 1,
+			hash:   common.Hash{fork, byte(parent.number + 1)},
+			parent: parent.hash,
+		}
+		parent = chain[i]
+	}
+	return chain
+}
+
+func TestBlockArchive(t *testing.T) {
+	archive, err := newBlockArchive(NewMemoryFreezer(false, witnessFreezerTableConfigs), witnessTable, 8)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	write := func(blocks ...testArchiveBlock) {
+		t.Helper()
+		for _, b := range blocks {
+			if err := archive.Write(b.number, b.hash, b.parent, b.hash.Bytes()); err != nil {
+				t.Fatalf("Failed to write block %d: %v", b.number, err)
+			}
+		}
+	}
+	check := func(available []testArchiveBlock, missing []testArchiveBlock) {
+		t.Helper()
+		for _, b := range available {
+			if data := archive.Read(b.number, b.hash); !bytes.Equal(data, b.hash.Bytes()) {
+				t.Fatalf("Block %d (%x): item not available", b.number, b.hash)
+			}
+		}
+		for _, b := range missing {
+			if data := archive.Read(b.number, b.hash); data != nil {
+				t.Fatalf("Block %d (%x): unexpected item", b.number, b.hash)
+			}
+		}
+	}
+	main := makeArchiveChain(testArchiveBlock{number: 99}, 6, 0x01) // 100-105
+	write(main...)
+	check(main, nil)
+
+	// Blocks of another fork below the head don't replace the archived ones
+	side := makeArchiveChain(main[2], 2, 0x02) // 103-104
+	write(side...)
+	check(append(main, side...), nil)
+
+	// The fork extending beyond the archived chain replaces it from the fork
+	// point, the replaced items are kept aside
+	side = append(side, makeArchiveChain(side[1], 2, 0x02)...) // 103-106
+	write(side[2:]...)
+	check(append(main, side...), nil)
+
+	// Reorging back onto the original chain restores it
+	main = append(main, makeArchiveChain(main[5], 2, 0x01)...) // 100-107
+	write(main[6:]...)
+	check(main, nil)
+
+	// Gaps are filled without dropping the items within the retention window
+	next := makeArchiveChain(testArchiveBlock{number: 109, hash: common.Hash{0xff}}, 2, 0x01) // 110-111
+	write(next...)
+	check(append(main[4:], next...), main[:4])
+
+	// Gaps beyond the retention window restart the archive
+	far := makeArchiveChain(testArchiveBlock{number: 199, hash: common.Hash{0xff}}, 1, 0x01) // 200
+	write(far...)
+	check(far, append(main, next...))
+}
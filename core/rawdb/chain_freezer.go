@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -49,6 +50,11 @@ type chainFreezer struct {
 	// Optional Era database used as a backup for the pruned chain.
 	eradb *eradb.Store
 
+	// First block number whose body and receipts are retrievable, refreshed
+	// whenever the freezer tail moves.
+	histTail     atomic.Uint64
+	histTailLock sync.Mutex
+
 	quit    chan struct{}
 	wg      sync.WaitGroup
 	trigger chan chan struct{} // Manual blocking freeze trigger, test determinism
@@ -76,12 +82,18 @@ func newChainFreezer(datadir string, eraDir string, namespace string, readonly b
 	if err != nil {
 		return nil, err
 	}
-	return &chainFreezer{
+	f := &chainFreezer{
 		ancients: freezer,
 		eradb:    edb,
 		quit:     make(chan struct{}),
 		trigger:  make(chan chan struct{}),
-	}, nil
+	}
+	tail, err := freezer.Tail()
+	if err != nil {
+		return nil, err
+	}
+	f.updateHistoryTail(tail)
+	return f, nil
 }
 
 // Close closes the chain freezer instance and terminates the background thread.
@@ -375,6 +387,34 @@ func (f *chainFreezer) Ancient(kind string, number uint64) ([]byte, error) {
 	return nil, errUnknownTable
 }
 
+// historyTail returns the first block number whose body and receipts are
+// retrievable, taking the optional era backend into account for the chain
+// segment below the freezer tail.
+func (f *chainFreezer) historyTail() (uint64, error) {
+	if f.eradb == nil {
+		return f.ancients.Tail()
+	}
+	return f.histTail.Load(), nil
+}
+
+// updateHistoryTail resolves the history tail for the given freezer tail. The
+// era files are only scanned here, as the freezer tail moves, rather than on
+// each lookup.
+func (f *chainFreezer) updateHistoryTail(tail uint64) {
+	if f.eradb == nil {
+		return
+	}
+	if tail != 0 {
+		number, err := f.eradb.Tail(tail)
+		if err != nil {
+			log.Error("Failed to resolve era store tail", "err", err)
+		} else {
+			tail = number
+		}
+	}
+	f.histTail.Store(tail)
+}
+
 // ReadAncients executes an operation while preventing mutations to the freezer,
 // i.e. if fn performs multiple reads, they will be consistent with each other.
 func (f *chainFreezer) ReadAncients(fn func(ethdb.AncientReaderOp) error) (err error) {
@@ -407,12 +447,34 @@ func (f *chainFreezer) ModifyAncients(fn func(ethdb.AncientWriteOp) error) (int6
 	return f.ancients.ModifyAncients(fn)
 }
 
+// TruncateHead discards any recent data above the provided threshold number,
+// moving the history tail along if the freezer tail is affected.
 func (f *chainFreezer) TruncateHead(items uint64) (uint64, error) {
-	return f.ancients.TruncateHead(items)
+	f.histTailLock.Lock()
+	defer f.histTailLock.Unlock()
+
+	old, err := f.ancients.TruncateHead(items)
+	if tail, terr := f.ancients.Tail(); terr == nil {
+		f.updateHistoryTail(tail)
+	}
+	return old, err
 }
 
+// TruncateTail discards any recent data below the provided threshold number.
+// The history tail is moved ahead prior to the truncation, ensuring the data
+// being discarded is not served in the meantime.
 func (f *chainFreezer) TruncateTail(items uint64) (uint64, error) {
-	return f.ancients.TruncateTail(items)
+	f.histTailLock.Lock()
+	defer f.histTailLock.Unlock()
+
+	if tail, err := f.ancients.Tail(); err == nil && items > tail {
+		f.updateHistoryTail(items)
+	}
+	old, err := f.ancients.TruncateTail(items)
+	if tail, terr := f.ancients.Tail(); terr == nil {
+		f.updateHistoryTail(tail)
+	}
+	return old, err
 }
 
 func (f *chainFreezer) SyncAncient() error {
@@ -193,6 +193,16 @@ func resolveChainEraDir(chainFreezerDir string, era string) string {
 	}
 }
 
+// ReadHistoryTail returns the first block number whose body and receipts are
+// retrievable from the database. Besides the chain freezer, the era store
+// backing the pruned chain segment is taken into account if it's available.
+func ReadHistoryTail(db ethdb.AncientReader) (uint64, error) {
+	if f, ok := db.(interface{ historyTail() (uint64, error) }); ok {
+		return f.historyTail()
+	}
+	return db.Tail()
+}
+
 // NewDatabaseWithFreezer creates a high level database on top of a given key-value store.
 // The passed ancient indicates the path of root ancient directory where the chain freezer
 // can be opened.
@@ -14,15 +14,21 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-// Package eradb implements a history backend using era1 files.
+// Package eradb implements a history backend using era1 and post-merge era files.
 package eradb
 
 import (
 	"bytes"
+	"cmp"
 	"errors"
 	"fmt"
 	"io/fs"
+	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common/lru"
@@ -35,17 +41,30 @@ const openFileLimit = 64
 
 var errClosed = errors.New("era store is closed")
 
-// Store manages read access to a directory of era1 files.
+// Store manages read access to a directory of era1 and post-merge era files.
 // The getter methods are thread-safe.
+//
+// Open files are cached by file key, which is the epoch number shifted left by
+// one bit, with the lowest bit set for post-merge archives. The epochs of era1
+// files are ranges of 8192 blocks, whereas the post-merge files are grouped by
+// slot era, so the block ranges they cover are indexed when opening the store.
 type Store struct {
 	datadir string
 
 	// The mutex protects all remaining fields.
-	mu      sync.Mutex
-	cond    *sync.Cond
-	lru     lru.BasicLRU[uint64, *fileCacheEntry]
-	opening map[uint64]*fileCacheEntry
-	closing bool
+	mu        sync.Mutex
+	cond      *sync.Cond
+	lru       lru.BasicLRU[uint64, *fileCacheEntry] // file key -> entry
+	opening   map[uint64]*fileCacheEntry            // file key -> entry
+	postMerge []eraRange                            // post-merge files, sorted by start
+	closing   bool
+}
+
+// eraRange is the block range covered by a post-merge era file.
+type eraRange struct {
+	epoch uint64 // slot era of the file
+	start uint64 // first block number
+	count uint64 // number of blocks
 }
 
 type fileCacheEntry struct {
@@ -72,10 +91,60 @@ func New(datadir string) (*Store, error) {
 		opening: make(map[uint64]*fileCacheEntry),
 	}
 	db.cond = sync.NewCond(&db.mu)
+	db.indexPostMerge()
 	log.Info("Opened Era store", "datadir", datadir)
 	return db, nil
 }
 
+// indexPostMerge scans the store directory for post-merge era files, indexing
+// the block ranges they cover.
+func (db *Store) indexPostMerge() {
+	matches, err := filepath.Glob(filepath.Join(db.datadir, "*-*-*.erae"))
+	if err != nil {
+		log.Warn("Failed to list post-merge era files", "err", err)
+		return
+	}
+	var ranges []eraRange
+	for _, filename := range matches {
+		// File name scheme is <network>-<epoch>-<root>.
+		parts := strings.Split(filepath.Base(filename), "-")
+		if len(parts) != 3 {
+			continue
+		}
+		epoch, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		e, err := era.Open(filename)
+		if err != nil {
+			log.Warn("Failed to open post-merge era file", "file", filename, "err", err)
+			continue
+		}
+		ranges = append(ranges, eraRange{epoch: epoch, start: e.Start(), count: e.Count()})
+		e.Close()
+	}
+	slices.SortFunc(ranges, func(a, b eraRange) int {
+		return cmp.Compare(a.start, b.start)
+	})
+	db.mu.Lock()
+	db.postMerge = ranges
+	db.mu.Unlock()
+}
+
+// postMergeRange returns the indexed post-merge file covering the given block.
+func (db *Store) postMergeRange(number uint64) (eraRange, bool) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	i := sort.Search(len(db.postMerge), func(i int) bool {
+		return db.postMerge[i].start+db.postMerge[i].count > number
+	})
+	if i == len(db.postMerge) || db.postMerge[i].start > number {
+		return eraRange{}, false
+	}
+	return db.postMerge[i], true
+}
+
 // Close closes all open era1 files in the cache.
 func (db *Store) Close() {
 	db.mu.Lock()
@@ -87,10 +156,10 @@ func (db *Store) Close() {
 	// Deref all active files. Since inactive files have a refcount of one, they will be
 	// closed right here and now after decrementing. Files which are currently being used
 	// have a refcount > 1 and will hit zero when their access finishes.
-	for _, epoch := range db.lru.Keys() {
-		entry, _ := db.lru.Peek(epoch)
-		if entry.derefAndClose(epoch) {
-			db.lru.Remove(epoch)
+	for _, key := range db.lru.Keys() {
+		entry, _ := db.lru.Peek(key)
+		if entry.derefAndClose(key) {
+			db.lru.Remove(key)
 		}
 	}
 
@@ -100,32 +169,104 @@ func (db *Store) Close() {
 	}
 }
 
-// GetRawBody returns the raw body for a given block number.
-func (db *Store) GetRawBody(number uint64) ([]byte, error) {
-	epoch := number / uint64(era.MaxEra1Size)
-	entry := db.getEraByEpoch(epoch)
+// Tail returns the first block number of the contiguous range of era files
+// which ends right before the given block number. The given number is returned
+// if the era file covering the preceding block is not present.
+func (db *Store) Tail(number uint64) (uint64, error) {
+	if number == 0 {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(db.datadir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return number, nil
+		}
+		return 0, err
+	}
+	// File name scheme is <network>-<epoch>-<root>.
+	var (
+		epochs = make(map[uint64]struct{})
+		last   uint64
+	)
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".era1" {
+			continue
+		}
+		parts := strings.Split(entry.Name(), "-")
+		if len(parts) != 3 {
+			continue
+		}
+		if epoch, err := strconv.ParseUint(parts[1], 10, 64); err == nil {
+			epochs[epoch] = struct{}{}
+			last = max(last, epoch)
+		}
+	}
+	// Refresh the post-merge index, as files might have been added or removed.
+	db.indexPostMerge()
+
+	for number > 0 {
+		if r, ok := db.postMergeRange(number - 1); ok {
+			number = r.start
+			continue
+		}
+		epoch := (number - 1) / uint64(era.MaxEra1Size)
+		if _, ok := epochs[epoch]; !ok {
+			break
+		}
+		// The last era1 file might end before the epoch does, being followed
+		// by the post-merge files.
+		if epoch == last {
+			covered, err := db.coversBlock(epoch<<1, number-1)
+			if err != nil {
+				return 0, err
+			}
+			if !covered {
+				break
+			}
+		}
+		number = epoch * uint64(era.MaxEra1Size)
+	}
+	return number, nil
+}
+
+// coversBlock reports whether the era file of the given key covers the block.
+func (db *Store) coversBlock(key uint64, number uint64) (bool, error) {
+	entry := db.getEraByKey(key)
 	if entry.err != nil {
 		if errors.Is(entry.err, fs.ErrNotExist) {
-			return nil, nil
+			return false, nil
 		}
+		return false, entry.err
+	}
+	defer db.doneWithFile(key, entry)
+
+	return number >= entry.file.Start() && number < entry.file.Start()+entry.file.Count(), nil
+}
+
+// GetRawBody returns the raw body for a given block number.
+func (db *Store) GetRawBody(number uint64) ([]byte, error) {
+	key, entry := db.getEraByNumber(number)
+	if entry == nil {
+		return nil, nil
+	}
+	if entry.err != nil {
 		return nil, entry.err
 	}
-	defer db.doneWithFile(epoch, entry)
+	defer db.doneWithFile(key, entry)
 
 	return entry.file.GetRawBodyByNumber(number)
 }
 
 // GetRawReceipts returns the raw receipts for a given block number.
 func (db *Store) GetRawReceipts(number uint64) ([]byte, error) {
-	epoch := number / uint64(era.MaxEra1Size)
-	entry := db.getEraByEpoch(epoch)
+	key, entry := db.getEraByNumber(number)
+	if entry == nil {
+		return nil, nil
+	}
 	if entry.err != nil {
-		if errors.Is(entry.err, fs.ErrNotExist) {
-			return nil, nil
-		}
 		return nil, entry.err
 	}
-	defer db.doneWithFile(epoch, entry)
+	defer db.doneWithFile(key, entry)
 
 	data, err := entry.file.GetRawReceiptsByNumber(number)
 	if err != nil {
@@ -189,11 +330,35 @@ func convertReceipts(input []byte) ([]byte, error) {
 	return out.Bytes(), nil
 }
 
-// getEraByEpoch opens an era file or gets it from the cache.
+// getEraByNumber opens the era file covering the given block number or gets it
+// from the cache. The era1 archive of the epoch is tried first, followed by the
+// indexed post-merge one. Nil is returned if the block is not covered by any file.
+func (db *Store) getEraByNumber(number uint64) (uint64, *fileCacheEntry) {
+	keys := []uint64{number / uint64(era.MaxEra1Size) << 1}
+	if r, ok := db.postMergeRange(number); ok {
+		keys = append(keys, r.epoch<<1|1)
+	}
+	for _, key := range keys {
+		entry := db.getEraByKey(key)
+		if entry.err != nil {
+			if errors.Is(entry.err, fs.ErrNotExist) {
+				continue
+			}
+			return key, entry
+		}
+		if number >= entry.file.Start() && number < entry.file.Start()+entry.file.Count() {
+			return key, entry
+		}
+		db.doneWithFile(key, entry)
+	}
+	return 0, nil
+}
+
+// getEraByKey opens an era file or gets it from the cache.
 // The caller can freely access the returned entry's .file and .err
 // db.doneWithFile must be called when it is done reading the file.
-func (db *Store) getEraByEpoch(epoch uint64) *fileCacheEntry {
-	stat, entry := db.getCacheEntry(epoch)
+func (db *Store) getEraByKey(key uint64) *fileCacheEntry {
+	stat, entry := db.getCacheEntry(key)
 
 	switch stat {
 	case storeClosing:
@@ -201,11 +366,11 @@ func (db *Store) getEraByEpoch(epoch uint64) *fileCacheEntry {
 
 	case fileIsNew:
 		// Open the file and put it into the cache.
-		e, err := db.openEraFile(epoch)
+		e, err := db.openEraFile(key)
 		if err != nil {
-			db.fileFailedToOpen(epoch, entry, err)
+			db.fileFailedToOpen(key, entry, err)
 		} else {
-			db.fileOpened(epoch, entry, e)
+			db.fileOpened(key, entry, e)
 		}
 		close(entry.opened)
 
@@ -223,16 +388,16 @@ func (db *Store) getEraByEpoch(epoch uint64) *fileCacheEntry {
 }
 
 // getCacheEntry gets an open era file from the cache.
-func (db *Store) getCacheEntry(epoch uint64) (stat fileCacheStatus, entry *fileCacheEntry) {
+func (db *Store) getCacheEntry(key uint64) (stat fileCacheStatus, entry *fileCacheEntry) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
 	if db.closing {
 		return storeClosing, nil
 	}
-	if entry = db.opening[epoch]; entry != nil {
+	if entry = db.opening[key]; entry != nil {
 		stat = fileIsOpening
-	} else if entry, _ = db.lru.Get(epoch); entry != nil {
+	} else if entry, _ = db.lru.Get(key); entry != nil {
 		stat = fileIsCached
 	} else {
 		// It's a new file, create an entry in the opening table. Note the entry is
@@ -241,7 +406,7 @@ func (db *Store) getCacheEntry(epoch uint64) (stat fileCacheStatus, entry *fileC
 		// accessed. When the store is closed or the file gets evicted from the cache,
 		// refcount will be decreased by one, thus allowing it to hit zero.
 		entry = &fileCacheEntry{refcount: 1, opened: make(chan struct{})}
-		db.opening[epoch] = entry
+		db.opening[key] = entry
 		stat = fileIsNew
 	}
 	entry.refcount++
@@ -249,11 +414,11 @@ func (db *Store) getCacheEntry(epoch uint64) (stat fileCacheStatus, entry *fileC
 }
 
 // fileOpened is called after an era file has been successfully opened.
-func (db *Store) fileOpened(epoch uint64, entry *fileCacheEntry, file *era.Era) {
+func (db *Store) fileOpened(key uint64, entry *fileCacheEntry, file *era.Era) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	delete(db.opening, epoch)
+	delete(db.opening, key)
 	db.cond.Signal() // db.opening was modified
 
 	// The database may have been closed while opening the file. When that happens, we
@@ -266,31 +431,35 @@ func (db *Store) fileOpened(epoch uint64, entry *fileCacheEntry, file *era.Era)
 
 	// Add it to the LRU. This may evict an existing item, which we have to close.
 	entry.file = file
-	evictedEpoch, evictedEntry, _ := db.lru.Add3(epoch, entry)
+	evictedKey, evictedEntry, _ := db.lru.Add3(key, entry)
 	if evictedEntry != nil {
-		evictedEntry.derefAndClose(evictedEpoch)
+		evictedEntry.derefAndClose(evictedKey)
 	}
 }
 
 // fileFailedToOpen is called when an era file could not be opened.
-func (db *Store) fileFailedToOpen(epoch uint64, entry *fileCacheEntry, err error) {
+func (db *Store) fileFailedToOpen(key uint64, entry *fileCacheEntry, err error) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	delete(db.opening, epoch)
+	delete(db.opening, key)
 	db.cond.Signal() // db.opening was modified
 	entry.err = err
 }
 
-func (db *Store) openEraFile(epoch uint64) (*era.Era, error) {
+func (db *Store) openEraFile(key uint64) (*era.Era, error) {
 	// File name scheme is <network>-<epoch>-<root>.
-	glob := fmt.Sprintf("*-%05d-*.era1", epoch)
+	epoch, ext := key>>1, "era1"
+	if key&1 == 1 {
+		ext = "erae"
+	}
+	glob := fmt.Sprintf("*-%05d-*.%s", epoch, ext)
 	matches, err := filepath.Glob(filepath.Join(db.datadir, glob))
 	if err != nil {
 		return nil, err
 	}
 	if len(matches) > 1 {
-		return nil, fmt.Errorf("multiple era1 files found for epoch %d", epoch)
+		return nil, fmt.Errorf("multiple %s files found for epoch %d", ext, epoch)
 	}
 	if len(matches) == 0 {
 		return nil, fs.ErrNotExist
@@ -301,27 +470,39 @@ func (db *Store) openEraFile(epoch uint64) (*era.Era, error) {
 	if err != nil {
 		return nil, err
 	}
-	// Sanity-check start block.
-	if e.Start()%uint64(era.MaxEra1Size) != 0 {
+	if e.IsPostMerge() {
+		// Post-merge archives are grouped by slot era, sanity-check it.
+		slot, _, err := e.BeaconAnchor()
+		if err != nil {
+			e.Close()
+			return nil, err
+		}
+		if slot/era.SlotsPerEra != epoch {
+			e.Close()
+			return nil, fmt.Errorf("post-merge era file has invalid slot era, epoch %d, first slot %d", epoch, slot)
+		}
+	} else if e.Start()%uint64(era.MaxEra1Size) != 0 {
+		// Sanity-check start block.
+		e.Close()
 		return nil, fmt.Errorf("pre-merge era1 file has invalid boundary. %d %% %d != 0", e.Start(), era.MaxEra1Size)
 	}
-	log.Debug("Opened era1 file", "epoch", epoch)
+	log.Debug("Opened era file", "epoch", epoch, "postmerge", e.IsPostMerge())
 	return e, nil
 }
 
 // doneWithFile signals that the caller has finished using a file.
 // This decrements the refcount and ensures the file is closed by the last user.
-func (db *Store) doneWithFile(epoch uint64, entry *fileCacheEntry) {
+func (db *Store) doneWithFile(key uint64, entry *fileCacheEntry) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
 	if entry.err != nil {
 		return
 	}
-	if entry.derefAndClose(epoch) {
+	if entry.derefAndClose(key) {
 		// Delete closed entry from LRU if it is still present.
-		if e, _ := db.lru.Peek(epoch); e == entry {
-			db.lru.Remove(epoch)
+		if e, _ := db.lru.Peek(key); e == entry {
+			db.lru.Remove(key)
 			db.cond.Signal() // db.lru was modified
 		}
 	}
@@ -329,7 +510,7 @@ func (db *Store) doneWithFile(epoch uint64, entry *fileCacheEntry) {
 
 // derefAndClose decrements the reference counter and closes the file
 // when it hits zero.
-func (entry *fileCacheEntry) derefAndClose(epoch uint64) (closed bool) {
+func (entry *fileCacheEntry) derefAndClose(key uint64) (closed bool) {
 	entry.refcount--
 	if entry.refcount > 0 {
 		return false
@@ -337,9 +518,9 @@ func (entry *fileCacheEntry) derefAndClose(epoch uint64) (closed bool) {
 
 	closeErr := entry.file.Close()
 	if closeErr == nil {
-		log.Debug("Closed era1 file", "epoch", epoch)
+		log.Debug("Closed era file", "epoch", key>>1, "postmerge", key&1 == 1)
 	} else {
-		log.Warn("Error closing era1 file", "epoch", epoch, "err", closeErr)
+		log.Warn("Error closing era file", "epoch", key>>1, "postmerge", key&1 == 1, "err", closeErr)
 	}
 	return true
 }
@@ -17,10 +17,15 @@
 package eradb
 
 import (
+	"math/big"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/era"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +53,97 @@ func TestEraDatabase(t *testing.T) {
 	assert.Equal(t, 3, len(receipts), "receipts length mismatch")
 }
 
+func TestEraDatabaseTail(t *testing.T) {
+	db, err := New("testdata")
+	require.NoError(t, err)
+	defer db.Close()
+
+	// The testdata directory contains the era1 files of epoch 0 and 21.
+	for _, tt := range []struct {
+		number uint64
+		want   uint64
+	}{
+		{0, 0},
+		{1, 0},
+		{8192, 0},
+		{8193, 8193},
+		{5 * 8192, 5 * 8192},
+		{21*8192 + 100, 21 * 8192},
+		{22 * 8192, 21 * 8192},
+		{22*8192 + 1, 22*8192 + 1},
+	} {
+		tail, err := db.Tail(tt.number)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, tail, "tail mismatch for number %d", tt.number)
+	}
+}
+
+func TestEraDatabasePostMerge(t *testing.T) {
+	// The first five blocks are stored in an era1 file and the remaining ones
+	// in the post-merge file of the third slot era.
+	var (
+		dir    = t.TempDir()
+		blocks []*types.Block
+		slot   = 3 * era.SlotsPerEra
+	)
+	for i := 0; i < 10; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), Difficulty: new(big.Int)}
+		if i < 5 {
+			header.Difficulty = big.NewInt(1)
+		} else {
+			header.Time = (slot + uint64(i-5)*2) * era.SecondsPerSlot
+			header.BaseFee = new(big.Int)
+			header.WithdrawalsHash = &types.EmptyWithdrawalsHash
+			header.BlobGasUsed, header.ExcessBlobGas = new(uint64), new(uint64)
+			header.ParentBeaconRoot = &common.Hash{byte(i)}
+		}
+		blocks = append(blocks, types.NewBlockWithHeader(header).WithBody(types.Body{}))
+	}
+	write := func(name string, builder func(f *os.File) *era.Builder, blocks []*types.Block, td *big.Int) {
+		f, err := os.Create(filepath.Join(dir, name))
+		require.NoError(t, err)
+		defer f.Close()
+
+		b := builder(f)
+		for _, block := range blocks {
+			var btd *big.Int
+			if td != nil {
+				btd = new(big.Int).Set(td.Add(td, block.Difficulty()))
+			}
+			require.NoError(t, b.Add(block, types.Receipts{}, btd))
+		}
+		_, err = b.Finalize()
+		require.NoError(t, err)
+	}
+	write(era.Filename("dev", 0, common.Hash{}), func(f *os.File) *era.Builder { return era.NewBuilder(f) }, blocks[:5], new(big.Int))
+	write(era.PostMergeFilename("dev", 3, common.Hash{}), func(f *os.File) *era.Builder {
+		b := era.NewPostMergeBuilder(f, slot)
+		b.SetLastBeaconRoot(common.Hash{0xff})
+		return b
+	}, blocks[5:], nil)
+
+	db, err := New(dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := range blocks {
+		r, err := db.GetRawBody(uint64(i))
+		require.NoError(t, err)
+		require.NotNil(t, r, "block body %d not found", i)
+
+		r, err = db.GetRawReceipts(uint64(i))
+		require.NoError(t, err)
+		require.NotNil(t, r, "receipts %d not found", i)
+	}
+	r, err := db.GetRawBody(uint64(len(blocks)))
+	require.NoError(t, err)
+	require.Nil(t, r, "block body beyond the archives found")
+
+	tail, err := db.Tail(uint64(len(blocks)))
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), tail)
+}
+
 func TestEraDatabaseConcurrentOpen(t *testing.T) {
 	db, err := New("testdata")
 	require.NoError(t, err)
@@ -17,14 +17,16 @@
 package state
 
 import (
-	"errors"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/trie/utils"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/ethereum/go-ethereum/triedb/pathdb"
@@ -33,11 +35,12 @@ import (
 // historicReader wraps a historical state reader defined in path database,
 // providing historic state serving over the path scheme.
 //
-// TODO(rjl493456442): historicReader is not thread-safe and does not fully
-// comply with the StateReader interface requirements, needs to be fixed.
-// Currently, it is only used in a non-concurrent context, so it is safe for now.
+// The underlying historical state reader caches the state index readers
+// internally, which is not thread-safe. The access is serialized by the lock
+// to comply with the StateReader interface requirements.
 type historicReader struct {
 	reader *pathdb.HistoricalStateReader
+	lock   sync.Mutex
 }
 
 // newHistoricReader constructs a reader for historic state serving.
@@ -52,7 +55,10 @@ func newHistoricReader(r *pathdb.HistoricalStateReader) *historicReader {
 //
 // The returned account might be nil if it's not existent.
 func (r *historicReader) Account(addr common.Address) (*types.StateAccount, error) {
+	r.lock.Lock()
 	account, err := r.reader.Account(addr)
+	r.lock.Unlock()
+
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +88,10 @@ func (r *historicReader) Account(addr common.Address) (*types.StateAccount, erro
 //
 // The returned storage slot might be empty if it's not existent.
 func (r *historicReader) Storage(addr common.Address, key common.Hash) (common.Hash, error) {
+	r.lock.Lock()
 	blob, err := r.reader.Storage(addr, key)
+	r.lock.Unlock()
+
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -100,12 +109,25 @@ func (r *historicReader) Storage(addr common.Address, key common.Hash) (common.H
 
 // HistoricDB is the implementation of Database interface, with the ability to
 // access historical state.
+//
+// It's only available in the path scheme, with the state history indexing
+// enabled (archive mode). The states older than the persistent disk layer are
+// resolved from the indexed state histories, which are retained for the recent
+// blocks configured by the state history limit (the entire chain if zero).
+//
+// The state is served in the flat format. The trie nodes of the historical
+// states are not retained, but reconstructed on demand from the state histories
+// once the tries are opened, e.g. for generating the Merkle proofs. It can be
+// expensive for the states far behind the chain head.
 type HistoricDB struct {
 	disk          ethdb.KeyValueStore
 	triedb        *triedb.Database
 	codeCache     *lru.SizeConstrainedCache[common.Hash, []byte]
 	codeSizeCache *lru.Cache[common.Hash, int]
 	pointCache    *utils.PointCache
+
+	nodes     *pathdb.HistoricalNodeDatabase // Lazily reconstructed trie nodes of the historical state
+	nodesLock sync.Mutex
 }
 
 // NewHistoricDatabase creates a historic state database.
@@ -128,15 +150,43 @@ func (db *HistoricDB) Reader(stateRoot common.Hash) (Reader, error) {
 	return newReader(newCachingCodeReader(db.disk, db.codeCache, db.codeSizeCache), newHistoricReader(hr)), nil
 }
 
-// OpenTrie opens the main account trie. It's not supported by historic database.
+// nodeDatabase returns the node database of the specified historical state,
+// reconstructing the trie nodes if it's not yet done.
+func (db *HistoricDB) nodeDatabase(stateRoot common.Hash) (*pathdb.HistoricalNodeDatabase, error) {
+	db.nodesLock.Lock()
+	defer db.nodesLock.Unlock()
+
+	if db.nodes != nil {
+		if _, err := db.nodes.NodeReader(stateRoot); err == nil {
+			return db.nodes, nil
+		}
+	}
+	nodes, err := db.triedb.HistoricNodeDatabase(stateRoot)
+	if err != nil {
+		return nil, err
+	}
+	db.nodes = nodes
+	return nodes, nil
+}
+
+// OpenTrie opens the main account trie of the historical state. The trie nodes
+// are reconstructed from the state histories.
 func (db *HistoricDB) OpenTrie(root common.Hash) (Trie, error) {
-	return nil, errors.New("not implemented")
+	nodes, err := db.nodeDatabase(root)
+	if err != nil {
+		return nil, err
+	}
+	return trie.NewStateTrie(trie.StateTrieID(root), nodes)
 }
 
-// OpenStorageTrie opens the storage trie of an account. It's not supported by
-// historic database.
-func (db *HistoricDB) OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash, trie Trie) (Trie, error) {
-	return nil, errors.New("not implemented")
+// OpenStorageTrie opens the storage trie of an account in the historical state.
+// The trie nodes are reconstructed from the state histories.
+func (db *HistoricDB) OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash, self Trie) (Trie, error) {
+	nodes, err := db.nodeDatabase(stateRoot)
+	if err != nil {
+		return nil, err
+	}
+	return trie.NewStateTrie(trie.StorageTrieID(stateRoot, crypto.Keccak256Hash(address.Bytes()), root), nodes)
 }
 
 // PointCache returns the cache holding points used in verkle tree key computation
@@ -1423,6 +1423,22 @@ func (s *StateDB) SlotInAccessList(addr common.Address, slot common.Hash) (addre
 	return s.accessList.Contains(addr, slot)
 }
 
+// Mutations returns the accounts mutated since the state was opened, along with
+// the storage slots written to each of them. The mutations are only tracked at
+// the transaction boundaries, so the state must be finalised beforehand. The
+// storage slots of deleted accounts are not reported.
+func (s *StateDB) Mutations() map[common.Address][]common.Hash {
+	mutated := make(map[common.Address][]common.Hash, len(s.mutations))
+	for addr := range s.mutations {
+		var slots []common.Hash
+		if obj := s.stateObjects[addr]; obj != nil {
+			slots = slices.Collect(maps.Keys(obj.pendingStorage))
+		}
+		mutated[addr] = slots
+	}
+	return mutated
+}
+
 // markDelete is invoked when an account is deleted but the deletion is
 // not yet committed. The pending mutation is cached and will be applied
 // all together
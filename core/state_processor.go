@@ -35,8 +35,9 @@ import (
 //
 // StateProcessor implements Processor.
 type StateProcessor struct {
-	config *params.ChainConfig // Chain configuration options
-	chain  *HeaderChain        // Canonical header chain
+	config   *params.ChainConfig // Chain configuration options
+	chain    *HeaderChain        // Canonical header chain
+	parallel bool                // Whether to execute the transactions optimistically in parallel
 }
 
 // NewStateProcessor initialises a new StateProcessor.
@@ -79,6 +80,13 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	if hooks := cfg.Tracer; hooks != nil {
 		tracingStateDB = state.NewHookedState(statedb, hooks)
 	}
+	// Record the state accesses for constructing the block access list if
+	// it's requested.
+	var bal *accessListBuilder
+	if cfg.BlockAccessList {
+		bal = newAccessListBuilder(tracingStateDB)
+		tracingStateDB = bal.recorder
+	}
 	context = NewEVMBlockContext(header, p.chain, nil)
 	evm := vm.NewEVM(context, tracingStateDB, p.config, cfg)
 
@@ -88,21 +96,36 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	if p.config.IsPrague(block.Number(), block.Time()) || p.config.IsVerkle(block.Number(), block.Time()) {
 		ProcessParentBlockHash(block.ParentHash(), evm)
 	}
+	if bal != nil {
+		bal.collect(0)
+	}
 
 	// Iterate over and process the individual transactions
-	for i, tx := range block.Transactions() {
-		msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+	if p.canProcessParallel(block, statedb, cfg) {
+		var err error
+		receipts, allLogs, err = p.processParallel(block, statedb, cfg, evm, gp, usedGas)
 		if err != nil {
-			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			return nil, err
 		}
-		statedb.SetTxContext(tx.Hash(), i)
+	} else {
+		for i, tx := range block.Transactions() {
+			msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+			if err != nil {
+				return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			}
+			statedb.SetTxContext(tx.Hash(), i)
 
-		receipt, err := ApplyTransactionWithEVM(msg, gp, statedb, blockNumber, blockHash, context.Time, tx, usedGas, evm)
-		if err != nil {
-			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			receipt, err := ApplyTransactionWithEVM(msg, gp, statedb, blockNumber, blockHash, context.Time, tx, usedGas, evm)
+			if err != nil {
+				return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			}
+			receipts = append(receipts, receipt)
+			allLogs = append(allLogs, receipt.Logs...)
+
+			if bal != nil {
+				bal.collect(uint16(i + 1))
+			}
 		}
-		receipts = append(receipts, receipt)
-		allLogs = append(allLogs, receipt.Logs...)
 	}
 	// Read requests if Prague is enabled.
 	var requests [][]byte
@@ -125,11 +148,17 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
 	p.chain.engine.Finalize(p.chain, header, tracingStateDB, block.Body())
 
+	var accessList *types.BlockAccessList
+	if bal != nil {
+		bal.collect(uint16(len(block.Transactions()) + 1))
+		accessList = bal.build()
+	}
 	return &ProcessResult{
-		Receipts: receipts,
-		Requests: requests,
-		Logs:     allLogs,
-		GasUsed:  *usedGas,
+		Receipts:   receipts,
+		Requests:   requests,
+		Logs:       allLogs,
+		GasUsed:    *usedGas,
+		AccessList: accessList,
 	}, nil
 }
 
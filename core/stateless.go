@@ -17,8 +17,12 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -78,3 +82,69 @@ func ExecuteStateless(config *params.ChainConfig, vmconfig vm.Config, block *typ
 	stateRoot := db.IntermediateRoot(config.IsEIP158(block.Number()))
 	return stateRoot, receiptRoot, nil
 }
+
+// VerifyStateless re-executes the given block statelessly based on the witness,
+// without relying on any local database, and checks the computed state root and
+// receipt root against the ones in the block header. The computed roots are
+// returned regardless of whether they match the header or not.
+func VerifyStateless(config *params.ChainConfig, vmconfig vm.Config, block *types.Block, witness *stateless.Witness) (common.Hash, common.Hash, error) {
+	if len(witness.Headers) == 0 {
+		return common.Hash{}, common.Hash{}, errors.New("witness contains no parent header")
+	}
+	if parent := witness.Headers[0].Hash(); parent != block.ParentHash() {
+		return common.Hash{}, common.Hash{}, fmt.Errorf("witness parent mismatch (block: %x witness: %x)", block.ParentHash(), parent)
+	}
+	// Remove the fields to be computed from the block to force the recalculation
+	header := block.Header()
+	header.Root = common.Hash{}
+	header.ReceiptHash = common.Hash{}
+	task := types.NewBlockWithHeader(header).WithBody(*block.Body())
+
+	stateRoot, receiptRoot, err := ExecuteStateless(config, vmconfig, task, witness)
+	if err != nil {
+		return common.Hash{}, common.Hash{}, err
+	}
+	if stateRoot != block.Root() {
+		return stateRoot, receiptRoot, fmt.Errorf("state root mismatch (remote: %x local: %x)", block.Root(), stateRoot)
+	}
+	if receiptRoot != block.ReceiptHash() {
+		return stateRoot, receiptRoot, fmt.Errorf("receipt root mismatch (remote: %x local: %x)", block.ReceiptHash(), receiptRoot)
+	}
+	return stateRoot, receiptRoot, nil
+}
+
+// GenerateWitness re-executes the given block on top of its parent state and
+// returns the execution witness collected along the way. The parent state must
+// be available as live tries, the historical states are not supported.
+func (bc *BlockChain) GenerateWitness(block *types.Block) (*stateless.Witness, error) {
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not executable")
+	}
+	if !bc.chainConfig.IsByzantium(block.Number()) {
+		return nil, errors.New("witness is not supported before byzantium")
+	}
+	parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	statedb, err := bc.StateAt(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	witness, err := stateless.NewWitness(block.Header(), bc)
+	if err != nil {
+		return nil, err
+	}
+	statedb.StartPrefetcher("witness", witness)
+	defer statedb.StopPrefetcher()
+
+	res, err := bc.processor.Process(block, statedb, vm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	// Validate the post state, resolving the trie nodes touched by the hashing
+	if err := bc.validator.ValidateState(block, statedb, res, false); err != nil {
+		return nil, err
+	}
+	return witness, nil
+}
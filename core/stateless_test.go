@@ -0,0 +1,105 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that a block can be verified purely based on the witness produced
+// during its import, and that the verification rejects tampered blocks.
+func TestVerifyStateless(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender  = crypto.PubkeyToAddress(key.PublicKey)
+		counter = common.Address{0xc0} // SSTORE(0, SLOAD(0)+1)
+		gspec   = &Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender:                    {Balance: big.NewInt(params.Ether)},
+				params.BeaconRootsAddress: {Code: params.BeaconRootsCode},
+				counter: {Code: []byte{
+					byte(vm.PUSH1), 0x00, byte(vm.SLOAD), byte(vm.PUSH1), 0x01, byte(vm.ADD),
+					byte(vm.PUSH1), 0x00, byte(vm.SSTORE),
+				}},
+			},
+		}
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *BlockGen) {
+		b.SetParentBeaconRoot(common.Hash{byte(i + 1)})
+		b.AddTx(types.MustSignNewTx(key, b.Signer(), &types.LegacyTx{
+			Nonce:    b.TxNonce(sender),
+			To:       &counter,
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+		}))
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("Failed to insert chain: %v", err)
+	}
+	block := blocks[1]
+	witness, err := chain.InsertBlockWithoutSetHead(block, true)
+	if err != nil {
+		t.Fatalf("Failed to insert block: %v", err)
+	}
+	// Round-trip the witness through its encoding to mimic loading it from disk
+	enc, err := rlp.EncodeToBytes(witness)
+	if err != nil {
+		t.Fatalf("Failed to encode witness: %v", err)
+	}
+	witness = new(stateless.Witness)
+	if err := rlp.DecodeBytes(enc, witness); err != nil {
+		t.Fatalf("Failed to decode witness: %v", err)
+	}
+	stateRoot, receiptRoot, err := VerifyStateless(gspec.Config, vm.Config{}, block, witness)
+	if err != nil {
+		t.Fatalf("Failed to verify block: %v", err)
+	}
+	if stateRoot != block.Root() || receiptRoot != block.ReceiptHash() {
+		t.Fatalf("Root mismatch: state %x, want %x, receipt %x, want %x", stateRoot, block.Root(), receiptRoot, block.ReceiptHash())
+	}
+	// Tamper with the block header and ensure the verification fails
+	header := block.Header()
+	header.Root = common.Hash{0x01}
+	if _, _, err := VerifyStateless(gspec.Config, vm.Config{}, block.WithSeal(header), witness); err == nil {
+		t.Fatalf("Expected state root mismatch")
+	}
+	header = block.Header()
+	header.ParentHash = common.Hash{0x01}
+	if _, _, err := VerifyStateless(gspec.Config, vm.Config{}, block.WithSeal(header), witness); err == nil {
+		t.Fatalf("Expected parent mismatch")
+	}
+}
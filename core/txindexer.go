@@ -21,6 +21,7 @@ import (
 	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
@@ -68,7 +69,18 @@ type txIndexer struct {
 
 // newTxIndexer initializes the transaction indexer.
 func newTxIndexer(limit uint64, chain *BlockChain) *txIndexer {
-	cutoff, _ := chain.HistoryPruningCutoff()
+	// The transactions of the pruned chain segment are still indexed if the
+	// bodies are retrievable from the era store.
+	cutoff := chain.HistoryRetrievalCutoff()
+
+	// The bodies outside of the rolling history window are discarded, cap the
+	// indexing range accordingly, ensuring the stale indexes are removed before
+	// the relevant bodies are pruned.
+	if chain.cfg.ChainHistoryMode == history.KeepRecent {
+		if recent := chain.cfg.ChainHistoryRecent; limit == 0 || limit > recent {
+			limit = recent
+		}
+	}
 	indexer := &txIndexer{
 		limit:  limit,
 		cutoff: cutoff,
@@ -323,8 +323,9 @@ type BlobPool struct {
 	spent  map[common.Address]*uint256.Int  // Expenditure tracking for individual accounts
 	evict  *evictHeap                       // Heap of cheapest accounts for eviction when full
 
-	discoverFeed event.Feed // Event feed to send out new tx events on pool discovery (reorg excluded)
-	insertFeed   event.Feed // Event feed to send out new tx events on pool inclusion (reorg included)
+	discoverFeed event.Feed         // Event feed to send out new tx events on pool discovery (reorg excluded)
+	insertFeed   event.Feed         // Event feed to send out new tx events on pool inclusion (reorg included)
+	txEvents     txpool.TxEventFeed // Event feed to send out transaction lifecycle events
 
 	// txValidationFn defaults to txpool.ValidateTransaction, but can be
 	// overridden for testing purposes.
@@ -417,7 +418,7 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reser
 	// Sort the indexed transactions by nonce and delete anything gapped, create
 	// the eviction heap of anyone still standing
 	for addr := range p.index {
-		p.recheck(addr, nil)
+		p.recheck(addr, nil, nil)
 	}
 	var (
 		basefee = uint256.MustFromBig(eip1559.CalcBaseFee(p.chain.Config(), p.head))
@@ -454,6 +455,8 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reser
 
 // Close closes down the underlying persistent store.
 func (p *BlobPool) Close() error {
+	p.txEvents.Close()
+
 	var errs []error
 	if p.limbo != nil { // Close might be invoked due to error in constructor, before p,limbo is set
 		if err := p.limbo.Close(); err != nil {
@@ -522,7 +525,10 @@ func (p *BlobPool) parseTransaction(id uint64, size uint32, blob []byte) error {
 
 // recheck verifies the pool's content for a specific account and drops anything
 // that does not fit anymore (dangling or filled nonce, overdraft).
-func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint64) {
+//
+// The included set is used to report the filled transactions as included or as
+// replaced by a different one, it's nil if the blocks moved onto are unknown.
+func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint64, included *txpool.TxInclusions) {
 	// Sort the transactions belonging to the account so reinjects can be simpler
 	txs := p.index[addr]
 	if inclusions != nil && txs == nil { // during reorgs, we might find new accounts
@@ -551,6 +557,13 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.stored -= uint64(txs[i].storageSize)
 			p.lookup.untrack(txs[i])
 
+			if gapped {
+				p.recordEvent(txpool.TxEventDrop, addr, txs[i], txpool.DropGapped, common.Hash{})
+			} else {
+				typ, reason, by := included.Consumed(addr, txs[i].nonce, txs[i].hash)
+				p.recordEvent(typ, addr, txs[i], reason, by)
+			}
+
 			// Included transactions blobs need to be moved to the limbo
 			if filled && inclusions != nil {
 				p.offload(addr, txs[i].nonce, txs[i].id, inclusions)
@@ -592,6 +605,9 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.stored -= uint64(txs[0].storageSize)
 			p.lookup.untrack(txs[0])
 
+			typ, reason, by := included.Consumed(addr, txs[0].nonce, txs[0].hash)
+			p.recordEvent(typ, addr, txs[0], reason, by)
+
 			// Included transactions blobs need to be moved to the limbo
 			if inclusions != nil {
 				p.offload(addr, txs[0].nonce, txs[0].id, inclusions)
@@ -647,6 +663,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
 			p.stored -= uint64(txs[i].storageSize)
 			p.lookup.untrack(txs[i])
+			p.recordEvent(txpool.TxEventDrop, addr, txs[i], txpool.DropInvalid, common.Hash{})
 
 			if err := p.store.Delete(id); err != nil {
 				log.Error("Failed to delete blob transaction", "from", addr, "id", id, "err", err)
@@ -669,6 +686,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[j].costCap)
 			p.stored -= uint64(txs[j].storageSize)
 			p.lookup.untrack(txs[j])
+			p.recordEvent(txpool.TxEventDrop, addr, txs[j], txpool.DropGapped, common.Hash{})
 		}
 		txs = txs[:i]
 
@@ -707,6 +725,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.storageSize)
 			p.lookup.untrack(last)
+			p.recordEvent(txpool.TxEventDrop, addr, last, txpool.DropNoFunds, common.Hash{})
 		}
 		if len(txs) == 0 {
 			delete(p.index, addr)
@@ -747,6 +766,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.storageSize)
 			p.lookup.untrack(last)
+			p.recordEvent(txpool.TxEventDrop, addr, last, txpool.DropAccountCap, common.Hash{})
 		}
 		p.index[addr] = txs
 
@@ -798,6 +818,8 @@ func (p *BlobPool) offload(addr common.Address, nonce uint64, id uint64, inclusi
 // Reset implements txpool.SubPool, allowing the blob pool's internal state to be
 // kept in sync with the main transaction pool's internal state.
 func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
+	defer p.txEvents.Flush()
+
 	waitStart := time.Now()
 	p.lock.Lock()
 	resetwaitHist.Update(time.Since(waitStart).Nanoseconds())
@@ -817,7 +839,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 
 	// Run the reorg between the old and new head and figure out which accounts
 	// need to be rechecked and which transactions need to be readded
-	if reinject, inclusions := p.reorg(oldHead, newHead); reinject != nil {
+	if reinject, inclusions, included := p.reorg(oldHead, newHead); reinject != nil {
 		var adds []*types.Transaction
 		for addr, txs := range reinject {
 			// Blindly push all the lost transactions back into the pool
@@ -828,7 +850,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 			}
 			// Recheck the account's pooled transactions to drop included and
 			// invalidated ones
-			p.recheck(addr, inclusions)
+			p.recheck(addr, inclusions, included)
 		}
 		if len(adds) > 0 {
 			p.insertFeed.Send(core.NewTxsEvent{Txs: adds})
@@ -858,18 +880,19 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 // which transactions need to be requeued.
 //
 // The transactionblock inclusion infos are also returned to allow tracking any
-// just-included blocks by block number in the limbo.
-func (p *BlobPool) reorg(oldHead, newHead *types.Header) (map[common.Address][]*types.Transaction, map[common.Hash]uint64) {
+// just-included blocks by block number in the limbo, along with the included set
+// to report the lifecycle of the transactions dropped from the pool.
+func (p *BlobPool) reorg(oldHead, newHead *types.Header) (map[common.Address][]*types.Transaction, map[common.Hash]uint64, *txpool.TxInclusions) {
 	// If the pool was not yet initialized, don't do anything
 	if oldHead == nil {
-		return nil, nil
+		return nil, nil, nil
 	}
 	// If the reorg is too deep, avoid doing it (will happen during snap sync)
 	oldNum := oldHead.Number.Uint64()
 	newNum := newHead.Number.Uint64()
 
 	if depth := uint64(math.Abs(float64(oldNum) - float64(newNum))); depth > 64 {
-		return nil, nil
+		return nil, nil, nil
 	}
 	// Reorg seems shallow enough to pull in all transactions into memory
 	var (
@@ -877,6 +900,7 @@ func (p *BlobPool) reorg(oldHead, newHead *types.Header) (map[common.Address][]*
 		discarded   = make(map[common.Address][]*types.Transaction)
 		included    = make(map[common.Address][]*types.Transaction)
 		inclusions  = make(map[common.Hash]uint64)
+		includedTxs = txpool.NewTxInclusions()
 
 		rem = p.chain.GetBlock(oldHead.Hash(), oldHead.Number.Uint64())
 		add = p.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64())
@@ -887,7 +911,7 @@ func (p *BlobPool) reorg(oldHead, newHead *types.Header) (map[common.Address][]*
 		// reorg caused by sync-reversion or explicit sethead back to an
 		// earlier block.
 		log.Warn("Blobpool reset with missing new head", "number", newHead.Number, "hash", newHead.Hash())
-		return nil, nil
+		return nil, nil, nil
 	}
 	if rem == nil {
 		// This can happen if a setHead is performed, where we simply discard
@@ -898,13 +922,13 @@ func (p *BlobPool) reorg(oldHead, newHead *types.Header) (map[common.Address][]*
 			// of setHead
 			log.Warn("Blobpool reset with missing old head",
 				"old", oldHead.Hash(), "oldnum", oldNum, "new", newHead.Hash(), "newnum", newNum)
-			return nil, nil
+			return nil, nil, nil
 		}
 		// If the reorg ended up on a lower number, it's indicative of setHead
 		// being the cause
 		log.Debug("Skipping blobpool reset caused by setHead",
 			"old", oldHead.Hash(), "oldnum", oldNum, "new", newHead.Hash(), "newnum", newNum)
-		return nil, nil
+		return nil, nil, nil
 	}
 	// Both old and new blocks exist, traverse through the progression chain
 	// and accumulate the transactors and transactions
@@ -917,7 +941,7 @@ func (p *BlobPool) reorg(oldHead, newHead *types.Header) (map[common.Address][]*
 		}
 		if rem = p.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
 			log.Error("Unrooted old chain seen by blobpool", "block", oldHead.Number, "hash", oldHead.Hash())
-			return nil, nil
+			return nil, nil, nil
 		}
 	}
 	for add.NumberU64() > rem.NumberU64() {
@@ -926,11 +950,12 @@ func (p *BlobPool) reorg(oldHead, newHead *types.Header) (map[common.Address][]*
 
 			included[from] = append(included[from], tx)
 			inclusions[tx.Hash()] = add.NumberU64()
+			includedTxs.Add(from, tx)
 			transactors[from] = struct{}{}
 		}
 		if add = p.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 			log.Error("Unrooted new chain seen by blobpool", "block", newHead.Number, "hash", newHead.Hash())
-			return nil, nil
+			return nil, nil, nil
 		}
 	}
 	for rem.Hash() != add.Hash() {
@@ -942,18 +967,19 @@ func (p *BlobPool) reorg(oldHead, newHead *types.Header) (map[common.Address][]*
 		}
 		if rem = p.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
 			log.Error("Unrooted old chain seen by blobpool", "block", oldHead.Number, "hash", oldHead.Hash())
-			return nil, nil
+			return nil, nil, nil
 		}
 		for _, tx := range add.Transactions() {
 			from, _ := types.Sender(p.signer, tx)
 
 			included[from] = append(included[from], tx)
 			inclusions[tx.Hash()] = add.NumberU64()
+			includedTxs.Add(from, tx)
 			transactors[from] = struct{}{}
 		}
 		if add = p.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 			log.Error("Unrooted new chain seen by blobpool", "block", newHead.Number, "hash", newHead.Hash())
-			return nil, nil
+			return nil, nil, nil
 		}
 	}
 	// Generate the set of transactions per address to pull back into the pool,
@@ -976,7 +1002,7 @@ func (p *BlobPool) reorg(oldHead, newHead *types.Header) (map[common.Address][]*
 			}
 		}
 	}
-	return reinject, inclusions
+	return reinject, inclusions, includedTxs
 }
 
 // reinject blindly pushes a transaction previously included in the chain - and
@@ -1026,12 +1052,15 @@ func (p *BlobPool) reinject(addr common.Address, txhash common.Hash) error {
 	}
 	p.lookup.track(meta)
 	p.stored += uint64(meta.storageSize)
+	p.txEvents.Record(txpool.TxEventAdd, addr, tx.WithoutBlobTxSidecar(), "", common.Hash{})
 	return nil
 }
 
 // SetGasTip implements txpool.SubPool, allowing the blob pool's gas requirements
 // to be kept in sync with the main transaction pool's gas requirements.
 func (p *BlobPool) SetGasTip(tip *big.Int) {
+	defer p.txEvents.Flush()
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -1052,6 +1081,7 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 					p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
 					p.stored -= uint64(tx.storageSize)
 					p.lookup.untrack(tx)
+					p.recordEvent(txpool.TxEventDrop, addr, tx, txpool.DropUnderpriced, common.Hash{})
 					txs[i] = nil
 
 					// Drop everything afterwards, no gaps allowed
@@ -1062,6 +1092,7 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 						p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], tx.costCap)
 						p.stored -= uint64(tx.storageSize)
 						p.lookup.untrack(tx)
+						p.recordEvent(txpool.TxEventDrop, addr, tx, txpool.DropGapped, common.Hash{})
 						txs[i+1+j] = nil
 					}
 					// Clear out the dropped transactions from the index
@@ -1367,6 +1398,7 @@ func (p *BlobPool) Add(txs []*types.Transaction, sync bool) []error {
 		p.discoverFeed.Send(core.NewTxsEvent{Txs: adds})
 		p.insertFeed.Send(core.NewTxsEvent{Txs: adds})
 	}
+	p.txEvents.Flush()
 	return errs
 }
 
@@ -1440,6 +1472,7 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 		return err
 	}
 	meta := newBlobTxMeta(id, tx.Size(), p.store.Size(id), tx)
+	p.txEvents.Record(txpool.TxEventAdd, from, tx.WithoutBlobTxSidecar(), "", common.Hash{})
 
 	var (
 		next   = p.state.GetNonce(from)
@@ -1468,6 +1501,7 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 		p.lookup.untrack(prev)
 		p.lookup.track(meta)
 		p.stored += uint64(meta.storageSize) - uint64(prev.storageSize)
+		p.recordEvent(txpool.TxEventReplace, from, prev, "", meta.hash)
 	} else {
 		// Transaction extends previously scheduled ones
 		p.index[from] = append(p.index[from], meta)
@@ -1566,6 +1600,7 @@ func (p *BlobPool) drop() {
 	}
 	p.stored -= uint64(drop.storageSize)
 	p.lookup.untrack(drop)
+	p.recordEvent(txpool.TxEventDrop, from, drop, txpool.DropUnderpriced, common.Hash{})
 
 	// Remove the transaction from the pool's eviction heap:
 	//   - If the entire account was dropped, pop off the address
@@ -1656,6 +1691,44 @@ func (p *BlobPool) Pending(filter txpool.PendingFilter) map[common.Address][]*tx
 	return pending
 }
 
+// Entries retrieves the handles of the pooled transactions of the given senders,
+// or of all senders if none are given. The handles are built from the indexed
+// metadata, the transactions themselves are only read from disk when resolved.
+func (p *BlobPool) Entries(senders []common.Address) []*txpool.TxEntry {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var entries []*txpool.TxEntry
+	collect := func(addr common.Address, txs []*blobTxMeta) {
+		for _, tx := range txs {
+			entries = append(entries, &txpool.TxEntry{
+				LazyTransaction: &txpool.LazyTransaction{
+					Pool:      p,
+					Hash:      tx.hash,
+					GasFeeCap: tx.execFeeCap,
+					GasTipCap: tx.execTipCap,
+					Gas:       tx.execGas,
+					BlobGas:   tx.blobGas,
+				},
+				From:    addr,
+				Nonce:   tx.nonce,
+				Type:    types.BlobTxType,
+				Pending: true, // Blob pool only tracks executable transactions
+			})
+		}
+	}
+	if len(senders) == 0 {
+		for addr, txs := range p.index {
+			collect(addr, txs)
+		}
+		return entries
+	}
+	for _, addr := range senders {
+		collect(addr, p.index[addr])
+	}
+	return entries
+}
+
 // updateStorageMetrics retrieves a bunch of stats from the data store and pushes
 // them out as metrics.
 func (p *BlobPool) updateStorageMetrics() {
@@ -1742,6 +1815,28 @@ func (p *BlobPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool
 	}
 }
 
+// SubscribeTxEvents registers a subscription for the lifecycle events of the
+// pooled transactions.
+//
+// Note, the blob pool doesn't hold the transactions in memory, so the events
+// only carry the transaction itself when it's being added.
+func (p *BlobPool) SubscribeTxEvents(ch chan<- []txpool.TxEvent) event.Subscription {
+	return p.txEvents.Subscribe(ch)
+}
+
+// recordEvent queues a lifecycle event of a tracked blob transaction.
+func (p *BlobPool) recordEvent(typ txpool.TxEventType, from common.Address, meta *blobTxMeta, reason string, by common.Hash) {
+	p.txEvents.RecordEvent(txpool.TxEvent{
+		Type:   typ,
+		Hash:   meta.hash,
+		From:   from,
+		Nonce:  meta.nonce,
+		TxType: types.BlobTxType,
+		Reason: reason,
+		By:     by,
+	})
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *BlobPool) Nonce(addr common.Address) uint64 {
@@ -71,4 +71,8 @@ var (
 	// ErrInflightTxLimitReached is returned when the maximum number of in-flight
 	// transactions is reached for specific accounts.
 	ErrInflightTxLimitReached = errors.New("in-flight transaction limit reached for delegated accounts")
+
+	// ErrPrivateUnsupported is returned if a transaction is attempted to be held
+	// privately by a subpool unable to do so.
+	ErrPrivateUnsupported = errors.New("private transactions not supported for this type")
 )
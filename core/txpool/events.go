@@ -0,0 +1,193 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// TxEventType is the kind of change in the life of a pooled transaction.
+type TxEventType uint8
+
+const (
+	TxEventAdd     TxEventType = iota // Transaction was accepted into the pool
+	TxEventDrop                       // Transaction was evicted from the pool
+	TxEventReplace                    // Transaction was replaced by one with the same nonce
+	TxEventInclude                    // Transaction was included by the chain
+)
+
+// String implements fmt.Stringer.
+func (t TxEventType) String() string {
+	switch t {
+	case TxEventAdd:
+		return "add"
+	case TxEventDrop:
+		return "drop"
+	case TxEventReplace:
+		return "replace"
+	case TxEventInclude:
+		return "include"
+	default:
+		return "unknown"
+	}
+}
+
+// Reason codes of the transaction drop events.
+const (
+	DropUnderpriced = "underpriced" // Outbid by better paying transactions or below the minimum tip
+	DropLifetime    = "lifetime"    // Queued for longer than the allowed lifetime
+	DropNoFunds     = "nofunds"     // Sender can't pay for the transaction anymore
+	DropAccountCap  = "accountcap"  // Sender exceeded its allowance of queued transactions
+	DropFairness    = "fairness"    // Evicted to equalize the pool usage of the senders
+	DropCost        = "cost"        // Evicted for paying the lowest average tip per gas
+	DropGapped      = "gapped"      // Became non-executable due to a nonce gap
+	DropInvalid     = "invalid"     // Became invalid, e.g. duplicate nonce in the store
+	DropRemoved     = "removed"     // Removed explicitly by the node, e.g. expired private transaction
+	DropStale       = "stale"       // Nonce was consumed by a transaction outside of the known blocks
+)
+
+// TxEvent is a change in the life of a pooled transaction.
+type TxEvent struct {
+	Type   TxEventType
+	Hash   common.Hash
+	From   common.Address
+	Nonce  uint64
+	TxType uint8
+	Reason string      // Reason code of drop events
+	By     common.Hash // Replacement transaction of replace events
+
+	// Tx is the transaction the event is about. It may be nil if the pool doesn't
+	// hold the transaction in memory (e.g. blob transaction evictions).
+	Tx *types.Transaction
+}
+
+// TxEventFeed collects the transaction events of a subpool while its lock is
+// held, and delivers them to the subscribers once released. Events are only
+// collected when there are subscribers.
+type TxEventFeed struct {
+	feed  event.Feed
+	scope event.SubscriptionScope
+
+	queue     []TxEvent
+	queueLock sync.Mutex // Lock protecting the queued events
+	sendLock  sync.Mutex // Lock ensuring the batches are delivered in order
+}
+
+// Subscribe registers a subscription for batches of transaction events.
+func (f *TxEventFeed) Subscribe(ch chan<- []TxEvent) event.Subscription {
+	return f.scope.Track(f.feed.Subscribe(ch))
+}
+
+// Record queues an event about a transaction for delivery at the next flush.
+func (f *TxEventFeed) Record(typ TxEventType, from common.Address, tx *types.Transaction, reason string, by common.Hash) {
+	if f.scope.Count() == 0 {
+		return
+	}
+	f.queueLock.Lock()
+	f.queue = append(f.queue, TxEvent{
+		Type:   typ,
+		Hash:   tx.Hash(),
+		From:   from,
+		Nonce:  tx.Nonce(),
+		TxType: tx.Type(),
+		Reason: reason,
+		By:     by,
+		Tx:     tx,
+	})
+	f.queueLock.Unlock()
+}
+
+// RecordEvent queues an event for delivery at the next flush. It's meant for
+// the pools which don't hold the transaction in memory.
+func (f *TxEventFeed) RecordEvent(ev TxEvent) {
+	if f.scope.Count() == 0 {
+		return
+	}
+	f.queueLock.Lock()
+	f.queue = append(f.queue, ev)
+	f.queueLock.Unlock()
+}
+
+// Flush delivers the queued events to the subscribers. It must not be called
+// while holding the pool lock, since the subscribers may block on it.
+func (f *TxEventFeed) Flush() {
+	f.sendLock.Lock()
+	defer f.sendLock.Unlock()
+
+	f.queueLock.Lock()
+	events := f.queue
+	f.queue = nil
+	f.queueLock.Unlock()
+
+	if len(events) > 0 {
+		f.feed.Send(events)
+	}
+}
+
+// Close terminates all the subscriptions.
+func (f *TxEventFeed) Close() {
+	f.scope.Close()
+}
+
+// TxInclusions is the set of transactions included by the blocks a pool reset
+// moved onto. It tells apart the pooled transactions included by the chain from
+// the ones whose nonce was consumed by a different transaction.
+type TxInclusions struct {
+	hashes map[common.Hash]struct{}
+	nonces map[common.Address]map[uint64]common.Hash
+}
+
+// NewTxInclusions creates an empty set of included transactions.
+func NewTxInclusions() *TxInclusions {
+	return &TxInclusions{
+		hashes: make(map[common.Hash]struct{}),
+		nonces: make(map[common.Address]map[uint64]common.Hash),
+	}
+}
+
+// Add marks a transaction of the given sender as included.
+func (in *TxInclusions) Add(from common.Address, tx *types.Transaction) {
+	in.hashes[tx.Hash()] = struct{}{}
+	if in.nonces[from] == nil {
+		in.nonces[from] = make(map[uint64]common.Hash)
+	}
+	in.nonces[from][tx.Nonce()] = tx.Hash()
+}
+
+// Consumed returns the event to report for a pooled transaction removed because
+// its nonce was consumed by the chain, along with the drop reason or replacement
+// hash: an inclusion if the transaction is part of the known blocks, a replacement
+// if a different one with the same nonce is, or a drop otherwise.
+//
+// The set might be nil if the blocks aren't known (e.g. deep reorg), in which case
+// all the transactions are reported as dropped.
+func (in *TxInclusions) Consumed(from common.Address, nonce uint64, hash common.Hash) (TxEventType, string, common.Hash) {
+	if in == nil {
+		return TxEventDrop, DropStale, common.Hash{}
+	}
+	if _, ok := in.hashes[hash]; ok {
+		return TxEventInclude, "", common.Hash{}
+	}
+	if by, ok := in.nonces[from][nonce]; ok {
+		return TxEventReplace, "", by
+	}
+	return TxEventDrop, DropStale, common.Hash{}
+}
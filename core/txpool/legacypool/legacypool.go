@@ -95,6 +95,14 @@ var (
 	queuedNofundsMeter   = metrics.NewRegisteredMeter("txpool/queued/nofunds", nil)   // Dropped due to out-of-funds
 	queuedEvictionMeter  = metrics.NewRegisteredMeter("txpool/queued/eviction", nil)  // Dropped due to lifetime
 
+	// Metrics for the evicted transactions, by the reason of the eviction
+	evictLifetimeMeter    = metrics.NewRegisteredMeter("txpool/evict/lifetime", nil)    // Queued for longer than the lifetime
+	evictUnderpricedMeter = metrics.NewRegisteredMeter("txpool/evict/underpriced", nil) // Outbid by a new transaction in a full pool
+	evictNofundsMeter     = metrics.NewRegisteredMeter("txpool/evict/nofunds", nil)     // Unpayable by the sender or over the block gas limit
+	evictAccountCapMeter  = metrics.NewRegisteredMeter("txpool/evict/accountcap", nil)  // Over the queue limit of the sender
+	evictFairnessMeter    = metrics.NewRegisteredMeter("txpool/evict/fairness", nil)    // Over the global limits, equalizing the senders
+	evictCostMeter        = metrics.NewRegisteredMeter("txpool/evict/cost", nil)        // Over the global limits, by execution cost relative to fees
+
 	// General tx metrics
 	knownTxMeter       = metrics.NewRegisteredMeter("txpool/known", nil)
 	validTxMeter       = metrics.NewRegisteredMeter("txpool/valid", nil)
@@ -150,6 +158,21 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+
+	Snapshot         string        // Snapshot of all pooled transactions to survive node restarts (empty = disabled)
+	SnapshotInterval time.Duration // Time interval to regenerate the snapshot (zero = only at shutdown)
+
+	// PeerSlots is the maximum number of transactions admitted from a single
+	// peer which may reside in the pool at once, zero meaning unlimited. It is
+	// enforced by the transaction fetcher of the eth handler, which is the only
+	// one aware of the peers.
+	PeerSlots uint64
+
+	// CostEviction makes the pool evict the executable transactions above the
+	// global limit from the senders paying the lowest average tip per gas for
+	// the gas they occupy, instead of equalizing the number of transactions
+	// across the senders.
+	CostEviction bool
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -230,6 +253,7 @@ type LegacyPool struct {
 	chain       BlockChain
 	gasTip      atomic.Pointer[uint256.Int]
 	txFeed      event.Feed
+	txEvents    txpool.TxEventFeed
 	signer      types.Signer
 	mu          sync.RWMutex
 
@@ -237,6 +261,8 @@ type LegacyPool struct {
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
 	reserver      txpool.Reserver              // Address reserver to ensure exclusivity across subpools
+	private       func(common.Hash) bool       // Reports the transactions held privately, never snapshotted
+	inclusions    *txpool.TxInclusions         // Transactions included by the blocks of the running reset
 
 	pending map[common.Address]*list     // All currently processable transactions
 	queue   map[common.Address]*list     // Queued but non-processable transactions
@@ -327,6 +353,11 @@ func (pool *LegacyPool) Init(gasTip uint64, head *types.Header, reserver txpool.
 
 	pool.wg.Add(1)
 	go pool.loop()
+
+	// Restore the transactions of the previous run, if persisted
+	if pool.config.Snapshot != "" {
+		pool.loadSnapshot()
+	}
 	return nil
 }
 
@@ -346,6 +377,14 @@ func (pool *LegacyPool) loop() {
 	defer report.Stop()
 	defer evict.Stop()
 
+	// Start the snapshot ticker if periodic snapshots are enabled
+	var snapshot <-chan time.Time
+	if pool.config.Snapshot != "" && pool.config.SnapshotInterval > 0 {
+		ticker := time.NewTicker(pool.config.SnapshotInterval)
+		defer ticker.Stop()
+		snapshot = ticker.C
+	}
+
 	// Notify tests that the init phase is done
 	close(pool.initDoneCh)
 	for {
@@ -374,12 +413,19 @@ func (pool *LegacyPool) loop() {
 				if time.Since(pool.beats[addr]) > pool.config.Lifetime {
 					list := pool.queue[addr].Flatten()
 					for _, tx := range list {
+						pool.txEvents.Record(txpool.TxEventDrop, addr, tx, txpool.DropLifetime, common.Hash{})
 						pool.removeTx(tx.Hash(), true, true)
 					}
 					queuedEvictionMeter.Mark(int64(len(list)))
+					evictLifetimeMeter.Mark(int64(len(list)))
 				}
 			}
 			pool.mu.Unlock()
+			pool.txEvents.Flush()
+
+		// Handle periodic snapshots of the pool contents
+		case <-snapshot:
+			pool.saveSnapshot()
 		}
 	}
 }
@@ -389,6 +435,11 @@ func (pool *LegacyPool) Close() error {
 	// Terminate the pool reorger and return
 	close(pool.reorgShutdownCh)
 	pool.wg.Wait()
+	pool.txEvents.Close()
+
+	if pool.config.Snapshot != "" {
+		pool.saveSnapshot()
+	}
 
 	log.Info("Transaction pool stopped")
 	return nil
@@ -411,9 +462,17 @@ func (pool *LegacyPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs
 	return pool.txFeed.Subscribe(ch)
 }
 
+// SubscribeTxEvents registers a subscription for the lifecycle events of the
+// pooled transactions.
+func (pool *LegacyPool) SubscribeTxEvents(ch chan<- []txpool.TxEvent) event.Subscription {
+	return pool.txEvents.Subscribe(ch)
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
+	defer pool.txEvents.Flush()
+
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
@@ -427,6 +486,8 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 		// pool.priced is sorted by GasFeeCap, so we have to iterate through pool.all instead
 		drop := pool.all.TxsBelowTip(tip)
 		for _, tx := range drop {
+			from, _ := types.Sender(pool.signer, tx)
+			pool.txEvents.Record(txpool.TxEventDrop, from, tx, txpool.DropUnderpriced, common.Hash{})
 			pool.removeTx(tx.Hash(), false, true)
 		}
 		pool.priced.Removed(len(drop))
@@ -500,6 +561,54 @@ func (pool *LegacyPool) ContentFrom(addr common.Address) ([]*types.Transaction,
 	return pending, queued
 }
 
+// Entries retrieves the handles of the pooled transactions of the given senders,
+// or of all senders if none are given. The transactions are kept in memory, so
+// the handles are already resolved.
+func (pool *LegacyPool) Entries(senders []common.Address) []*txpool.TxEntry {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	var entries []*txpool.TxEntry
+	collect := func(addr common.Address, txs *list, pending bool) {
+		for _, tx := range txs.Flatten() {
+			entries = append(entries, &txpool.TxEntry{
+				LazyTransaction: &txpool.LazyTransaction{
+					Pool:      pool,
+					Hash:      tx.Hash(),
+					Tx:        tx,
+					Time:      tx.Time(),
+					GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+					GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+					Gas:       tx.Gas(),
+					BlobGas:   tx.BlobGas(),
+				},
+				From:    addr,
+				Nonce:   tx.Nonce(),
+				Type:    tx.Type(),
+				Pending: pending,
+			})
+		}
+	}
+	if len(senders) == 0 {
+		for addr, list := range pool.pending {
+			collect(addr, list, true)
+		}
+		for addr, list := range pool.queue {
+			collect(addr, list, false)
+		}
+		return entries
+	}
+	for _, addr := range senders {
+		if list, ok := pool.pending[addr]; ok {
+			collect(addr, list, true)
+		}
+		if list, ok := pool.queue[addr]; ok {
+			collect(addr, list, false)
+		}
+	}
+	return entries
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 //
@@ -765,8 +874,10 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 		for _, tx := range drop {
 			log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
 			underpricedTxMeter.Mark(1)
+			evictUnderpricedMeter.Mark(1)
 
 			sender, _ := types.Sender(pool.signer, tx)
+			pool.txEvents.Record(txpool.TxEventDrop, sender, tx, txpool.DropUnderpriced, common.Hash{})
 			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
 
 			pool.changesSinceReorg += dropped
@@ -786,10 +897,12 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.txEvents.Record(txpool.TxEventReplace, from, old, "", hash)
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
 		pool.queueTxEvent(tx)
+		pool.txEvents.Record(txpool.TxEventAdd, from, tx, "", common.Hash{})
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
 		// Successful promotion, bump the heartbeat
@@ -850,6 +963,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, addAl
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		queuedReplaceMeter.Mark(1)
+		pool.txEvents.Record(txpool.TxEventReplace, from, old, "", hash)
 	} else {
 		// Nothing was replaced, bump the queued counter
 		queuedGauge.Inc(1)
@@ -862,6 +976,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, addAl
 	if addAll {
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
+		pool.txEvents.Record(txpool.TxEventAdd, from, tx, "", common.Hash{})
 	}
 	// If we never record the heartbeat, do it right now.
 	if _, exist := pool.beats[from]; !exist {
@@ -887,6 +1002,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
+		pool.txEvents.Record(txpool.TxEventReplace, addr, tx, "", list.txs.Get(tx.Nonce()).Hash())
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -894,6 +1010,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.txEvents.Record(txpool.TxEventReplace, addr, old, "", hash)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
@@ -968,6 +1085,7 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, sync bool) []error {
 	pool.mu.Lock()
 	newErrs, dirtyAddrs := pool.addTxsLocked(news)
 	pool.mu.Unlock()
+	pool.txEvents.Flush()
 
 	var nilSlot = 0
 	for _, err := range newErrs {
@@ -1279,6 +1397,8 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	// because of another transaction (e.g. higher gas price).
 	if reset != nil {
 		pool.demoteUnexecutables()
+		pool.inclusions = nil
+
 		if reset.newHead != nil {
 			if pool.chainconfig.IsLondon(new(big.Int).Add(reset.newHead.Number, big.NewInt(1))) {
 				pendingBaseFee := eip1559.CalcBaseFee(pool.chainconfig, reset.newHead)
@@ -1302,6 +1422,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
 	pool.mu.Unlock()
+	pool.txEvents.Flush()
 
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
@@ -1326,6 +1447,14 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	// If we're reorging an old state, reinject all dropped transactions
 	var reinject types.Transactions
 
+	pool.inclusions = nil
+	if oldHead != nil && oldHead.Hash() == newHead.ParentHash {
+		// The chain was extended by a single block, track its transactions to
+		// report the pooled ones it consumed
+		if block := pool.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64()); block != nil {
+			pool.trackInclusions(block.Transactions())
+		}
+	}
 	if oldHead != nil && oldHead.Hash() != newHead.ParentHash {
 		// If the reorg is too deep, avoid doing it (will happen during fast sync)
 		oldNum := oldHead.Number.Uint64()
@@ -1390,6 +1519,8 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 						return
 					}
 				}
+				pool.trackInclusions(included)
+
 				lost := make([]*types.Transaction, 0, len(discarded))
 				for _, tx := range types.TxDifference(discarded, included) {
 					if pool.Filter(tx) {
@@ -1419,6 +1550,17 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	pool.addTxsLocked(reinject)
 }
 
+// trackInclusions collects the transactions included by the blocks the pool is
+// being reset onto, to tell the pooled transactions included by the chain apart
+// from the ones whose nonce was consumed by a different transaction.
+func (pool *LegacyPool) trackInclusions(txs types.Transactions) {
+	pool.inclusions = txpool.NewTxInclusions()
+	for _, tx := range txs {
+		from, _ := types.Sender(pool.signer, tx)
+		pool.inclusions.Add(from, tx)
+	}
+}
+
 // promoteExecutables moves transactions that have become processable from the
 // future queue to the set of pending transactions. During this process, all
 // invalidated transactions (low nonce, low balance) are deleted.
@@ -1437,15 +1579,20 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		forwards := list.Forward(pool.currentState.GetNonce(addr))
 		for _, tx := range forwards {
 			pool.all.Remove(tx.Hash())
+
+			typ, reason, by := pool.inclusions.Consumed(addr, tx.Nonce(), tx.Hash())
+			pool.txEvents.Record(typ, addr, tx, reason, by)
 		}
 		log.Trace("Removed old queued transactions", "count", len(forwards))
 		// Drop all transactions that are too costly (low balance or out of gas)
 		drops, _ := list.Filter(pool.currentState.GetBalance(addr), gasLimit)
 		for _, tx := range drops {
 			pool.all.Remove(tx.Hash())
+			pool.txEvents.Record(txpool.TxEventDrop, addr, tx, txpool.DropNoFunds, common.Hash{})
 		}
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
+		evictNofundsMeter.Mark(int64(len(drops)))
 
 		// Gather all executable transactions and promote them
 		readies := list.Ready(pool.pendingNonces.get(addr))
@@ -1463,9 +1610,11 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		for _, tx := range caps {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.txEvents.Record(txpool.TxEventDrop, addr, tx, txpool.DropAccountCap, common.Hash{})
 			log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 		}
 		queuedRateLimitMeter.Mark(int64(len(caps)))
+		evictAccountCapMeter.Mark(int64(len(caps)))
 		// Mark all the items dropped as removed
 		pool.priced.Removed(len(forwards) + len(drops) + len(caps))
 		queuedGauge.Dec(int64(len(forwards) + len(drops) + len(caps)))
@@ -1486,6 +1635,10 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 // pending limit. The algorithm tries to reduce transaction counts by an approximately
 // equal number for all for accounts with many pending transactions.
 func (pool *LegacyPool) truncatePending() {
+	if pool.config.CostEviction {
+		pool.truncatePendingByCost()
+		return
+	}
 	pending := uint64(0)
 
 	// Assemble a spam order to penalize large transactors first
@@ -1525,6 +1678,7 @@ func (pool *LegacyPool) truncatePending() {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pool.txEvents.Record(txpool.TxEventDrop, offenders[i], tx, txpool.DropFairness, common.Hash{})
 
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
@@ -1550,6 +1704,7 @@ func (pool *LegacyPool) truncatePending() {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
 					pool.all.Remove(hash)
+					pool.txEvents.Record(txpool.TxEventDrop, addr, tx, txpool.DropFairness, common.Hash{})
 
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1562,6 +1717,88 @@ func (pool *LegacyPool) truncatePending() {
 		}
 	}
 	pendingRateLimitMeter.Mark(int64(pendingBeforeCap - pending))
+	evictFairnessMeter.Mark(int64(pendingBeforeCap - pending))
+}
+
+// senderCost is the gas occupied by the pending transactions of a sender, along
+// with the tips offered for it.
+type senderCost struct {
+	address common.Address
+	gas     uint64   // Total gas limit of the transactions
+	fees    *big.Int // Total effective tip offered for the gas
+}
+
+// exceeds reports whether the sender should be evicted before the other one:
+// either its average tip per gas (fees/gas) is lower, or the averages are equal
+// and it occupies more gas.
+func (c senderCost) exceeds(other senderCost) bool {
+	// Compare c.fees/c.gas against other.fees/other.gas without division
+	have := new(big.Int).Mul(c.fees, new(big.Int).SetUint64(other.gas))
+	want := new(big.Int).Mul(other.fees, new(big.Int).SetUint64(c.gas))
+	if cmp := have.Cmp(want); cmp != 0 {
+		return cmp < 0
+	}
+	return c.gas > other.gas
+}
+
+// truncatePendingByCost removes transactions from the pending queue if the pool
+// is above the pending limit, starting with the senders paying the lowest
+// average effective tip per gas across their transactions, the ones occupying
+// more gas first among equal payers. Like the equalizing eviction, only the
+// senders above the per-account allowance are affected, and they are never cut
+// below it.
+func (pool *LegacyPool) truncatePendingByCost() {
+	var (
+		pending   uint64
+		offenders []senderCost
+		baseFee   = pool.priced.urgent.baseFee
+	)
+	for addr, list := range pool.pending {
+		length := uint64(list.Len())
+		pending += length
+		if length <= pool.config.AccountSlots {
+			continue
+		}
+		cost := senderCost{address: addr, fees: new(big.Int)}
+		for _, tx := range list.Flatten() {
+			cost.gas += tx.Gas()
+			if tip, err := tx.EffectiveGasTip(baseFee); err == nil {
+				cost.fees.Add(cost.fees, tip.Mul(tip, new(big.Int).SetUint64(tx.Gas())))
+			}
+		}
+		offenders = append(offenders, cost)
+	}
+	if pending <= pool.config.GlobalSlots {
+		return
+	}
+	sort.Slice(offenders, func(i, j int) bool {
+		return offenders[i].exceeds(offenders[j])
+	})
+	pendingBeforeCap := pending
+	for _, offender := range offenders {
+		list := pool.pending[offender.address]
+		for pending > pool.config.GlobalSlots && uint64(list.Len()) > pool.config.AccountSlots {
+			caps := list.Cap(list.Len() - 1)
+			for _, tx := range caps {
+				// Drop the transaction from the global pools too
+				hash := tx.Hash()
+				pool.all.Remove(hash)
+				pool.txEvents.Record(txpool.TxEventDrop, offender.address, tx, txpool.DropCost, common.Hash{})
+
+				// Update the account nonce to the dropped transaction
+				pool.pendingNonces.setIfLower(offender.address, tx.Nonce())
+				log.Trace("Removed underpaying pending transaction", "hash", hash)
+			}
+			pool.priced.Removed(len(caps))
+			pendingGauge.Dec(int64(len(caps)))
+			pending--
+		}
+		if pending <= pool.config.GlobalSlots {
+			break
+		}
+	}
+	pendingRateLimitMeter.Mark(int64(pendingBeforeCap - pending))
+	evictCostMeter.Mark(int64(pendingBeforeCap - pending))
 }
 
 // truncateQueue drops the oldest transactions in the queue if the pool is above the global queue limit.
@@ -1591,18 +1828,22 @@ func (pool *LegacyPool) truncateQueue() {
 		// Drop all transactions if they are less than the overflow
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
+				pool.txEvents.Record(txpool.TxEventDrop, addr.address, tx, txpool.DropFairness, common.Hash{})
 				pool.removeTx(tx.Hash(), true, true)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
+			evictFairnessMeter.Mark(int64(size))
 			continue
 		}
 		// Otherwise drop only last few transactions
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
+			pool.txEvents.Record(txpool.TxEventDrop, addr.address, txs[i], txpool.DropFairness, common.Hash{})
 			pool.removeTx(txs[i].Hash(), true, true)
 			drop--
 			queuedRateLimitMeter.Mark(1)
+			evictFairnessMeter.Mark(1)
 		}
 	}
 }
@@ -1625,6 +1866,9 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range olds {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+
+			typ, reason, by := pool.inclusions.Consumed(addr, tx.Nonce(), hash)
+			pool.txEvents.Record(typ, addr, tx, reason, by)
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
@@ -1632,9 +1876,11 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.txEvents.Record(txpool.TxEventDrop, addr, tx, txpool.DropNoFunds, common.Hash{})
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
+		evictNofundsMeter.Mark(int64(len(drops)))
 
 		for _, tx := range invalids {
 			hash := tx.Hash()
@@ -1894,6 +2140,24 @@ func numSlots(tx *types.Transaction) int {
 	return int((tx.Size() + txSlotSize - 1) / txSlotSize)
 }
 
+// RemoveTx removes a single transaction from the pool, moving the subsequent
+// executable transactions of the sender back to the queue.
+func (pool *LegacyPool) RemoveTx(hash common.Hash) bool {
+	defer pool.txEvents.Flush()
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	tx := pool.all.Get(hash)
+	if tx == nil {
+		return false
+	}
+	from, _ := types.Sender(pool.signer, tx) // already validated during insertion
+	pool.txEvents.Record(txpool.TxEventDrop, from, tx, txpool.DropRemoved, common.Hash{})
+	pool.removeTx(hash, true, true)
+	return true
+}
+
 // Clear implements txpool.SubPool, removing all tracked txs from the pool
 // and rotating the journal.
 //
@@ -67,6 +67,7 @@ type testBlockChain struct {
 	gasLimit      atomic.Uint64
 	statedb       *state.StateDB
 	chainHeadFeed *event.Feed
+	blocks        map[common.Hash]*types.Block // Known blocks, empty ones are made up for the rest
 }
 
 func newTestBlockChain(config *params.ChainConfig, gasLimit uint64, statedb *state.StateDB, chainHeadFeed *event.Feed) *testBlockChain {
@@ -88,6 +89,9 @@ func (bc *testBlockChain) CurrentBlock() *types.Header {
 }
 
 func (bc *testBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	if block, ok := bc.blocks[hash]; ok {
+		return block
+	}
 	return types.NewBlock(bc.CurrentBlock(), nil, nil, trie.NewStackTrie(nil))
 }
 
@@ -1243,6 +1247,56 @@ func TestPendingGlobalLimiting(t *testing.T) {
 	}
 }
 
+// Tests that if the transaction count belonging to multiple accounts go above
+// some hard threshold with cost eviction enabled, the senders imposing the most
+// execution cost relative to their fees are capped first.
+func TestPendingCostEviction(t *testing.T) {
+	t.Parallel()
+
+	// Create the pool to test the limit enforcement with
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.AccountSlots = 2
+	config.GlobalSlots = 8
+	config.CostEviction = true
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	// Create a heavy cheap sender, a light cheap sender and a heavy generous one
+	var (
+		keys   = make([]*ecdsa.PrivateKey, 3)
+		gases  = []uint64{100000, 21000, 100000}
+		prices = []int64{1, 1, 1000}
+		txs    = types.Transactions{}
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000000))
+		for nonce := uint64(0); nonce < 6; nonce++ {
+			txs = append(txs, pricedTransaction(nonce, gases[i], big.NewInt(prices[i]), keys[i]))
+		}
+	}
+	// Import the batch and verify that the costly senders were capped first
+	pool.addRemotesSync(txs)
+
+	for i, want := range []int{2, 2, 4} {
+		var have int
+		if list := pool.pending[crypto.PubkeyToAddress(keys[i].PublicKey)]; list != nil {
+			have = list.Len()
+		}
+		if have != want {
+			t.Errorf("sender %d: pending transactions mismatch: have %d, want %d", i, have, want)
+		}
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Test the limit on transaction size is enforced correctly.
 // This test verifies every transaction having allowed size
 // is added to the pool, and longer transactions are rejected.
@@ -2233,6 +2287,26 @@ func TestStatusCheck(t *testing.T) {
 			t.Errorf("transaction %d: status mismatch: have %v, want %v", i, status, expect[i])
 		}
 	}
+	// Ensure the pool entries report the same statuses, filtered by sender
+	if entries := pool.Entries(nil); len(entries) != len(txs) {
+		t.Errorf("entry count mismatch: have %d, want %d", len(entries), len(txs))
+	}
+	entries := pool.Entries([]common.Address{crypto.PubkeyToAddress(keys[1].PublicKey)})
+	if len(entries) != 2 {
+		t.Fatalf("sender entry count mismatch: have %d, want %d", len(entries), 2)
+	}
+	for _, entry := range entries {
+		i := slices.Index(hashes, entry.Hash)
+		if i < 0 {
+			t.Fatalf("entry %x: unknown transaction", entry.Hash)
+		}
+		if entry.Pending != (expect[i] == txpool.TxStatusPending) {
+			t.Errorf("entry %d: pending mismatch: have %v, want %v", i, entry.Pending, expect[i] == txpool.TxStatusPending)
+		}
+		if entry.Nonce != txs[i].Nonce() || entry.Tx != txs[i] {
+			t.Errorf("entry %d: metadata mismatch", i)
+		}
+	}
 }
 
 // Test the transaction slots consumption is computed correctly
@@ -2709,3 +2783,89 @@ func BenchmarkMultiAccountBatchInsert(b *testing.B) {
 		pool.addRemotesSync([]*types.Transaction{tx})
 	}
 }
+
+// Tests that the lifecycle events of the transactions are emitted when they are
+// added, replaced, included and dropped.
+func TestTxEvents(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	events := make(chan []txpool.TxEvent, 32)
+	sub := pool.SubscribeTxEvents(events)
+	defer sub.Unsubscribe()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+
+	var (
+		tx0  = pricedTransaction(0, 100000, big.NewInt(1), key)
+		tx0b = pricedTransaction(0, 100000, big.NewInt(2), key)
+		tx1  = pricedTransaction(1, 100000, big.NewInt(1), key)
+		tx1b = pricedTransaction(1, 100000, big.NewInt(3), key) // Never pooled
+		tx2  = pricedTransaction(2, 100000, big.NewInt(1), key)
+		tx5  = pricedTransaction(5, 100000, big.NewInt(1), key)
+	)
+	for i, err := range pool.addRemotesSync([]*types.Transaction{tx0, tx1, tx2, tx5}) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	if err := pool.addRemoteSync(tx0b); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	// Include the replacement and a transaction unknown to the pool in a new
+	// block, the latter consuming the nonce of a pooled one
+	var (
+		parent = pool.currentHead.Load()
+		block  = types.NewBlock(&types.Header{
+			ParentHash: parent.Hash(),
+			Number:     big.NewInt(1),
+			GasLimit:   parent.GasLimit,
+			Difficulty: common.Big0,
+			BaseFee:    common.Big1,
+		}, &types.Body{Transactions: types.Transactions{tx0b, tx1b}}, nil, trie.NewStackTrie(nil))
+	)
+	pool.chain.(*testBlockChain).blocks = map[common.Hash]*types.Block{block.Hash(): block}
+	testSetNonce(pool, from, 2)
+	<-pool.requestReset(parent, block.Header())
+
+	// Consume the nonce of another pooled transaction without the pool knowing
+	// the block doing it
+	testSetNonce(pool, from, 3)
+	<-pool.requestReset(nil, nil)
+
+	if !pool.RemoveTx(tx5.Hash()) {
+		t.Fatalf("failed to remove queued transaction")
+	}
+	want := []txpool.TxEvent{
+		{Type: txpool.TxEventAdd, Hash: tx0.Hash()},
+		{Type: txpool.TxEventAdd, Hash: tx1.Hash()},
+		{Type: txpool.TxEventAdd, Hash: tx2.Hash()},
+		{Type: txpool.TxEventAdd, Hash: tx5.Hash()},
+		{Type: txpool.TxEventReplace, Hash: tx0.Hash(), By: tx0b.Hash()},
+		{Type: txpool.TxEventAdd, Hash: tx0b.Hash()},
+		{Type: txpool.TxEventInclude, Hash: tx0b.Hash()},
+		{Type: txpool.TxEventReplace, Hash: tx1.Hash(), By: tx1b.Hash()},
+		{Type: txpool.TxEventDrop, Hash: tx2.Hash(), Reason: txpool.DropStale},
+		{Type: txpool.TxEventDrop, Hash: tx5.Hash(), Reason: txpool.DropRemoved},
+	}
+	var have []txpool.TxEvent
+	for len(have) < len(want) {
+		select {
+		case batch := <-events:
+			have = append(have, batch...)
+		case <-time.After(time.Second):
+			t.Fatalf("event count mismatch: have %d, want %d", len(have), len(want))
+		}
+	}
+	for i, ev := range have {
+		if ev.Type != want[i].Type || ev.Hash != want[i].Hash || ev.Reason != want[i].Reason || ev.By != want[i].By {
+			t.Errorf("event %d mismatch: have %v %x %q %x, want %v %x %q %x", i, ev.Type, ev.Hash, ev.Reason, ev.By, want[i].Type, want[i].Hash, want[i].Reason, want[i].By)
+		}
+		if ev.From != from {
+			t.Errorf("event %d sender mismatch: have %x, want %x", i, ev.From, from)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// snapshotEntry is a transaction persisted in the pool snapshot, along with
+// the time it was first seen by the pool.
+type snapshotEntry struct {
+	Time uint64 // Unix time in seconds
+	Tx   *types.Transaction
+}
+
+// writeSnapshot persists the given transactions into the snapshot at path,
+// replacing any previous one atomically.
+func writeSnapshot(path string, txs []*types.Transaction) error {
+	output, err := os.OpenFile(path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(output)
+	for _, tx := range txs {
+		if err := rlp.Encode(writer, &snapshotEntry{Time: uint64(tx.Time().Unix()), Tx: tx}); err != nil {
+			output.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		output.Close()
+		return err
+	}
+	if err := output.Close(); err != nil {
+		return err
+	}
+	return os.Rename(path+".new", path)
+}
+
+// readSnapshot parses the transactions from the snapshot at path. A missing
+// snapshot is not an error. If the snapshot is corrupted, the transactions
+// parsed until the corruption are returned along with the error.
+func readSnapshot(path string) ([]*types.Transaction, error) {
+	input, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+
+	var (
+		stream = rlp.NewStream(bufio.NewReader(input), 0)
+		txs    []*types.Transaction
+	)
+	for {
+		entry := new(snapshotEntry)
+		if err := stream.Decode(entry); err != nil {
+			if err == io.EOF {
+				return txs, nil
+			}
+			return txs, err
+		}
+		entry.Tx.SetTime(time.Unix(int64(entry.Time), 0))
+		txs = append(txs, entry.Tx)
+	}
+}
+
+// SetPrivateFilter sets the function reporting the transactions held privately
+// by the node. As their privacy is not persisted, they are omitted from the
+// snapshot, not to be gossiped after a restart.
+func (pool *LegacyPool) SetPrivateFilter(private func(hash common.Hash) bool) {
+	pool.private = private
+}
+
+// saveSnapshot persists the executable and the queued transactions of the pool
+// into the configured snapshot, except for the private ones.
+func (pool *LegacyPool) saveSnapshot() {
+	var txs []*types.Transaction
+
+	pool.mu.RLock()
+	for _, list := range pool.pending {
+		txs = append(txs, list.Flatten()...)
+	}
+	for _, list := range pool.queue {
+		txs = append(txs, list.Flatten()...)
+	}
+	pool.mu.RUnlock()
+
+	if pool.private != nil {
+		txs = slices.DeleteFunc(txs, func(tx *types.Transaction) bool {
+			return pool.private(tx.Hash())
+		})
+	}
+
+	if err := writeSnapshot(pool.config.Snapshot, txs); err != nil {
+		log.Warn("Failed to write transaction pool snapshot", "err", err)
+		return
+	}
+	log.Debug("Saved transaction pool snapshot", "transactions", len(txs))
+}
+
+// loadSnapshot injects the transactions of the configured snapshot into the
+// pool. The transactions first seen longer than the lifetime ago are dropped,
+// the rest are revalidated against the current head as they are added.
+func (pool *LegacyPool) loadSnapshot() {
+	txs, err := readSnapshot(pool.config.Snapshot)
+	if err != nil {
+		log.Warn("Failed to read transaction pool snapshot", "err", err)
+	}
+	var (
+		fresh   = make([]*types.Transaction, 0, len(txs))
+		stale   int
+		dropped int
+	)
+	for _, tx := range txs {
+		if time.Since(tx.Time()) > pool.config.Lifetime {
+			stale++
+			continue
+		}
+		fresh = append(fresh, tx)
+	}
+	// Add the transactions in small-ish batches to not hold the lock for long
+	for len(fresh) > 0 {
+		batch := fresh[:min(len(fresh), 1024)]
+		fresh = fresh[len(batch):]
+
+		for _, err := range pool.Add(batch, false) {
+			if err != nil {
+				log.Trace("Failed to add snapshotted transaction", "err", err)
+				dropped++
+			}
+		}
+	}
+	if len(txs) > 0 {
+		log.Info("Loaded transaction pool snapshot", "transactions", len(txs), "stale", stale, "dropped", dropped)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// Tests that the pool contents are persisted at shutdown and restored at the
+// next startup, dropping the transactions invalidated in the meantime.
+func TestSnapshotRestart(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+	)
+
+	config := testTxPoolConfig
+	config.Snapshot = filepath.Join(t.TempDir(), "snapshot.rlp")
+
+	// Create a pool with a few executable and queued transactions
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr, uint256.NewInt(1000000000), tracing.BalanceChangeUnspecified)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+
+	txs := []*types.Transaction{transaction(0, 100000, key), transaction(1, 100000, key), transaction(2, 100000, key), transaction(5, 100000, key)}
+	for i, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 3 || queued != 1 {
+		t.Fatalf("pool contents mismatch: have %d/%d, want 3/1", pending, queued)
+	}
+	pool.Close()
+
+	// Include the first transaction while the node is down, and restart
+	statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr, uint256.NewInt(1000000000), tracing.BalanceChangeUnspecified)
+	statedb.SetNonce(addr, 1, tracing.NonceChangeUnspecified)
+	blockchain = newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	pool = New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+	<-pool.requestReset(nil, nil)
+
+	if pending, queued := pool.Stats(); pending != 2 || queued != 1 {
+		t.Fatalf("restored pool contents mismatch: have %d/%d, want 2/1", pending, queued)
+	}
+	for i, tx := range txs {
+		if have, want := pool.Has(tx.Hash()), i > 0; have != want {
+			t.Errorf("transaction %d: presence mismatch: have %v, want %v", i, have, want)
+		}
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the transactions first seen longer than the lifetime ago are not
+// restored from the snapshot.
+func TestSnapshotStale(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	config := testTxPoolConfig
+	config.Snapshot = filepath.Join(t.TempDir(), "snapshot.rlp")
+
+	stale, fresh := transaction(0, 100000, key), transaction(1, 100000, key)
+	stale.SetTime(time.Now().Add(-2 * config.Lifetime))
+	fresh.SetTime(time.Now())
+	if err := writeSnapshot(config.Snapshot, []*types.Transaction{stale, fresh}); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+	txs, err := readSnapshot(config.Snapshot)
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	if len(txs) != 2 || txs[0].Hash() != stale.Hash() || txs[1].Time().Unix() != fresh.Time().Unix() {
+		t.Fatalf("snapshot contents mismatch")
+	}
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), uint256.NewInt(1000000000), tracing.BalanceChangeUnspecified)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+	<-pool.requestReset(nil, nil)
+
+	// The fresh transaction is gapped without the stale one, so it's queued
+	if pending, queued := pool.Stats(); pending != 0 || queued != 1 {
+		t.Fatalf("restored pool contents mismatch: have %d/%d, want 0/1", pending, queued)
+	}
+	if pool.Has(stale.Hash()) {
+		t.Fatalf("stale transaction restored")
+	}
+}
+
+// Tests that the transactions held privately are not persisted, so they are
+// not gossiped as public ones after a restart.
+func TestSnapshotPrivate(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	config := testTxPoolConfig
+	config.Snapshot = filepath.Join(t.TempDir(), "snapshot.rlp")
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), uint256.NewInt(1000000000), tracing.BalanceChangeUnspecified)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	public, private := transaction(0, 100000, key), transaction(1, 100000, key)
+
+	pool := New(config, blockchain)
+	pool.SetPrivateFilter(func(hash common.Hash) bool { return hash == private.Hash() })
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	for i, err := range pool.addRemotesSync([]*types.Transaction{public, private}) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	pool.Close()
+
+	txs, err := readSnapshot(config.Snapshot)
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	if len(txs) != 1 || txs[0].Hash() != public.Hash() {
+		t.Fatalf("snapshot contents mismatch: have %d transactions, want the public one", len(txs))
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// remover is implemented by the subpools able to drop individual transactions,
+// which is required for holding transactions privately until their expiry.
+type remover interface {
+	// RemoveTx removes a transaction from the pool, reporting whether it was
+	// present.
+	RemoveTx(hash common.Hash) bool
+}
+
+// privateFilterer is implemented by the subpools persisting their transactions,
+// which must leave out the ones held privately.
+type privateFilterer interface {
+	// SetPrivateFilter sets the function reporting whether a transaction is
+	// held privately.
+	SetPrivateFilter(private func(hash common.Hash) bool)
+}
+
+// AddPrivate adds a transaction to the pool which is held for inclusion by the
+// local node only, never being propagated to the network. The transaction is
+// dropped once the chain reaches the expiry block without including it.
+func (p *TxPool) AddPrivate(tx *types.Transaction, expiry uint64) error {
+	var supported bool
+	for _, subpool := range p.subpools {
+		if subpool.Filter(tx) {
+			_, supported = subpool.(remover)
+			break
+		}
+	}
+	if !supported {
+		return ErrPrivateUnsupported
+	}
+	// Mark the transaction private before adding it, as the pool announces the
+	// new transactions asynchronously.
+	hash := tx.Hash()
+
+	p.privateLock.Lock()
+	if _, ok := p.private[hash]; ok {
+		p.privateLock.Unlock()
+		return ErrAlreadyKnown
+	}
+	p.private[hash] = expiry
+	p.privateLock.Unlock()
+
+	if err := p.Add([]*types.Transaction{tx}, false)[0]; err != nil {
+		p.privateLock.Lock()
+		delete(p.private, hash)
+		p.privateLock.Unlock()
+		return err
+	}
+	return nil
+}
+
+// IsPrivate reports whether the transaction with the given hash is held
+// privately, and must not be propagated to the network.
+func (p *TxPool) IsPrivate(hash common.Hash) bool {
+	p.privateLock.RLock()
+	defer p.privateLock.RUnlock()
+
+	_, ok := p.private[hash]
+	return ok
+}
+
+// expirePrivate drops the private transactions which can't be included after
+// the given head block, and forgets the ones which left the pool.
+func (p *TxPool) expirePrivate(head uint64) {
+	p.privateLock.Lock()
+	defer p.privateLock.Unlock()
+
+	for hash, expiry := range p.private {
+		if !p.Has(hash) {
+			delete(p.private, hash)
+			continue
+		}
+		if head < expiry {
+			continue
+		}
+		for _, subpool := range p.subpools {
+			if remover, ok := subpool.(remover); ok && remover.RemoveTx(hash) {
+				log.Debug("Dropped expired private transaction", "hash", hash, "expiry", expiry)
+				break
+			}
+		}
+		delete(p.private, hash)
+	}
+}
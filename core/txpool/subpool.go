@@ -58,6 +58,17 @@ func (ltx *LazyTransaction) Resolve() *types.Transaction {
 	return ltx.Pool.Get(ltx.Hash)
 }
 
+// TxEntry is a pooled transaction handle along with the metadata needed to
+// filter and order the pool content without pulling up the transactions.
+type TxEntry struct {
+	*LazyTransaction
+
+	From    common.Address // Sender of the transaction
+	Nonce   uint64         // Nonce of the transaction
+	Type    uint8          // Type of the transaction
+	Pending bool           // Whether the transaction is executable or queued
+}
+
 // LazyResolver is a minimal interface needed for a transaction pool to satisfy
 // resolving lazy transactions. It's mostly a helper to avoid the entire sub-
 // pool being injected into the lazy transaction.
@@ -150,11 +161,19 @@ type SubPool interface {
 	// reduce allocations and load on downstream subsystems.
 	Pending(filter PendingFilter) map[common.Address][]*LazyTransaction
 
+	// Entries retrieves the handles of the pooled transactions of the given senders,
+	// or of all senders if none are given, without resolving them.
+	Entries(senders []common.Address) []*TxEntry
+
 	// SubscribeTransactions subscribes to new transaction events. The subscriber
 	// can decide whether to receive notifications only for newly seen transactions
 	// or also for reorged out ones.
 	SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription
 
+	// SubscribeTxEvents subscribes to the lifecycle events of the pooled
+	// transactions, delivered in batches.
+	SubscribeTxEvents(ch chan<- []TxEvent) event.Subscription
+
 	// Nonce returns the next nonce of an account, with all transactions executable
 	// by the pool already applied on top.
 	Nonce(addr common.Address) uint64
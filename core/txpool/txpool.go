@@ -70,6 +70,9 @@ type TxPool struct {
 	stateLock sync.RWMutex   // The lock for protecting state instance
 	state     *state.StateDB // Current state at the blockchain head
 
+	private     map[common.Hash]uint64 // Transactions not to be propagated, mapped to their expiry block
+	privateLock sync.RWMutex           // The lock for protecting the private transaction set
+
 	subs event.SubscriptionScope // Subscription scope to unsubscribe all on shutdown
 	quit chan chan error         // Quit channel to tear down the head updater
 	term chan struct{}           // Termination channel to detect a closed pool
@@ -100,12 +103,16 @@ func New(gasTip uint64, chain BlockChain, subpools []SubPool) (*TxPool, error) {
 		chain:    chain,
 		signer:   types.LatestSigner(chain.Config()),
 		state:    statedb,
+		private:  make(map[common.Hash]uint64),
 		quit:     make(chan chan error),
 		term:     make(chan struct{}),
 		sync:     make(chan chan error),
 	}
 	reserver := NewReservationTracker()
 	for i, subpool := range subpools {
+		if filterer, ok := subpool.(privateFilterer); ok {
+			filterer.SetPrivateFilter(pool.IsPrivate)
+		}
 		if err := subpool.Init(gasTip, head, reserver.NewHandle(i)); err != nil {
 			for j := i - 1; j >= 0; j-- {
 				subpools[j].Close()
@@ -230,6 +237,9 @@ func (p *TxPool) loop(head *types.Header) {
 			oldHead = head
 			<-resetBusy
 
+			// Drop the private transactions which can't be included any more
+			p.expirePrivate(head.Number.Uint64())
+
 			// If someone is waiting for a reset to finish, notify them, unless
 			// the forced op is still pending. In that case, wait another round
 			// of resets.
@@ -371,6 +381,16 @@ func (p *TxPool) Pending(filter PendingFilter) map[common.Address][]*LazyTransac
 	return txs
 }
 
+// Entries retrieves the handles of the pooled transactions of the given senders,
+// or of all senders if none are given, across all the subpools.
+func (p *TxPool) Entries(senders []common.Address) []*TxEntry {
+	var entries []*TxEntry
+	for _, subpool := range p.subpools {
+		entries = append(entries, subpool.Entries(senders)...)
+	}
+	return entries
+}
+
 // SubscribeTransactions registers a subscription for new transaction events,
 // supporting feeding only newly seen or also resurrected transactions.
 func (p *TxPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription {
@@ -381,6 +401,16 @@ func (p *TxPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool)
 	return p.subs.Track(event.JoinSubscriptions(subs...))
 }
 
+// SubscribeTxEvents registers a subscription for the lifecycle events of the
+// transactions across all the subpools.
+func (p *TxPool) SubscribeTxEvents(ch chan<- []TxEvent) event.Subscription {
+	subs := make([]event.Subscription, len(p.subpools))
+	for i, subpool := range p.subpools {
+		subs[i] = subpool.SubscribeTxEvents(ch)
+	}
+	return p.subs.Track(event.JoinSubscriptions(subs...))
+}
+
 // PoolNonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *TxPool) PoolNonce(addr common.Address) uint64 {
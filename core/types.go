@@ -33,6 +33,10 @@ type Validator interface {
 
 	// ValidateState validates the given statedb and optionally the process result.
 	ValidateState(block *types.Block, state *state.StateDB, res *ProcessResult, stateless bool) error
+
+	// ValidateAccessList validates the given block access list against the
+	// mutations made by the block onto the parent state.
+	ValidateAccessList(block *types.Block, parent, post *state.StateDB, bal *types.BlockAccessList) error
 }
 
 // Prefetcher is an interface for pre-caching transaction signatures and state.
@@ -57,4 +61,6 @@ type ProcessResult struct {
 	Requests [][]byte
 	Logs     []*types.Log
 	GasUsed  uint64
+
+	AccessList *types.BlockAccessList // Block access list, only set if requested
 }
@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+)
+
+//go:generate go run github.com/fjl/gencodec -type StorageChange -field-override storageChangeMarshaling -out gen_storage_change_json.go
+//go:generate go run github.com/fjl/gencodec -type BalanceChange -field-override balanceChangeMarshaling -out gen_balance_change_json.go
+//go:generate go run github.com/fjl/gencodec -type NonceChange -field-override nonceChangeMarshaling -out gen_nonce_change_json.go
+//go:generate go run github.com/fjl/gencodec -type CodeChange -field-override codeChangeMarshaling -out gen_code_change_json.go
+
+// BlockAccessList is the list of the accounts and storage slots accessed during
+// the execution of a block, along with the post-values of the modified ones, as
+// specified by EIP-7928.
+//
+// The changes are keyed by the block access index, which is 0 for the system
+// calls before the transactions, i+1 for the i-th transaction and n+1 for the
+// operations after the n transactions (e.g. withdrawals, requests and rewards).
+//
+// The accounts are sorted by address, the storage slots are sorted by key and
+// the changes are sorted by the block access index.
+type BlockAccessList struct {
+	Accounts []AccountAccess `json:"accounts"`
+}
+
+// Hash returns the keccak256 hash of the RLP encoding of the access list.
+func (bal *BlockAccessList) Hash() common.Hash {
+	enc, _ := rlp.EncodeToBytes(bal)
+	return crypto.Keccak256Hash(enc)
+}
+
+// AccountAccess is the accesses made to an account within a block.
+type AccountAccess struct {
+	Address        common.Address  `json:"address"`
+	StorageChanges []SlotChanges   `json:"storageChanges"` // Storage slots modified
+	StorageReads   []common.Hash   `json:"storageReads"`   // Storage slots read but never modified
+	BalanceChanges []BalanceChange `json:"balanceChanges"`
+	NonceChanges   []NonceChange   `json:"nonceChanges"`
+	CodeChanges    []CodeChange    `json:"codeChanges"`
+}
+
+// SlotChanges is the list of changes made to a storage slot within a block.
+type SlotChanges struct {
+	Slot    common.Hash     `json:"slot"`
+	Changes []StorageChange `json:"changes"`
+}
+
+// StorageChange is the value of a storage slot after a block access.
+type StorageChange struct {
+	Index uint16      `json:"index"`
+	Value common.Hash `json:"value"`
+}
+
+type storageChangeMarshaling struct {
+	Index hexutil.Uint64
+}
+
+// BalanceChange is the balance of an account after a block access.
+type BalanceChange struct {
+	Index   uint16       `json:"index"`
+	Balance *uint256.Int `json:"balance"`
+}
+
+type balanceChangeMarshaling struct {
+	Index   hexutil.Uint64
+	Balance *hexutil.U256
+}
+
+// NonceChange is the nonce of an account after a block access.
+type NonceChange struct {
+	Index uint16 `json:"index"`
+	Nonce uint64 `json:"nonce"`
+}
+
+type nonceChangeMarshaling struct {
+	Index hexutil.Uint64
+	Nonce hexutil.Uint64
+}
+
+// CodeChange is the code of an account after a block access.
+type CodeChange struct {
+	Index uint16 `json:"index"`
+	Code  []byte `json:"code"`
+}
+
+type codeChangeMarshaling struct {
+	Index hexutil.Uint64
+	Code  hexutil.Bytes
+}
@@ -0,0 +1,42 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*codeChangeMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (c CodeChange) MarshalJSON() ([]byte, error) {
+	type CodeChange struct {
+		Index hexutil.Uint64 `json:"index"`
+		Code  hexutil.Bytes  `json:"code"`
+	}
+	var enc CodeChange
+	enc.Index = hexutil.Uint64(c.Index)
+	enc.Code = c.Code
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (c *CodeChange) UnmarshalJSON(input []byte) error {
+	type CodeChange struct {
+		Index *hexutil.Uint64 `json:"index"`
+		Code  *hexutil.Bytes  `json:"code"`
+	}
+	var dec CodeChange
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Index != nil {
+		c.Index = uint16(*dec.Index)
+	}
+	if dec.Code != nil {
+		c.Code = *dec.Code
+	}
+	return nil
+}
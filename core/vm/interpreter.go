@@ -35,6 +35,7 @@ type Config struct {
 	ExtraEips               []int // Additional EIPS that are to be enabled
 
 	StatelessSelfValidation bool // Generate execution witnesses and self-check against them (testing purpose)
+	BlockAccessList         bool // Generate block access lists (EIP-7928) and self-check against them on import
 }
 
 // ScopeContext contains the things that are per-call, such as stack and memory,
@@ -0,0 +1,95 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// witnessArchive maintains the execution witnesses of the recently imported
+// blocks in a dedicated freezer, so that they can be served to the stateless
+// clients and provers without re-executing the blocks.
+type witnessArchive struct {
+	archive *rawdb.BlockArchive
+	limit   uint64 // Number of recent blocks whose witnesses are retained
+}
+
+// newWitnessArchive opens the witness archive in the ancient directory of the
+// given database, or keeps it in memory if the ancient store is not available.
+func newWitnessArchive(db ethdb.Database, limit uint64) (*witnessArchive, error) {
+	ancient, err := db.AncientDatadir()
+	if err != nil {
+		ancient = "" // ancient store is disabled, use the in-memory freezer
+	}
+	freezer, err := rawdb.NewWitnessFreezer(ancient, false)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := rawdb.NewWitnessArchive(freezer, limit)
+	if err != nil {
+		freezer.Close()
+		return nil, err
+	}
+	log.Info("Initialized witness archive", "range", limit)
+	return &witnessArchive{archive: archive, limit: limit}, nil
+}
+
+// wants reports whether the witness of a block with the given number would be
+// retained, given the number of the current head. The witnesses of the blocks
+// below the retention window, e.g. of stale side chains, are not generated.
+func (a *witnessArchive) wants(number uint64, head uint64) bool {
+	return number+a.limit > head
+}
+
+// write archives the execution witness of the given block. The witnesses of
+// the blocks not extending the archived chain are only kept aside until their
+// fork becomes the archived one.
+func (a *witnessArchive) write(block *types.Block, witness *stateless.Witness) error {
+	blob, err := rlp.EncodeToBytes(witness)
+	if err != nil {
+		return err
+	}
+	return a.archive.Write(block.NumberU64(), block.Hash(), block.ParentHash(), blob)
+}
+
+// read retrieves the archived execution witness of the given block, or nil if
+// it's not available.
+func (a *witnessArchive) read(hash common.Hash, number uint64) *stateless.Witness {
+	blob := a.archive.Read(number, hash)
+	if len(blob) == 0 {
+		return nil
+	}
+	witness := new(stateless.Witness)
+	if err := rlp.DecodeBytes(blob, witness); err != nil {
+		log.Error("Failed to decode archived witness", "number", number, "err", err)
+		return nil
+	}
+	return witness
+}
+
+// close closes the underlying freezer of the archive.
+func (a *witnessArchive) close() {
+	if err := a.archive.Close(); err != nil {
+		log.Error("Failed to close witness archive", "err", err)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the execution witnesses of the recent blocks are archived during
+// the chain import, and that the archived and regenerated witnesses are both
+// sufficient for the stateless execution.
+func TestWitnessArchive(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender:                    {Balance: big.NewInt(params.Ether)},
+				params.BeaconRootsAddress: {Code: params.BeaconRootsCode},
+			},
+		}
+		engine = beacon.New(ethash.NewFaker())
+	)
+	generate := func(i int, b *BlockGen) {
+		b.SetParentBeaconRoot(common.Hash{byte(i + 1)})
+		b.AddTx(types.MustSignNewTx(key, b.Signer(), &types.LegacyTx{
+			Nonce:    b.TxNonce(sender),
+			To:       &common.Address{byte(i)},
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}))
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 5, generate)
+
+	options := DefaultConfig()
+	options.WitnessHistory = 3
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, options)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert chain: %v", err)
+	}
+	// Ensure only the witnesses within the retention window are archived
+	for i, block := range blocks {
+		witness := chain.GetWitness(block.Hash(), block.NumberU64())
+		if i < len(blocks)-3 {
+			if witness != nil {
+				t.Fatalf("Block %d: unexpected archived witness", block.NumberU64())
+			}
+			continue
+		}
+		if witness == nil {
+			t.Fatalf("Block %d: witness not archived", block.NumberU64())
+		}
+		if _, _, err := VerifyStateless(gspec.Config, vm.Config{}, block, witness); err != nil {
+			t.Fatalf("Block %d: failed to verify with archived witness: %v", block.NumberU64(), err)
+		}
+	}
+	// Ensure the witness can be regenerated for the blocks out of the window
+	witness, err := chain.GenerateWitness(blocks[1])
+	if err != nil {
+		t.Fatalf("Failed to generate witness: %v", err)
+	}
+	if _, _, err := VerifyStateless(gspec.Config, vm.Config{}, blocks[1], witness); err != nil {
+		t.Fatalf("Failed to verify with generated witness: %v", err)
+	}
+	// Import a sibling of the head block and ensure the canonical witnesses
+	// survive, with the one of the sibling kept aside
+	_, sides, _ := GenerateChainWithGenesis(gspec, engine, 6, func(i int, b *BlockGen) {
+		generate(i, b)
+		if i == 4 {
+			b.SetExtra([]byte("side"))
+		}
+	})
+	side := sides[4]
+	if _, err := chain.InsertBlockWithoutSetHead(side, false); err != nil {
+		t.Fatalf("Failed to insert side block: %v", err)
+	}
+	for _, block := range append(blocks[2:], side) {
+		if chain.GetWitness(block.Hash(), block.NumberU64()) == nil {
+			t.Fatalf("Block %d (%x): witness not available", block.NumberU64(), block.Hash())
+		}
+	}
+	// Extend the side chain beyond the archived one and ensure the archive is
+	// reorged onto it, without losing the replaced witness
+	if _, err := chain.InsertBlockWithoutSetHead(sides[5], false); err != nil {
+		t.Fatalf("Failed to insert side block: %v", err)
+	}
+	for _, block := range append(blocks[3:], sides[4:]...) {
+		witness := chain.GetWitness(block.Hash(), block.NumberU64())
+		if witness == nil {
+			t.Fatalf("Block %d (%x): witness not available", block.NumberU64(), block.Hash())
+		}
+		if _, _, err := VerifyStateless(gspec.Config, vm.Config{}, block, witness); err != nil {
+			t.Fatalf("Block %d: failed to verify with archived witness: %v", block.NumberU64(), err)
+		}
+	}
+}
+
+// Tests that the witness archive is persisted in the ancient store and can be
+// reopened, as well as that the gaps in the archived blocks are filled.
+func TestWitnessArchiveReopen(t *testing.T) {
+	db, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	var blocks []*types.Block
+	for i := 0; i < 10; i++ {
+		blocks = append(blocks, types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(100 + i))}))
+	}
+	newWitness := func(block *types.Block) *stateless.Witness {
+		return &stateless.Witness{
+			Headers: []*types.Header{block.Header()},
+			Codes:   map[string]struct{}{string(block.Hash().Bytes()): {}},
+			State:   map[string]struct{}{},
+		}
+	}
+	archive, err := newWitnessArchive(db, 3)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	for _, block := range blocks[:5] {
+		if err := archive.write(block, newWitness(block)); err != nil {
+			t.Fatalf("Failed to archive witness: %v", err)
+		}
+	}
+	archive.close()
+
+	archive, err = newWitnessArchive(db, 3)
+	if err != nil {
+		t.Fatalf("Failed to reopen archive: %v", err)
+	}
+	defer archive.close()
+
+	check := func(available []*types.Block, missing []*types.Block) {
+		t.Helper()
+		for _, block := range available {
+			witness := archive.read(block.Hash(), block.NumberU64())
+			if witness == nil || witness.Headers[0].Hash() != block.Hash() {
+				t.Fatalf("Block %d: witness not available", block.NumberU64())
+			}
+		}
+		for _, block := range missing {
+			if archive.read(block.Hash(), block.NumberU64()) != nil {
+				t.Fatalf("Block %d: unexpected witness", block.NumberU64())
+			}
+		}
+	}
+	check(blocks[2:5], blocks[:2])
+
+	// Write a witness leaving a gap, the archived ones are retained until they
+	// fall out of the retention window
+	if err := archive.write(blocks[6], newWitness(blocks[6])); err != nil {
+		t.Fatalf("Failed to archive witness: %v", err)
+	}
+	check(blocks[4:5], blocks[:4])
+	check(blocks[6:7], blocks[5:6])
+}
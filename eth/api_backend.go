@@ -19,6 +19,7 @@ package eth
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -236,12 +238,9 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	if header == nil {
 		return nil, nil, errors.New("header not found")
 	}
-	stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+	stateDb, err := b.stateAt(header)
 	if err != nil {
-		stateDb, err = b.eth.BlockChain().HistoricState(header.Root)
-		if err != nil {
-			return nil, nil, err
-		}
+		return nil, nil, err
 	}
 	return stateDb, header, nil
 }
@@ -261,21 +260,31 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 		if blockNrOrHash.RequireCanonical && b.eth.blockchain.GetCanonicalHash(header.Number.Uint64()) != hash {
 			return nil, nil, errors.New("hash is not currently canonical")
 		}
-		stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+		stateDb, err := b.stateAt(header)
 		if err != nil {
-			stateDb, err = b.eth.BlockChain().HistoricState(header.Root)
-			if err != nil {
-				return nil, nil, err
-			}
+			return nil, nil, err
 		}
 		return stateDb, header, nil
 	}
 	return nil, nil, errors.New("invalid arguments; neither block nor hash specified")
 }
 
+// stateAt returns the state of the given block. The live state is preferred,
+// while the historical state is resolved from the indexed state histories in
+// path scheme archive mode.
+func (b *EthAPIBackend) stateAt(header *types.Header) (*state.StateDB, error) {
+	stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+	if err == nil {
+		return stateDb, nil
+	}
+	if b.eth.BlockChain().TrieDB().Scheme() != rawdb.PathScheme || !b.eth.ArchiveMode() {
+		return nil, err
+	}
+	return b.eth.BlockChain().HistoricState(header.Root)
+}
+
 func (b *EthAPIBackend) HistoryPruningCutoff() uint64 {
-	bn, _ := b.eth.blockchain.HistoryPruningCutoff()
-	return bn
+	return b.eth.blockchain.HistoryRetrievalCutoff()
 }
 
 func (b *EthAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
@@ -389,6 +398,12 @@ func (b *EthAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transac
 	return b.eth.txPool.ContentFrom(addr)
 }
 
+// TxPoolEntries retrieves the unresolved handles of the pooled transactions of
+// the given senders, or of all senders if none are given.
+func (b *EthAPIBackend) TxPoolEntries(senders []common.Address) []*txpool.TxEntry {
+	return b.eth.txPool.Entries(senders)
+}
+
 func (b *EthAPIBackend) TxPool() *txpool.TxPool {
 	return b.eth.txPool
 }
@@ -397,6 +412,10 @@ func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.S
 	return b.eth.txPool.SubscribeTransactions(ch, true)
 }
 
+func (b *EthAPIBackend) SubscribeTxPoolEvents(ch chan<- []txpool.TxEvent) event.Subscription {
+	return b.eth.txPool.SubscribeTxEvents(ch)
+}
+
 func (b *EthAPIBackend) SyncProgress(ctx context.Context) ethereum.SyncProgress {
 	prog := b.eth.Downloader().Progress()
 	if txProg, err := b.eth.blockchain.TxIndexProgress(); err == nil {
@@ -477,6 +496,27 @@ func (b *EthAPIBackend) StateAtBlock(ctx context.Context, block *types.Block, re
 	return b.eth.stateAtBlock(ctx, block, reexec, base, readOnly, preferDisk)
 }
 
+// ReplayBlock re-executes the given block on top of the state of its parent,
+// reporting every state change made while processing it to the given hooks,
+// including the ones of the system calls and the block finalization.
+func (b *EthAPIBackend) ReplayBlock(ctx context.Context, block *types.Block, hooks *tracing.Hooks) error {
+	if block.NumberU64() == 0 {
+		return errors.New("genesis is not executable")
+	}
+	parent := b.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, release, err := b.eth.stateAtBlock(ctx, parent, 0, nil, true, false)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	_, err = b.eth.blockchain.Processor().Process(block, statedb, vm.Config{Tracer: hooks})
+	return err
+}
+
 func (b *EthAPIBackend) StateAtTransaction(ctx context.Context, block *types.Block, txIndex int, reexec uint64) (*types.Transaction, vm.BlockContext, *state.StateDB, tracers.StateReleaseFunc, error) {
 	return b.eth.stateAtTransaction(ctx, block, txIndex, reexec)
 }
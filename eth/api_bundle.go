@@ -0,0 +1,194 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BundleAPI provides an API to submit and simulate transaction bundles, which
+// are included in the built blocks atomically. It's served in the mev namespace,
+// which needs to be enabled explicitly.
+type BundleAPI struct {
+	e *Ethereum
+}
+
+// NewBundleAPI creates a new BundleAPI instance.
+func NewBundleAPI(e *Ethereum) *BundleAPI {
+	return &BundleAPI{e}
+}
+
+// SendBundleArgs represents the arguments of a bundle submission.
+type SendBundleArgs struct {
+	Txs          []hexutil.Bytes `json:"txs"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	MinTimestamp *hexutil.Uint64 `json:"minTimestamp"`
+	MaxTimestamp *hexutil.Uint64 `json:"maxTimestamp"`
+}
+
+// SendBundleResult is the response of a bundle submission.
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// SendBundle submits a bundle of signed transactions to be included atomically,
+// in the given order, into the block with the given number.
+func (api *BundleAPI) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	txs, err := decodeBundleTxs(args.Txs)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &miner.Bundle{
+		Txs:         txs,
+		BlockNumber: uint64(args.BlockNumber),
+	}
+	if args.MinTimestamp != nil {
+		bundle.MinTimestamp = uint64(*args.MinTimestamp)
+	}
+	if args.MaxTimestamp != nil {
+		bundle.MaxTimestamp = uint64(*args.MaxTimestamp)
+	}
+	if err := api.e.Miner().SendBundle(bundle); err != nil {
+		return nil, err
+	}
+	return &SendBundleResult{BundleHash: bundle.Hash()}, nil
+}
+
+// CallBundleArgs represents the arguments of a bundle simulation.
+type CallBundleArgs struct {
+	Txs              []hexutil.Bytes        `json:"txs"`
+	BlockNumber      hexutil.Uint64         `json:"blockNumber"`      // Block the bundle is simulated in, the one after the state block if zero
+	StateBlockNumber *rpc.BlockNumberOrHash `json:"stateBlockNumber"` // Block the simulation is done on top of, the latest if unset
+	Coinbase         *common.Address        `json:"coinbase"`         // Fee recipient of the simulated block, the pending one if unset
+	Timestamp        *hexutil.Uint64        `json:"timestamp"`        // Timestamp of the simulated block, the state block's plus 12 seconds if unset
+}
+
+// CallBundleTxResult is the outcome of a transaction in a simulated bundle.
+type CallBundleTxResult struct {
+	TxHash            common.Hash     `json:"txHash"`
+	FromAddress       common.Address  `json:"fromAddress"`
+	ToAddress         *common.Address `json:"toAddress"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	GasFees           *hexutil.Big    `json:"gasFees"`
+	EthSentToCoinbase *hexutil.Big    `json:"ethSentToCoinbase"`
+	CoinbaseDiff      *hexutil.Big    `json:"coinbaseDiff"`
+	Error             string          `json:"error,omitempty"`
+}
+
+// CallBundleResult is the outcome of a bundle simulation.
+type CallBundleResult struct {
+	BundleHash        common.Hash           `json:"bundleHash"`
+	BundleGasPrice    *hexutil.Big          `json:"bundleGasPrice"`
+	CoinbaseDiff      *hexutil.Big          `json:"coinbaseDiff"`
+	GasFees           *hexutil.Big          `json:"gasFees"`
+	EthSentToCoinbase *hexutil.Big          `json:"ethSentToCoinbase"`
+	TotalGasUsed      hexutil.Uint64        `json:"totalGasUsed"`
+	StateBlockNumber  hexutil.Uint64        `json:"stateBlockNumber"`
+	Results           []*CallBundleTxResult `json:"results"`
+}
+
+// CallBundle simulates a bundle on top of the given state block, reporting the
+// outcome of its transactions and the payment made to the coinbase. Execution
+// stops at the first failing transaction.
+func (api *BundleAPI) CallBundle(ctx context.Context, args CallBundleArgs) (*CallBundleResult, error) {
+	txs, err := decodeBundleTxs(args.Txs)
+	if err != nil {
+		return nil, err
+	}
+	stateBlock := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if args.StateBlockNumber != nil {
+		stateBlock = *args.StateBlockNumber
+	} else if args.BlockNumber > 0 {
+		stateBlock = rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(args.BlockNumber - 1))
+	}
+	parent, err := api.e.APIBackend.HeaderByNumberOrHash(ctx, stateBlock)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, errors.New("state block not found")
+	}
+	if args.BlockNumber > 0 && uint64(args.BlockNumber) != parent.Number.Uint64()+1 {
+		return nil, fmt.Errorf("block %d does not follow state block %d", args.BlockNumber, parent.Number)
+	}
+	timestamp := parent.Time + 12
+	if args.Timestamp != nil {
+		timestamp = uint64(*args.Timestamp)
+	}
+	bundle := &miner.Bundle{Txs: txs, BlockNumber: parent.Number.Uint64() + 1}
+	sim, err := api.e.Miner().SimulateBundle(bundle, parent.Hash(), timestamp, args.Coinbase)
+	if err != nil {
+		return nil, err
+	}
+	result := &CallBundleResult{
+		BundleHash:       bundle.Hash(),
+		BundleGasPrice:   (*hexutil.Big)(sim.GasPrice()),
+		CoinbaseDiff:     (*hexutil.Big)(sim.CoinbaseDiff),
+		TotalGasUsed:     hexutil.Uint64(sim.GasUsed),
+		StateBlockNumber: hexutil.Uint64(parent.Number.Uint64()),
+	}
+	var (
+		fees = new(big.Int)
+		sent = new(big.Int)
+	)
+	for _, res := range sim.Results {
+		txResult := &CallBundleTxResult{
+			TxHash:            res.Hash,
+			FromAddress:       res.From,
+			ToAddress:         res.To,
+			GasUsed:           hexutil.Uint64(res.GasUsed),
+			GasFees:           (*hexutil.Big)(res.GasFees),
+			EthSentToCoinbase: (*hexutil.Big)(res.CoinbaseTransfer),
+			CoinbaseDiff:      (*hexutil.Big)(new(big.Int).Add(res.GasFees, res.CoinbaseTransfer)),
+		}
+		if res.Err != nil {
+			txResult.Error = res.Err.Error()
+		} else {
+			fees.Add(fees, res.GasFees)
+			sent.Add(sent, res.CoinbaseTransfer)
+		}
+		result.Results = append(result.Results, txResult)
+	}
+	result.GasFees = (*hexutil.Big)(fees)
+	result.EthSentToCoinbase = (*hexutil.Big)(sent)
+	return result, nil
+}
+
+// decodeBundleTxs decodes the signed transactions of a bundle.
+func decodeBundleTxs(encoded []hexutil.Bytes) (types.Transactions, error) {
+	if len(encoded) == 0 {
+		return nil, errors.New("bundle has no transactions")
+	}
+	txs := make(types.Transactions, len(encoded))
+	for i, input := range encoded {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return nil, fmt.Errorf("transaction %d: %v", i, err)
+		}
+		txs[i] = tx
+	}
+	return txs, nil
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/stateless"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
@@ -229,6 +231,59 @@ func (api *DebugAPI) StorageRangeAt(ctx context.Context, blockNrOrHash rpc.Block
 	return storageRangeAt(statedb, block.Root(), contractAddress, keyStart, maxResult)
 }
 
+// GetBlockAccessList re-executes the given block and returns the block access
+// list (EIP-7928) of it, containing all the accounts and storage slots accessed
+// in the block, along with the post-values of the modified ones.
+func (api *DebugAPI) GetBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.BlockAccessList, error) {
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %v not found", blockNrOrHash)
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not executable")
+	}
+	parent := api.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, release, err := api.eth.stateAtBlock(ctx, parent, 0, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	res, err := api.eth.blockchain.Processor().Process(block, statedb, vm.Config{BlockAccessList: true})
+	if err != nil {
+		return nil, err
+	}
+	return res.AccessList, nil
+}
+
+// ExecutionWitness returns the execution witness of the given block, containing
+// all the state trie nodes, contract codes and ancestor headers required for the
+// stateless execution of it. The witness is served from the witness archive if
+// available, otherwise it's regenerated on top of the parent state.
+func (api *DebugAPI) ExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*stateless.ExtWitness, error) {
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %v not found", blockNrOrHash)
+	}
+	if witness := api.eth.blockchain.GetWitness(block.Hash(), block.NumberU64()); witness != nil {
+		return witness.ToExtWitness(), nil
+	}
+	witness, err := api.eth.blockchain.GenerateWitness(block)
+	if err != nil {
+		return nil, err
+	}
+	return witness.ToExtWitness(), nil
+}
+
 func storageRangeAt(statedb *state.StateDB, root common.Hash, address common.Address, start []byte, maxResult int) (StorageRangeResult, error) {
 	storageRoot := statedb.GetStorageRoot(address)
 	if storageRoot == types.EmptyRootHash || storageRoot == (common.Hash{}) {
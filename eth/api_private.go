@@ -0,0 +1,103 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// defaultPrivateTxExpiry is the number of blocks a private transaction is
+	// held for if the submitter doesn't specify an expiry block.
+	defaultPrivateTxExpiry = 25
+
+	// privateTxForwardTimeout is the maximum time allowed for forwarding a
+	// private transaction to a single builder.
+	privateTxForwardTimeout = 5 * time.Second
+)
+
+// PrivateTxAPI provides an API to submit transactions which are held by the
+// local node for inclusion, without being propagated to the network.
+type PrivateTxAPI struct {
+	e *Ethereum
+}
+
+// NewPrivateTxAPI creates a new PrivateTxAPI instance.
+func NewPrivateTxAPI(e *Ethereum) *PrivateTxAPI {
+	return &PrivateTxAPI{e}
+}
+
+// SendPrivateRawTransaction adds the signed transaction to the pool without
+// announcing it to the peers, and forwards it to the configured builders. The
+// transaction is dropped from the pool if it's not included until the expiry
+// block, which defaults to 25 blocks after the current head.
+func (api *PrivateTxAPI) SendPrivateRawTransaction(ctx context.Context, input hexutil.Bytes, maxBlockNumber *hexutil.Uint64) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	head := api.e.blockchain.CurrentBlock().Number.Uint64()
+	expiry := head + defaultPrivateTxExpiry
+	if maxBlockNumber != nil {
+		expiry = uint64(*maxBlockNumber)
+	}
+	if expiry <= head {
+		return common.Hash{}, fmt.Errorf("expiry block %d not after current head %d", expiry, head)
+	}
+	// Apply the same checks as for the public submissions
+	if err := ethapi.CheckSubmission(api.e.APIBackend, tx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.e.txPool.AddPrivate(tx, expiry); err != nil {
+		return common.Hash{}, err
+	}
+	for _, url := range api.e.config.PrivateTxBuilders {
+		go forwardPrivateTx(url, input, expiry)
+	}
+	log.Info("Submitted private transaction", "hash", tx.Hash(), "nonce", tx.Nonce(), "expiry", expiry)
+	return tx.Hash(), nil
+}
+
+// forwardPrivateTx submits a private transaction to a block builder.
+func forwardPrivateTx(url string, input hexutil.Bytes, expiry uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), privateTxForwardTimeout)
+	defer cancel()
+
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		log.Warn("Failed to connect to builder", "url", url, "err", err)
+		return
+	}
+	defer client.Close()
+
+	request := map[string]interface{}{
+		"tx":             input,
+		"maxBlockNumber": hexutil.Uint64(expiry),
+	}
+	if err := client.CallContext(ctx, nil, "eth_sendPrivateTransaction", request); err != nil {
+		log.Warn("Failed to forward private transaction", "url", url, "err", err)
+	}
+}
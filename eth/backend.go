@@ -33,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
+	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/txpool"
@@ -54,6 +55,7 @@ import (
 	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/miner/builder"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
@@ -111,7 +113,8 @@ type Ethereum struct {
 	filterMaps      *filtermaps.FilterMaps
 	closeFilterMaps chan chan struct{}
 
-	APIBackend *EthAPIBackend
+	APIBackend   *EthAPIBackend
+	traceArchive tracers.TraceArchive // Trace archive maintained by the live tracer, if any
 
 	miner    *miner.Miner
 	gasPrice *big.Int
@@ -136,6 +139,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if !config.HistoryMode.IsValid() {
 		return nil, fmt.Errorf("invalid history mode %d", config.HistoryMode)
 	}
+	if config.HistoryMode == history.KeepRecent && config.HistoryRecent < params.FullImmutabilityThreshold {
+		return nil, fmt.Errorf("history retention window %d is below the immutability threshold %d", config.HistoryRecent, params.FullImmutabilityThreshold)
+	}
 	if config.Miner.GasPrice == nil || config.Miner.GasPrice.Sign() <= 0 {
 		log.Warn("Sanitizing invalid miner gas price", "provided", config.Miner.GasPrice, "updated", ethconfig.Defaults.Miner.GasPrice)
 		config.Miner.GasPrice = new(big.Int).Set(ethconfig.Defaults.Miner.GasPrice)
@@ -223,33 +229,47 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	var (
 		vmConfig = vm.Config{
 			EnablePreimageRecording: config.EnablePreimageRecording,
+			BlockAccessList:         config.BlockAccessList,
 		}
 		options = &core.BlockChainConfig{
-			TrieCleanLimit:   config.TrieCleanCache,
-			NoPrefetch:       config.NoPrefetch,
-			TrieDirtyLimit:   config.TrieDirtyCache,
-			ArchiveMode:      config.NoPruning,
-			TrieTimeLimit:    config.TrieTimeout,
-			SnapshotLimit:    config.SnapshotCache,
-			Preimages:        config.Preimages,
-			StateHistory:     config.StateHistory,
-			StateScheme:      scheme,
-			ChainHistoryMode: config.HistoryMode,
-			TxLookupLimit:    int64(min(config.TransactionHistory, math.MaxInt64)),
-			VmConfig:         vmConfig,
+			TrieCleanLimit:     config.TrieCleanCache,
+			NoPrefetch:         config.NoPrefetch,
+			Parallel:           config.Parallel,
+			TrieDirtyLimit:     config.TrieDirtyCache,
+			ArchiveMode:        config.NoPruning,
+			TrieTimeLimit:      config.TrieTimeout,
+			SnapshotLimit:      config.SnapshotCache,
+			Preimages:          config.Preimages,
+			StateHistory:       config.StateHistory,
+			WitnessHistory:     config.WitnessHistory,
+			StateScheme:        scheme,
+			ChainHistoryMode:   config.HistoryMode,
+			ChainHistoryRecent: config.HistoryRecent,
+			TxLookupLimit:      int64(min(config.TransactionHistory, math.MaxInt64)),
+			VmConfig:           vmConfig,
 		}
 	)
 
+	// In path scheme, the archive mode doesn't retain the historical tries, but
+	// the historical states are served from the indexed state histories instead.
+	if scheme == rawdb.PathScheme && config.NoPruning {
+		if config.StateHistory == 0 {
+			log.Info("Serving historical state of the entire chain from state histories")
+		} else {
+			log.Info("Serving historical state of recent blocks from state histories", "blocks", config.StateHistory)
+		}
+	}
 	if config.VMTrace != "" {
 		traceConfig := json.RawMessage("{}")
 		if config.VMTraceJsonConfig != "" {
 			traceConfig = json.RawMessage(config.VMTraceJsonConfig)
 		}
-		t, err := tracers.LiveDirectory.New(config.VMTrace, traceConfig)
+		t, archive, err := tracers.LiveDirectory.NewWithArchive(config.VMTrace, traceConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create tracer %s: %v", config.VMTrace, err)
 		}
 		vmConfig.Tracer = t
+		eth.traceArchive = archive
 	}
 	// Override the chain config with provided settings.
 	var overrides core.ChainOverrides
@@ -274,7 +294,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		HashScheme:     scheme == rawdb.HashScheme,
 	}
 	chainView := eth.newChainView(eth.blockchain.CurrentBlock())
-	historyCutoff, _ := eth.blockchain.HistoryPruningCutoff()
+	historyCutoff := eth.blockchain.HistoryRetrievalCutoff()
 	var finalBlock uint64
 	if fb := eth.blockchain.CurrentFinalBlock(); fb != nil {
 		finalBlock = fb.Number.Uint64()
@@ -286,6 +306,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
+	if config.TxPool.Snapshot != "" {
+		config.TxPool.Snapshot = stack.ResolvePath(config.TxPool.Snapshot)
+	}
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
 
 	if config.BlobPool.Datadir != "" {
@@ -320,6 +343,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		BloomCache:     uint64(cacheLimit),
 		EventMux:       eth.eventMux,
 		RequiredBlocks: config.RequiredBlocks,
+		TxPeerSlots:    config.TxPool.PeerSlots,
 	}); err != nil {
 		return nil, err
 	}
@@ -330,6 +354,16 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 	eth.miner.SetPrioAddresses(config.TxPool.Locals)
 
+	// Submit the built payloads to the builder relays if requested
+	if len(config.Builder.Relays) > 0 {
+		b, err := builder.New(config.Builder, eth.miner)
+		if err != nil {
+			return nil, err
+		}
+		stack.RegisterAPIs(b.APIs())
+		stack.RegisterLifecycle(b)
+	}
+
 	eth.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, eth, nil}
 	if eth.APIBackend.allowUnprotectedTxs {
 		log.Info("Unprotected transactions allowed")
@@ -380,6 +414,12 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "eth",
 			Service:   downloader.NewDownloaderAPI(s.handler.downloader, s.blockchain, s.eventMux),
+		}, {
+			Namespace: "eth",
+			Service:   NewPrivateTxAPI(s),
+		}, {
+			Namespace: "mev",
+			Service:   NewBundleAPI(s),
 		}, {
 			Namespace: "admin",
 			Service:   NewAdminAPI(s),
@@ -411,6 +451,10 @@ func (s *Ethereum) Synced() bool                       { return s.handler.synced
 func (s *Ethereum) SetSynced()                         { s.handler.enableSyncedFeatures() }
 func (s *Ethereum) ArchiveMode() bool                  { return s.config.NoPruning }
 
+// TraceArchive returns the trace archive maintained by the live tracer in use,
+// or nil if the tracer doesn't archive the traces.
+func (s *Ethereum) TraceArchive() tracers.TraceArchive { return s.traceArchive }
+
 // Protocols returns all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
@@ -476,7 +520,7 @@ func (s *Ethereum) updateFilterMapsHeads() {
 		if head == nil || newHead.Hash() != head.Hash() {
 			head = newHead
 			chainView := s.newChainView(head)
-			historyCutoff, _ := s.blockchain.HistoryPruningCutoff()
+			historyCutoff := s.blockchain.HistoryRetrievalCutoff()
 			var finalBlock uint64
 			if fb := s.blockchain.CurrentFinalBlock(); fb != nil {
 				finalBlock = fb.Number.Uint64()
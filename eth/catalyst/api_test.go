@@ -1804,3 +1804,85 @@ func TestValidateRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestInclusionList(t *testing.T) {
+	genesis, blocks := generateMergeChain(10, true)
+
+	// Enable prague on the last block.
+	time := blocks[len(blocks)-1].Header().Time + 1
+	genesis.Config.ShanghaiTime = &time
+	genesis.Config.CancunTime = &time
+	genesis.Config.PragueTime = &time
+	genesis.Config.BlobScheduleConfig = params.DefaultBlobSchedule
+
+	n, ethservice := startEthService(t, genesis, blocks)
+	defer n.Close()
+
+	var (
+		api    = newConsensusAPIWithoutHeartbeat(ethservice)
+		parent = ethservice.BlockChain().CurrentBlock()
+		signer = types.LatestSigner(ethservice.BlockChain().Config())
+		nonce  = ethservice.TxPool().Nonce(testAddr)
+	)
+	makeTx := func(nonce uint64) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTransaction(nonce, common.Address{0x01}, big.NewInt(1), params.TxGas, big.NewInt(2*params.InitialBaseFee), nil), signer, testKey)
+		return tx
+	}
+	encode := func(txs ...*types.Transaction) []hexutil.Bytes {
+		list := make([]hexutil.Bytes, len(txs))
+		for i, tx := range txs {
+			list[i], _ = tx.MarshalBinary()
+		}
+		return list
+	}
+	// Ensure the inclusion list is assembled from the pool
+	tx := makeTx(nonce)
+	if errs := ethservice.TxPool().Add([]*types.Transaction{tx}, true); errs[0] != nil {
+		t.Fatalf("failed to add transaction: %v", errs[0])
+	}
+	list, err := api.GetInclusionListV1(parent.Hash())
+	if err != nil {
+		t.Fatalf("failed to get inclusion list: %v", err)
+	}
+	if !reflect.DeepEqual(list, encode(tx)) {
+		t.Fatalf("inclusion list mismatch: have %v, want %v", list, encode(tx))
+	}
+	// Build an empty payload, leaving out the listed transaction
+	beaconRoot := common.Hash{42}
+	payload, err := ethservice.Miner().BuildPayload(&miner.BuildPayloadArgs{
+		Parent:      parent.Hash(),
+		Timestamp:   parent.Time + 5,
+		Withdrawals: make([]*types.Withdrawal, 0),
+		BeaconRoot:  &beaconRoot,
+		Version:     engine.PayloadV3,
+	}, false)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	empty := payload.ResolveEmpty()
+	requests := make([]hexutil.Bytes, len(empty.Requests))
+	for i, req := range empty.Requests {
+		requests[i] = req
+	}
+	tests := []struct {
+		list []hexutil.Bytes
+		want string
+	}{
+		{[]hexutil.Bytes{}, engine.VALID},
+		{encode(tx), engine.INCLUSION_LIST_UNSATISFIED},
+		{encode(makeTx(nonce + 1)), engine.VALID}, // not executable after the block
+	}
+	for i, tt := range tests {
+		status, err := api.NewPayloadV5(*empty.ExecutionPayload, []common.Hash{}, &beaconRoot, requests, tt.list)
+		if err != nil {
+			t.Fatalf("test %d: failed to validate payload: %v", i, err)
+		}
+		if status.Status != tt.want {
+			t.Errorf("test %d: status mismatch: have %s, want %s", i, status.Status, tt.want)
+		}
+	}
+	// Ensure the inclusion list of unknown payloads can't be updated
+	if _, err := api.UpdatePayloadWithInclusionListV1(engine.PayloadID{0x01}, encode(tx)); err != engine.UnknownPayload {
+		t.Errorf("unexpected error for unknown payload: have %v, want %v", err, engine.UnknownPayload)
+	}
+}
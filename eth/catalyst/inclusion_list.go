@@ -0,0 +1,134 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params/forks"
+)
+
+// GetInclusionListV1 assembles an inclusion list from the transaction pool for
+// the block on top of the given parent, see EIP-7805.
+func (api *ConsensusAPI) GetInclusionListV1(parentHash common.Hash) ([]hexutil.Bytes, error) {
+	log.Trace("Engine API request received", "method", "GetInclusionList", "parent", parentHash)
+
+	txs, err := api.eth.Miner().BuildInclusionList(parentHash)
+	if err != nil {
+		return nil, engine.GenericServerError.With(err)
+	}
+	list := make([]hexutil.Bytes, 0, len(txs))
+	for _, tx := range txs {
+		enc, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, engine.GenericServerError.With(err)
+		}
+		list = append(list, enc)
+	}
+	return list, nil
+}
+
+// UpdatePayloadWithInclusionListV1 sets the inclusion list a payload being built
+// needs to satisfy. The payload is rebuilt to include the listed transactions
+// not picked from the pool, as long as they fit and are valid.
+func (api *ConsensusAPI) UpdatePayloadWithInclusionListV1(payloadID engine.PayloadID, inclusionList []hexutil.Bytes) (*engine.PayloadID, error) {
+	log.Trace("Engine API request received", "method", "UpdatePayloadWithInclusionList", "id", payloadID, "txs", len(inclusionList))
+
+	txs, err := decodeInclusionList(inclusionList)
+	if err != nil {
+		return nil, engine.InvalidParams.With(err)
+	}
+	if !api.localBlocks.updateInclusionList(payloadID, txs) {
+		return nil, engine.UnknownPayload
+	}
+	return &payloadID, nil
+}
+
+// NewPayloadV5 is analogous to NewPayloadV4, only it also checks that the payload
+// satisfies the given inclusion list. A payload leaving out a listed transaction
+// which it could have included is reported as INCLUSION_LIST_UNSATISFIED.
+func (api *ConsensusAPI) NewPayloadV5(params engine.ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash, executionRequests []hexutil.Bytes, inclusionList []hexutil.Bytes) (engine.PayloadStatusV1, error) {
+	switch {
+	case params.Withdrawals == nil:
+		return invalidStatus, paramsErr("nil withdrawals post-shanghai")
+	case params.ExcessBlobGas == nil:
+		return invalidStatus, paramsErr("nil excessBlobGas post-cancun")
+	case params.BlobGasUsed == nil:
+		return invalidStatus, paramsErr("nil blobGasUsed post-cancun")
+	case versionedHashes == nil:
+		return invalidStatus, paramsErr("nil versionedHashes post-cancun")
+	case beaconRoot == nil:
+		return invalidStatus, paramsErr("nil beaconRoot post-cancun")
+	case executionRequests == nil:
+		return invalidStatus, paramsErr("nil executionRequests post-prague")
+	case inclusionList == nil:
+		return invalidStatus, paramsErr("nil inclusionList")
+	case !api.checkFork(params.Timestamp, forks.Prague, forks.Osaka):
+		return invalidStatus, unsupportedForkErr("newPayloadV5 must only be called for prague payloads")
+	}
+	requests := convertRequests(executionRequests)
+	if err := validateRequests(requests); err != nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(err)
+	}
+	txs, err := decodeInclusionList(inclusionList)
+	if err != nil {
+		return invalidStatus, engine.InvalidParams.With(err)
+	}
+	res, err := api.newPayload(params, versionedHashes, beaconRoot, requests, false)
+	if err != nil || res.Status != engine.VALID || len(txs) == 0 {
+		return res, err
+	}
+	return api.checkInclusionList(params.BlockHash, txs, res), nil
+}
+
+// checkInclusionList verifies whether an executed block satisfies an inclusion
+// list, returning the given valid status if so.
+func (api *ConsensusAPI) checkInclusionList(hash common.Hash, txs types.Transactions, valid engine.PayloadStatusV1) engine.PayloadStatusV1 {
+	block := api.eth.BlockChain().GetBlockByHash(hash)
+	if block == nil {
+		return valid
+	}
+	statedb, err := api.eth.BlockChain().StateAt(block.Root())
+	if err != nil {
+		log.Warn("State not available, skipping inclusion list check", "number", block.NumberU64(), "hash", hash, "err", err)
+		return valid
+	}
+	if err := core.VerifyInclusionList(api.config(), block, statedb, txs); err != nil {
+		log.Warn("Payload does not satisfy inclusion list", "number", block.NumberU64(), "hash", hash, "err", err)
+		return engine.PayloadStatusV1{Status: engine.INCLUSION_LIST_UNSATISFIED}
+	}
+	return valid
+}
+
+// decodeInclusionList decodes the transactions of an inclusion list.
+func decodeInclusionList(list []hexutil.Bytes) (types.Transactions, error) {
+	txs := make(types.Transactions, len(list))
+	for i, enc := range list {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(enc); err != nil {
+			return nil, fmt.Errorf("invalid inclusion list transaction %d: %v", i, err)
+		}
+		txs[i] = tx
+	}
+	return txs, nil
+}
@@ -91,6 +91,24 @@ func (q *payloadQueue) get(id engine.PayloadID, full bool) *engine.ExecutionPayl
 	return nil
 }
 
+// updateInclusionList sets the inclusion list of a previously stored payload,
+// reporting whether the payload exists.
+func (q *payloadQueue) updateInclusionList(id engine.PayloadID, txs types.Transactions) bool {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+
+	for _, item := range q.payloads {
+		if item == nil {
+			return false // no more items
+		}
+		if item.id == id {
+			item.payload.UpdateInclusionList(txs)
+			return true
+		}
+	}
+	return false
+}
+
 // has checks if a particular payload is already tracked.
 func (q *payloadQueue) has(id engine.PayloadID) bool {
 	q.lock.RLock()
@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/miner/builder"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -50,6 +51,7 @@ var FullNodeGPO = gasprice.Config{
 // Defaults contains default settings for use on the Ethereum main net.
 var Defaults = Config{
 	HistoryMode:        history.KeepAll,
+	HistoryRecent:      history.DefaultRecentBlocks,
 	SyncMode:           SnapSync,
 	NetworkId:          0, // enable auto configuration of networkID == chainID
 	TxLookupLimit:      2350000,
@@ -87,6 +89,10 @@ type Config struct {
 	// HistoryMode configures chain history retention.
 	HistoryMode history.HistoryMode
 
+	// HistoryRecent is the number of recent blocks whose bodies and receipts
+	// are retained, only relevant in the "recent" history mode.
+	HistoryRecent uint64 `toml:",omitempty"`
+
 	// This can be set to list of enrtree:// URLs which will be queried for
 	// nodes to connect to.
 	EthDiscoveryURLs  []string
@@ -95,6 +101,7 @@ type Config struct {
 	// State options.
 	NoPruning  bool // Whether to disable pruning and flush everything to disk
 	NoPrefetch bool // Whether to disable prefetching and only load state on demand
+	Parallel   bool // Whether to execute the block transactions optimistically in parallel
 
 	// Deprecated: use 'TransactionHistory' instead.
 	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
@@ -104,6 +111,7 @@ type Config struct {
 	LogNoHistory         bool   `toml:",omitempty"` // No log search index is maintained.
 	LogExportCheckpoints string // export log index checkpoints to file
 	StateHistory         uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
+	WitnessHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose execution witnesses are archived.
 
 	// State scheme represents the scheme used to store ethereum states and trie
 	// nodes on top. It can be 'hash', 'path', or none which means use the scheme
@@ -134,6 +142,9 @@ type Config struct {
 	// Mining options
 	Miner miner.Config
 
+	// Block builder options
+	Builder builder.Config
+
 	// Transaction pool options
 	TxPool   legacypool.Config
 	BlobPool blobpool.Config
@@ -148,6 +159,10 @@ type Config struct {
 	VMTrace           string
 	VMTraceJsonConfig string
 
+	// Enables generating block access lists (EIP-7928) on import and validating
+	// them against the execution
+	BlockAccessList bool
+
 	// RPCGasCap is the global gas cap for eth-call variants.
 	RPCGasCap uint64
 
@@ -158,6 +173,10 @@ type Config struct {
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// PrivateTxBuilders are the RPC endpoints of the block builders to forward
+	// the privately submitted transactions to.
+	PrivateTxBuilders []string `toml:",omitempty"`
+
 	// OverrideOsaka (TODO: remove after the fork)
 	OverrideOsaka *uint64 `toml:",omitempty"`
 
@@ -22,6 +22,7 @@ import (
 	"math"
 	mrand "math/rand"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -90,6 +91,7 @@ var (
 	txBroadcastKnownMeter       = metrics.NewRegisteredMeter("eth/fetcher/transaction/broadcasts/known", nil)
 	txBroadcastUnderpricedMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/broadcasts/underpriced", nil)
 	txBroadcastOtherRejectMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/broadcasts/otherreject", nil)
+	txBroadcastPeerLimitMeter   = metrics.NewRegisteredMeter("eth/fetcher/transaction/broadcasts/peerlimit", nil)
 
 	txRequestOutMeter     = metrics.NewRegisteredMeter("eth/fetcher/transaction/request/out", nil)
 	txRequestFailMeter    = metrics.NewRegisteredMeter("eth/fetcher/transaction/request/fail", nil)
@@ -100,6 +102,7 @@ var (
 	txReplyKnownMeter       = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/known", nil)
 	txReplyUnderpricedMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/underpriced", nil)
 	txReplyOtherRejectMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/otherreject", nil)
+	txReplyPeerLimitMeter   = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/peerlimit", nil)
 
 	txFetcherWaitingPeers   = metrics.NewRegisteredGauge("eth/fetcher/transaction/waiting/peers", nil)
 	txFetcherWaitingHashes  = metrics.NewRegisteredGauge("eth/fetcher/transaction/waiting/hashes", nil)
@@ -199,6 +202,12 @@ type TxFetcher struct {
 	requests   map[string]*txRequest               // In-flight transaction retrievals
 	alternates map[common.Hash]map[string]struct{} // In-flight transaction alternate origins if retrieval fails
 
+	// Admission accounting of the transactions delivered by the peers, limiting
+	// how many of them a single peer can have in the pool at once.
+	peerSlots int                                 // Maximum number of pooled transactions admitted from a peer (0 = unlimited)
+	admitted  map[string]map[common.Hash]struct{} // Transactions admitted into the pool, grouped by origin peer
+	admitLock sync.Mutex                          // Protects the admission accounting, as deliveries are concurrent
+
 	// Callbacks
 	hasTx    func(common.Hash) bool             // Retrieves a tx from the local txpool
 	addTxs   func([]*types.Transaction) []error // Insert a batch of transactions into local txpool
@@ -235,6 +244,7 @@ func NewTxFetcherForTests(
 		fetching:    make(map[common.Hash]string),
 		requests:    make(map[string]*txRequest),
 		alternates:  make(map[common.Hash]map[string]struct{}),
+		admitted:    make(map[string]map[common.Hash]struct{}),
 		underpriced: lru.NewCache[common.Hash, time.Time](maxTxUnderpricedSetSize),
 		hasTx:       hasTx,
 		addTxs:      addTxs,
@@ -246,6 +256,54 @@ func NewTxFetcherForTests(
 	}
 }
 
+// SetPeerSlots limits the number of transactions delivered by a single peer
+// which may reside in the pool at once, zero meaning unlimited. Deliveries over
+// the limit are discarded without being offered to the pool. It must be called
+// before the fetcher is started.
+func (f *TxFetcher) SetPeerSlots(slots uint64) {
+	f.peerSlots = int(min(slots, math.MaxInt32))
+}
+
+// admissions returns the number of transactions the given peer may still have
+// admitted into the pool.
+func (f *TxFetcher) admissions(peer string) int {
+	if f.peerSlots == 0 {
+		return math.MaxInt
+	}
+	f.admitLock.Lock()
+	defer f.admitLock.Unlock()
+
+	admitted := f.admitted[peer]
+	if len(admitted) >= f.peerSlots {
+		// Forget the transactions which left the pool since their admission,
+		// being either included or evicted.
+		for hash := range admitted {
+			if !f.hasTx(hash) {
+				delete(admitted, hash)
+			}
+		}
+	}
+	return f.peerSlots - len(admitted)
+}
+
+// admit records the transactions admitted into the pool from the given peer.
+func (f *TxFetcher) admit(peer string, hashes []common.Hash) {
+	if f.peerSlots == 0 || len(hashes) == 0 {
+		return
+	}
+	f.admitLock.Lock()
+	defer f.admitLock.Unlock()
+
+	admitted := f.admitted[peer]
+	if admitted == nil {
+		admitted = make(map[common.Hash]struct{})
+		f.admitted[peer] = admitted
+	}
+	for _, hash := range hashes {
+		admitted[hash] = struct{}{}
+	}
+}
+
 // Notify announces the fetcher of the potential availability of a new batch of
 // transactions in the network.
 func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []common.Hash) error {
@@ -315,12 +373,14 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 		knownMeter       = txReplyKnownMeter
 		underpricedMeter = txReplyUnderpricedMeter
 		otherRejectMeter = txReplyOtherRejectMeter
+		peerLimitMeter   = txReplyPeerLimitMeter
 	)
 	if !direct {
 		inMeter = txBroadcastInMeter
 		knownMeter = txBroadcastKnownMeter
 		underpricedMeter = txBroadcastUnderpricedMeter
 		otherRejectMeter = txBroadcastOtherRejectMeter
+		peerLimitMeter = txBroadcastPeerLimitMeter
 	}
 	// Keep track of all the propagated transactions
 	inMeter.Mark(int64(len(txs)))
@@ -344,6 +404,16 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 		)
 		batch := txs[i:end]
 
+		// Discard the transactions over the admission allowance of the peer. They
+		// are not marked delivered, so they can still be retrieved from the other
+		// peers announcing them.
+		if allowed := f.admissions(peer); allowed < len(batch) {
+			allowed = max(allowed, 0)
+			peerLimitMeter.Mark(int64(len(batch) - allowed))
+			log.Trace("Peer exceeding transaction admission limit", "peer", peer, "discarded", len(batch)-allowed)
+			batch = batch[:allowed]
+		}
+		admitted := make([]common.Hash, 0, len(batch))
 		for j, err := range f.addTxs(batch) {
 			// Track the transaction hash if the price is too low for us.
 			// Avoid re-request this transaction when we receive another
@@ -353,7 +423,8 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 			}
 			// Track a few interesting failure types
 			switch {
-			case err == nil: // Noop, but need to handle to not count these
+			case err == nil:
+				admitted = append(admitted, batch[j].Hash())
 
 			case errors.Is(err, txpool.ErrAlreadyKnown):
 				duplicate++
@@ -370,6 +441,8 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 				size: uint32(batch[j].Size()),
 			})
 		}
+		f.admit(peer, admitted)
+
 		knownMeter.Mark(duplicate)
 		underpricedMeter.Mark(underpriced)
 		otherRejectMeter.Mark(otherreject)
@@ -391,6 +464,10 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 // Drop should be called when a peer disconnects. It cleans up all the internal
 // data structures of the given node.
 func (f *TxFetcher) Drop(peer string) error {
+	f.admitLock.Lock()
+	delete(f.admitted, peer)
+	f.admitLock.Unlock()
+
 	select {
 	case f.drop <- &txDrop{peer: peer}:
 		return nil
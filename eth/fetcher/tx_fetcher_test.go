@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"math/rand"
 	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -568,6 +569,79 @@ func TestTransactionFetcherFailedRescheduling(t *testing.T) {
 	})
 }
 
+// Tests that the transactions delivered by a peer over its admission limit are
+// not offered to the pool nor marked delivered, and that the allowance is restored once admitted
+// transactions leave the pool.
+func TestTransactionFetcherPeerSlots(t *testing.T) {
+	var (
+		lock sync.Mutex
+		pool = make(map[common.Hash]struct{})
+	)
+	pooled := func(want ...bool) doFunc {
+		return func() {
+			lock.Lock()
+			defer lock.Unlock()
+
+			for i, tx := range testTxs {
+				if _, ok := pool[tx.Hash()]; ok != want[i] {
+					t.Errorf("transaction %d: pooled mismatch: have %v, want %v", i, ok, want[i])
+				}
+			}
+		}
+	}
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			f := NewTxFetcher(
+				func(hash common.Hash) bool {
+					lock.Lock()
+					defer lock.Unlock()
+
+					_, ok := pool[hash]
+					return ok
+				},
+				func(txs []*types.Transaction) []error {
+					lock.Lock()
+					defer lock.Unlock()
+
+					for _, tx := range txs {
+						pool[tx.Hash()] = struct{}{}
+					}
+					return make([]error, len(txs))
+				},
+				func(string, []common.Hash) error { return nil },
+				nil,
+			)
+			f.SetPeerSlots(2)
+			return f
+		},
+		steps: []interface{}{
+			// Deliver more transactions than allowed, the excess is discarded but
+			// the announcements of other peers are retained
+			doTxNotify{peer: "B", hashes: []common.Hash{testTxs[2].Hash()}, types: []byte{testTxs[2].Type()}, sizes: []uint32{uint32(testTxs[2].Size())}},
+			doTxEnqueue{peer: "A", txs: testTxs[:3], direct: false},
+			pooled(true, true, false, false),
+			isWaiting(map[string][]announce{
+				"B": {{testTxs[2].Hash(), testTxs[2].Type(), uint32(testTxs[2].Size())}},
+			}),
+
+			// Other peers have their own allowance
+			doTxEnqueue{peer: "B", txs: testTxs[2:3], direct: true},
+			pooled(true, true, true, false),
+
+			// Once an admitted transaction leaves the pool, the allowance is restored
+			doTxEnqueue{peer: "A", txs: testTxs[3:], direct: false},
+			pooled(true, true, true, false),
+			doFunc(func() {
+				lock.Lock()
+				delete(pool, testTxs[0].Hash())
+				lock.Unlock()
+			}),
+			doTxEnqueue{peer: "A", txs: testTxs[3:], direct: false},
+			pooled(false, true, true, true),
+		},
+	})
+}
+
 // Tests that if a transaction retrieval succeeds, all alternate origins
 // are cleaned up.
 func TestTransactionFetcherCleanup(t *testing.T) {
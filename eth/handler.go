@@ -91,6 +91,10 @@ type txPool interface {
 	// can decide whether to receive notifications only for newly seen transactions
 	// or also for reorged out ones.
 	SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription
+
+	// IsPrivate returns whether the transaction with the given hash is held
+	// privately by the node, and must not be propagated to the peers.
+	IsPrivate(hash common.Hash) bool
 }
 
 // handlerConfig is the collection of initialization parameters to create a full
@@ -105,6 +109,7 @@ type handlerConfig struct {
 	BloomCache     uint64                 // Megabytes to alloc for snap sync bloom
 	EventMux       *event.TypeMux         // Legacy event mux, deprecate for `feed`
 	RequiredBlocks map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
+	TxPeerSlots    uint64                 // Maximum number of pooled transactions admitted from a single peer (0 = unlimited)
 }
 
 type handler struct {
@@ -204,6 +209,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		return h.txpool.Add(txs, false)
 	}
 	h.txFetcher = fetcher.NewTxFetcher(h.txpool.Has, addTxs, fetchTx, h.removePeer)
+	h.txFetcher.SetPeerSlots(config.TxPeerSlots)
 	return h, nil
 }
 
@@ -470,8 +476,9 @@ func (h *handler) Stop() {
 // already have the given transaction.
 func (h *handler) BroadcastTransactions(txs types.Transactions) {
 	var (
-		blobTxs  int // Number of blob transactions to announce only
-		largeTxs int // Number of large transactions to announce only
+		blobTxs    int // Number of blob transactions to announce only
+		largeTxs   int // Number of large transactions to announce only
+		privateTxs int // Number of private transactions not to propagate
 
 		directCount int // Number of transactions sent directly to peers (duplicates included)
 		annCount    int // Number of transactions announced across all peers (duplicates included)
@@ -492,6 +499,11 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		hash   = make([]byte, 32)
 	)
 	for _, tx := range txs {
+		// Never propagate the transactions held privately by the node
+		if h.txpool.IsPrivate(tx.Hash()) {
+			privateTxs++
+			continue
+		}
 		var maybeDirect bool
 		switch {
 		case tx.Type() == types.BlobTxType:
@@ -538,8 +550,8 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		annCount += len(hashes)
 		peer.AsyncSendPooledTransactionHashes(hashes)
 	}
-	log.Debug("Distributed transactions", "plaintxs", len(txs)-blobTxs-largeTxs, "blobtxs", blobTxs, "largetxs", largeTxs,
-		"bcastpeers", len(txset), "bcastcount", directCount, "annpeers", len(annos), "anncount", annCount)
+	log.Debug("Distributed transactions", "plaintxs", len(txs)-blobTxs-largeTxs-privateTxs, "blobtxs", blobTxs, "largetxs", largeTxs,
+		"privatetxs", privateTxs, "bcastpeers", len(txset), "bcastcount", directCount, "annpeers", len(annos), "anncount", annCount)
 }
 
 // txBroadcastLoop announces new transactions to connected peers.
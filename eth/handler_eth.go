@@ -20,7 +20,9 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -31,7 +33,39 @@ import (
 type ethHandler handler
 
 func (h *ethHandler) Chain() *core.BlockChain { return h.chain }
-func (h *ethHandler) TxPool() eth.TxPool      { return h.txpool }
+func (h *ethHandler) TxPool() eth.TxPool      { return publicTxPool{h.txpool} }
+
+// publicTxPool hides the transactions held privately by the node from the peers.
+type publicTxPool struct {
+	txPool
+}
+
+// Get retrieves the transaction from the local txpool with the given hash,
+// unless it's private.
+func (p publicTxPool) Get(hash common.Hash) *types.Transaction {
+	if p.IsPrivate(hash) {
+		return nil
+	}
+	return p.txPool.Get(hash)
+}
+
+// GetRLP retrieves the RLP-encoded transaction from the local txpool with the
+// given hash, unless it's private.
+func (p publicTxPool) GetRLP(hash common.Hash) []byte {
+	if p.IsPrivate(hash) {
+		return nil
+	}
+	return p.txPool.GetRLP(hash)
+}
+
+// GetMetadata returns the transaction type and transaction size with the given
+// transaction hash, unless it's private.
+func (p publicTxPool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
+	if p.IsPrivate(hash) {
+		return nil
+	}
+	return p.txPool.GetMetadata(hash)
+}
 
 // RunPeer is invoked when a peer joins on the `eth` protocol.
 func (h *ethHandler) RunPeer(peer *eth.Peer, hand eth.Handler) error {
@@ -424,3 +424,87 @@ func testTransactionPropagation(t *testing.T, protocol uint) {
 		}
 	}
 }
+
+// Tests that privately held transactions are neither announced to newly joined
+// peers, nor broadcast to the existing ones.
+func TestPrivateTransactionPropagation68(t *testing.T) {
+	testPrivateTransactionPropagation(t, eth.ETH68)
+}
+
+func testPrivateTransactionPropagation(t *testing.T, protocol uint) {
+	t.Parallel()
+
+	source := newTestHandler()
+	source.handler.snapSync.Store(false) // Avoid requiring snap, otherwise some will be dropped below
+	defer source.close()
+
+	txs := make([]*types.Transaction, 4)
+	for nonce := range txs {
+		tx := types.NewTransaction(uint64(nonce), common.Address{}, big.NewInt(0), 100000, big.NewInt(0), nil)
+		tx, _ = types.SignTx(tx, types.HomesteadSigner{}, testKey)
+		txs[nonce] = tx
+	}
+	// Pool a private and a public transaction before the peers join
+	source.txpool.addPrivate(txs[0])
+	go source.txpool.Add(txs[1:2], false) // Need goroutine to not block on feed
+	time.Sleep(250 * time.Millisecond)    // Wait until tx events get out of the system
+
+	sinks := make([]*testHandler, 3)
+	for i := 0; i < len(sinks); i++ {
+		sinks[i] = newTestHandler()
+		defer sinks[i].close()
+
+		sinks[i].handler.synced.Store(true) // mark synced to accept transactions
+	}
+	for i, sink := range sinks {
+		sourcePipe, sinkPipe := p2p.MsgPipe()
+		defer sourcePipe.Close()
+		defer sinkPipe.Close()
+
+		sourcePeer := eth.NewPeer(protocol, p2p.NewPeerPipe(enode.ID{byte(i + 1)}, "", nil, sourcePipe), sourcePipe, source.txpool)
+		sinkPeer := eth.NewPeer(protocol, p2p.NewPeerPipe(enode.ID{0}, "", nil, sinkPipe), sinkPipe, sink.txpool)
+		defer sourcePeer.Close()
+		defer sinkPeer.Close()
+
+		go source.handler.runEthPeer(sourcePeer, func(peer *eth.Peer) error {
+			return eth.Handle((*ethHandler)(source.handler), peer)
+		})
+		go sink.handler.runEthPeer(sinkPeer, func(peer *eth.Peer) error {
+			return eth.Handle((*ethHandler)(sink.handler), peer)
+		})
+	}
+	txChs := make([]chan core.NewTxsEvent, len(sinks))
+	for i := 0; i < len(sinks); i++ {
+		txChs[i] = make(chan core.NewTxsEvent, 1024)
+
+		sub := sinks[i].txpool.SubscribeTransactions(txChs[i], false)
+		defer sub.Unsubscribe()
+	}
+	// Pool another private and public transaction with the peers connected
+	source.txpool.addPrivate(txs[2])
+	source.txpool.Add(txs[3:], false)
+
+	// Wait for the public transactions at the sinks and ensure no private ones
+	// were propagated in the meantime
+	for i := range sinks {
+		for arrived, timeout := 0, false; arrived < 2 && !timeout; {
+			select {
+			case event := <-txChs[i]:
+				for _, tx := range event.Txs {
+					if tx.Hash() == txs[0].Hash() || tx.Hash() == txs[2].Hash() {
+						t.Errorf("sink %d: private transaction %x propagated", i, tx.Hash())
+					}
+				}
+				arrived += len(event.Txs)
+			case <-time.After(2 * time.Second):
+				t.Errorf("sink %d: transaction propagation timed out: have %d, want 2", i, arrived)
+				timeout = true
+			}
+		}
+		for _, tx := range []*types.Transaction{txs[0], txs[2]} {
+			if sinks[i].txpool.Has(tx.Hash()) {
+				t.Errorf("sink %d: private transaction %x pooled", i, tx.Hash())
+			}
+		}
+	}
+}
@@ -48,7 +48,8 @@ var (
 // Its goal is to get around setting up a valid statedb for the balance and nonce
 // checks.
 type testTxPool struct {
-	pool map[common.Hash]*types.Transaction // Hash map of collected transactions
+	pool    map[common.Hash]*types.Transaction // Hash map of collected transactions
+	private map[common.Hash]bool               // Set of transactions held privately
 
 	txFeed event.Feed   // Notification feed to allow waiting for inclusion
 	lock   sync.RWMutex // Protects the transaction pool
@@ -57,10 +58,29 @@ type testTxPool struct {
 // newTestTxPool creates a mock transaction pool.
 func newTestTxPool() *testTxPool {
 	return &testTxPool{
-		pool: make(map[common.Hash]*types.Transaction),
+		pool:    make(map[common.Hash]*types.Transaction),
+		private: make(map[common.Hash]bool),
 	}
 }
 
+// addPrivate appends a transaction to the pool which must not be propagated.
+func (p *testTxPool) addPrivate(tx *types.Transaction) {
+	p.lock.Lock()
+	p.private[tx.Hash()] = true
+	p.lock.Unlock()
+
+	p.Add([]*types.Transaction{tx}, false)
+}
+
+// IsPrivate returns whether the transaction with the given hash is held
+// privately.
+func (p *testTxPool) IsPrivate(hash common.Hash) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.private[hash]
+}
+
 // Has returns an indicator whether txpool has a transaction
 // cached with the given hash.
 func (p *testTxPool) Has(hash common.Hash) bool {
@@ -1048,15 +1048,29 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 	return tracer.GetResult()
 }
 
-// APIs return the collection of RPC services the tracer package offers.
-func APIs(backend Backend) []rpc.API {
+// APIs return the collection of RPC services the tracer package offers. The
+// archived traces are served if the live tracer in use maintains an archive,
+// nil otherwise.
+func APIs(backend Backend, archive TraceArchive) []rpc.API {
 	// Append all the local APIs and return
-	return []rpc.API{
+	apis := []rpc.API{
 		{
 			Namespace: "debug",
 			Service:   NewAPI(backend),
 		},
+		{
+			Namespace: "trace",
+			Service:   NewTraceAPI(backend),
+		},
+	}
+	// Serve the archived traces if the live tracer in use maintains them
+	if archive != nil {
+		apis = append(apis, rpc.API{
+			Namespace: "archive",
+			Service:   NewArchiveAPI(backend, archive),
+		})
 	}
+	return apis
 }
 
 // overrideConfig returns a copy of original with forks enabled by override enabled,
@@ -0,0 +1,212 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// The tracers whose results are archived by the live trace archive.
+const (
+	archiveCallTracer     = "callTracer"
+	archivePrestateTracer = "prestateTracer"
+)
+
+// TraceArchive is implemented by the live tracers archiving the traces of the
+// imported blocks, allowing them to be served without re-executing the blocks.
+type TraceArchive interface {
+	// ReadTraces retrieves the archived traces of the block with the given
+	// number and hash, or nil if they are not available.
+	ReadTraces(number uint64, hash common.Hash) *BlockTraces
+}
+
+// BlockTraces is the archived traces of a block.
+type BlockTraces struct {
+	Number       hexutil.Uint64 `json:"number"`
+	Hash         common.Hash    `json:"hash"`
+	Transactions []*TxTraces    `json:"transactions"`
+
+	// StateDiff is the state modifications made by the entire block, including
+	// the system calls, withdrawals and rewards, in the same format as the
+	// prestateTracer in diff mode.
+	StateDiff json.RawMessage `json:"stateDiff"`
+}
+
+// TxTraces is the archived traces of a transaction.
+type TxTraces struct {
+	TxHash    common.Hash     `json:"txHash"`
+	Calls     json.RawMessage `json:"calls,omitempty"`     // Result of the callTracer
+	StateDiff json.RawMessage `json:"stateDiff,omitempty"` // Result of the prestateTracer in diff mode
+	Error     string          `json:"error,omitempty"`     // Failure of the tracers
+}
+
+// ArchiveTraceConfig selects the archived trace results to retrieve.
+type ArchiveTraceConfig struct {
+	// Tracer is either "callTracer" (default) or "prestateTracer", the latter
+	// is always archived in diff mode.
+	Tracer *string
+}
+
+// ArchiveAPI is the collection of APIs serving the block traces from the live
+// trace archive.
+type ArchiveAPI struct {
+	backend Backend
+	archive TraceArchive
+}
+
+// NewArchiveAPI creates a new API definition for serving the archived traces.
+func NewArchiveAPI(backend Backend, archive TraceArchive) *ArchiveAPI {
+	return &ArchiveAPI{backend: backend, archive: archive}
+}
+
+// traces retrieves the archived traces of the given block.
+func (api *ArchiveAPI) traces(number uint64, hash common.Hash) (*BlockTraces, error) {
+	traces := api.archive.ReadTraces(number, hash)
+	if traces == nil {
+		return nil, fmt.Errorf("traces of block #%d (%x) not archived", number, hash)
+	}
+	return traces, nil
+}
+
+// txResult selects the trace result of the transaction from the given config.
+func txResult(traces *TxTraces, config *ArchiveTraceConfig) (json.RawMessage, error) {
+	tracer := archiveCallTracer
+	if config != nil && config.Tracer != nil {
+		tracer = *config.Tracer
+	}
+	switch tracer {
+	case archiveCallTracer:
+		return traces.Calls, nil
+	case archivePrestateTracer:
+		return traces.StateDiff, nil
+	default:
+		return nil, fmt.Errorf("tracer %q not archived", tracer)
+	}
+}
+
+// TraceBlockByNumber returns the archived traces of all the transactions in the
+// block with the given number, in the same format as debug_traceBlockByNumber.
+func (api *ArchiveAPI) TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber, config *ArchiveTraceConfig) ([]*txTraceResult, error) {
+	header, err := api.backend.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	return api.traceBlock(header.Number.Uint64(), header.Hash(), config)
+}
+
+// TraceBlockByHash returns the archived traces of all the transactions in the
+// block with the given hash, in the same format as debug_traceBlockByHash.
+func (api *ArchiveAPI) TraceBlockByHash(ctx context.Context, hash common.Hash, config *ArchiveTraceConfig) ([]*txTraceResult, error) {
+	header, err := api.backend.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %s not found", hash.Hex())
+	}
+	return api.traceBlock(header.Number.Uint64(), hash, config)
+}
+
+func (api *ArchiveAPI) traceBlock(number uint64, hash common.Hash, config *ArchiveTraceConfig) ([]*txTraceResult, error) {
+	traces, err := api.traces(number, hash)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*txTraceResult, len(traces.Transactions))
+	for i, tx := range traces.Transactions {
+		result, err := txResult(tx, config)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = &txTraceResult{TxHash: tx.TxHash, Error: tx.Error}
+		if result != nil {
+			results[i].Result = result
+		}
+	}
+	return results, nil
+}
+
+// TraceTransaction returns the archived trace of the given transaction, in the
+// same format as debug_traceTransaction.
+func (api *ArchiveAPI) TraceTransaction(ctx context.Context, hash common.Hash, config *ArchiveTraceConfig) (interface{}, error) {
+	found, _, blockHash, blockNumber, index := api.backend.GetTransaction(hash)
+	if !found {
+		// Warn in case tx indexer is not done.
+		if !api.backend.TxIndexDone() {
+			return nil, ethapi.NewTxIndexingError()
+		}
+		return nil, errTxNotFound
+	}
+	traces, err := api.traces(blockNumber, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if index >= uint64(len(traces.Transactions)) || traces.Transactions[index].TxHash != hash {
+		return nil, errors.New("archived traces are inconsistent with the chain")
+	}
+	tx := traces.Transactions[index]
+	if tx.Error != "" {
+		return nil, errors.New(tx.Error)
+	}
+	return txResult(tx, config)
+}
+
+// StateDiff returns the state modifications made by the given block, including
+// the system calls, withdrawals and rewards, in the same format as the
+// prestateTracer in diff mode.
+func (api *ArchiveAPI) StateDiff(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (json.RawMessage, error) {
+	var (
+		number uint64
+		hash   common.Hash
+	)
+	if h, ok := blockNrOrHash.Hash(); ok {
+		header, err := api.backend.HeaderByHash(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("block %s not found", h.Hex())
+		}
+		number, hash = header.Number.Uint64(), h
+	} else {
+		n, _ := blockNrOrHash.Number()
+		header, err := api.backend.HeaderByNumber(ctx, n)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("block #%d not found", n)
+		}
+		number, hash = header.Number.Uint64(), header.Hash()
+	}
+	traces, err := api.traces(number, hash)
+	if err != nil {
+		return nil, err
+	}
+	return traces.StateDiff, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the archive tracer stores the call traces and state diffs of the
+// imported blocks, and retains only the configured number of recent blocks.
+func TestArchiveTracer(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender   = crypto.PubkeyToAddress(key.PublicKey)
+		receiver = common.Address{0xee}
+		gspec    = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		engine = beacon.New(ethash.NewFaker())
+	)
+	tracer, archive, err := tracers.LiveDirectory.NewWithArchive("archive", json.RawMessage(fmt.Sprintf(`{"path":%q,"limit":2}`, t.TempDir())))
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+	if archive == nil {
+		t.Fatal("Trace archive not returned")
+	}
+
+	options := core.DefaultConfig()
+	options.VmConfig = vm.Config{Tracer: tracer}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, options)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 3, func(i int, b *core.BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, b.Signer(), &types.LegacyTx{
+			Nonce:    b.TxNonce(sender),
+			To:       &receiver,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}))
+	})
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert chain: %v", err)
+	}
+	if archive.ReadTraces(blocks[0].NumberU64(), blocks[0].Hash()) != nil {
+		t.Fatal("Traces out of the retention window available")
+	}
+	for _, block := range blocks[1:] {
+		traces := archive.ReadTraces(block.NumberU64(), block.Hash())
+		if traces == nil {
+			t.Fatalf("Block %d: traces not archived", block.NumberU64())
+		}
+		if len(traces.Transactions) != 1 {
+			t.Fatalf("Block %d: unexpected transaction count %d", block.NumberU64(), len(traces.Transactions))
+		}
+		tx := traces.Transactions[0]
+		if tx.TxHash != block.Transactions()[0].Hash() || tx.Error != "" {
+			t.Fatalf("Block %d: unexpected transaction traces: %+v", block.NumberU64(), tx)
+		}
+		var call struct {
+			Type  string         `json:"type"`
+			From  common.Address `json:"from"`
+			To    common.Address `json:"to"`
+			Value *hexutil.Big   `json:"value"`
+		}
+		if err := json.Unmarshal(tx.Calls, &call); err != nil {
+			t.Fatalf("Block %d: failed to decode call trace: %v", block.NumberU64(), err)
+		}
+		if call.Type != "CALL" || call.From != sender || call.To != receiver || call.Value.ToInt().Int64() != 1000 {
+			t.Fatalf("Block %d: unexpected call trace: %s", block.NumberU64(), tx.Calls)
+		}
+		// Check the block level diff of the receiver balance
+		var diff struct {
+			Pre  map[common.Address]struct{ Balance *hexutil.Big } `json:"pre"`
+			Post map[common.Address]struct{ Balance *hexutil.Big } `json:"post"`
+		}
+		if err := json.Unmarshal(traces.StateDiff, &diff); err != nil {
+			t.Fatalf("Block %d: failed to decode state diff: %v", block.NumberU64(), err)
+		}
+		var (
+			n    = int64(block.NumberU64())
+			pre  = diff.Pre[receiver].Balance
+			post = diff.Post[receiver].Balance
+		)
+		if pre == nil || post == nil || pre.ToInt().Int64() != (n-1)*1000 || post.ToInt().Int64() != n*1000 {
+			t.Fatalf("Block %d: unexpected state diff: %s", block.NumberU64(), traces.StateDiff)
+		}
+	}
+	// Ensure the traces of an unknown block are not served
+	if archive.ReadTraces(blocks[2].NumberU64(), common.Hash{0x1}) != nil {
+		t.Fatal("Traces served for mismatching block hash")
+	}
+	// Import a side chain block below the head, ensure the traces of the
+	// canonical blocks are retained
+	_, sides, _ := core.GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *core.BlockGen) {
+		b.SetExtra([]byte("side"))
+	})
+	if _, err := chain.InsertChain(sides); err != nil {
+		t.Fatalf("Failed to insert side chain: %v", err)
+	}
+	for _, block := range append(blocks[1:], sides[1]) {
+		if archive.ReadTraces(block.NumberU64(), block.Hash()) == nil {
+			t.Fatalf("Block %d (%x): traces not available", block.NumberU64(), block.Hash())
+		}
+	}
+}
+
+// Tests that the archive tracer handles transactions failing validation, which
+// are reported without a receipt.
+func TestArchiveTracerInvalidTx(t *testing.T) {
+	hooks, archive, err := tracers.LiveDirectory.NewWithArchive("archive", json.RawMessage(fmt.Sprintf(`{"path":%q}`, t.TempDir())))
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+	defer hooks.OnClose()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		signer = types.LatestSigner(params.MergedTestChainConfig)
+		tx     = types.MustSignNewTx(key, signer, &types.LegacyTx{To: &common.Address{0xee}, Gas: params.TxGas})
+		block  = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+		db, _  = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	)
+	hooks.OnBlockchainInit(params.MergedTestChainConfig)
+	hooks.OnBlockStart(tracing.BlockEvent{Block: block})
+	hooks.OnTxStart(&tracing.VMContext{BlockNumber: block.Number(), StateDB: db}, tx, crypto.PubkeyToAddress(key.PublicKey))
+	hooks.OnTxEnd(nil, core.ErrNonceTooHigh)
+	hooks.OnBlockEnd(nil)
+
+	traces := archive.ReadTraces(block.NumberU64(), block.Hash())
+	if traces == nil || len(traces.Transactions) != 1 {
+		t.Fatalf("Unexpected traces: %+v", traces)
+	}
+	if traced := traces.Transactions[0]; traced.TxHash != tx.Hash() || traced.Error != core.ErrNonceTooHigh.Error() {
+		t.Fatalf("Unexpected transaction traces: %+v", traced)
+	}
+}
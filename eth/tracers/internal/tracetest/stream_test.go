@@ -0,0 +1,217 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracetest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/live"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the stream tracer delivers the hook invocations of the block import
+// to an out-of-process tracer listening on a local socket.
+func TestStreamTracer(t *testing.T) {
+	dir, err := os.MkdirTemp("", "stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "tracer.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("Unix sockets not supported: %v", err)
+	}
+	defer listener.Close()
+
+	events := make(chan []live.StreamEvent, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(events)
+			return
+		}
+		defer conn.Close()
+
+		var received []live.StreamEvent
+		scanner := bufio.NewScanner(conn)
+		scanner.Buffer(nil, 16*1024*1024)
+		for scanner.Scan() {
+			var event live.StreamEvent
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				break
+			}
+			received = append(received, event)
+			if event.Type == live.StreamEventClose {
+				break
+			}
+		}
+		events <- received
+	}()
+
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender   = crypto.PubkeyToAddress(key.PublicKey)
+		receiver = common.Address{0xee}
+		gspec    = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		engine = beacon.New(ethash.NewFaker())
+	)
+	tracer, err := tracers.LiveDirectory.New("stream", json.RawMessage(fmt.Sprintf(`{"path":%q}`, socket)))
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+	options := core.DefaultConfig()
+	options.VmConfig = vm.Config{Tracer: tracer}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, options)
+	if err != nil {
+		t.Fatalf("Failed to create chain: %v", err)
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 1, func(i int, b *core.BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, b.Signer(), &types.LegacyTx{
+			Nonce:    b.TxNonce(sender),
+			To:       &receiver,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}))
+	})
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("Failed to insert chain: %v", err)
+	}
+	chain.Stop()
+
+	// Check the sequence of the chain and transaction events, the call frames
+	// are also emitted for the system calls so they are checked separately.
+	received := <-events
+	want := []string{
+		live.StreamEventHello, live.StreamEventBlockchainInit, live.StreamEventGenesisBlock,
+		live.StreamEventBlockStart, live.StreamEventTxStart, live.StreamEventTxEnd,
+		live.StreamEventBlockEnd, live.StreamEventClose,
+	}
+	var sequence []string
+	for _, event := range received {
+		if slices.Contains(want, event.Type) {
+			sequence = append(sequence, event.Type)
+		}
+	}
+	if !reflect.DeepEqual(sequence, want) {
+		t.Fatalf("Unexpected event sequence: have %v, want %v", sequence, want)
+	}
+	// Check the content of the events
+	var transfer bool
+	for _, event := range received {
+		switch event.Type {
+		case live.StreamEventBlockStart:
+			var data live.StreamBlockEvent
+			if err := json.Unmarshal(event.Data, &data); err != nil {
+				t.Fatalf("Failed to decode block event: %v", err)
+			}
+			if data.Header.Hash() != blocks[0].Hash() || len(data.TxHashes) != 1 || data.TxHashes[0] != blocks[0].Transactions()[0].Hash() {
+				t.Fatalf("Unexpected block event: %+v", data)
+			}
+		case live.StreamEventEnter:
+			var data live.StreamEnter
+			if err := json.Unmarshal(event.Data, &data); err != nil {
+				t.Fatalf("Failed to decode enter event: %v", err)
+			}
+			if data.From == sender {
+				if data.To != receiver || data.Value.ToInt().Int64() != 1000 {
+					t.Fatalf("Unexpected enter event: %+v", data)
+				}
+				transfer = true
+			}
+		}
+	}
+	if !transfer {
+		t.Fatal("Transfer call frame not streamed")
+	}
+}
+
+// Tests that an out-of-process tracer not reading the events doesn't stall the
+// block processing, but gets dropped.
+func TestStreamTracerStalled(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "tracer.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("Unix sockets not supported: %v", err)
+	}
+	defer listener.Close()
+
+	// Accept the connections without ever reading from them
+	conns := make(chan net.Conn, 1024)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				close(conns)
+				return
+			}
+			conns <- conn
+		}
+	}()
+	hooks, err := tracers.LiveDirectory.New("stream", json.RawMessage(fmt.Sprintf(`{"path":%q}`, socket)))
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), Extra: make([]byte, 32*1024)})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			hooks.OnBlockStart(tracing.BlockEvent{Block: block})
+			hooks.OnBlockEnd(nil)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Block processing stalled by the tracer")
+	}
+	// Release the writers blocked on the connections and shut down
+	listener.Close()
+	for conn := range conns {
+		conn.Close()
+	}
+	hooks.OnClose()
+}
@@ -19,32 +19,59 @@ package tracers
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/core/tracing"
 )
 
 type ctorFunc func(config json.RawMessage) (*tracing.Hooks, error)
 
+// archiveCtorFunc is the constructor of a tracer archiving the traces of the
+// imported blocks, which returns the archive along with the hooks.
+type archiveCtorFunc func(config json.RawMessage) (*tracing.Hooks, TraceArchive, error)
+
 // LiveDirectory is the collection of tracers which can be used
 // during normal block import operations.
-var LiveDirectory = liveDirectory{elems: make(map[string]ctorFunc)}
+var LiveDirectory = liveDirectory{elems: make(map[string]archiveCtorFunc)}
 
 type liveDirectory struct {
-	elems map[string]ctorFunc
+	elems map[string]archiveCtorFunc
 }
 
 // Register registers a tracer constructor by name.
 func (d *liveDirectory) Register(name string, f ctorFunc) {
+	d.elems[name] = func(config json.RawMessage) (*tracing.Hooks, TraceArchive, error) {
+		hooks, err := f(config)
+		return hooks, nil, err
+	}
+}
+
+// RegisterArchive registers the constructor of a tracer archiving the traces
+// of the imported blocks by name.
+func (d *liveDirectory) RegisterArchive(name string, f archiveCtorFunc) {
 	d.elems[name] = f
 }
 
-// New instantiates a tracer by name.
+// New instantiates a tracer by name. If no tracer is registered with the name
+// and it refers to a Go plugin (*.so), the tracer is loaded from the plugin.
 func (d *liveDirectory) New(name string, config json.RawMessage) (*tracing.Hooks, error) {
+	hooks, _, err := d.NewWithArchive(name, config)
+	return hooks, err
+}
+
+// NewWithArchive instantiates a tracer by name like New, additionally returning
+// the trace archive maintained by the tracer, or nil if it doesn't archive the
+// traces.
+func (d *liveDirectory) NewWithArchive(name string, config json.RawMessage) (*tracing.Hooks, TraceArchive, error) {
 	if len(config) == 0 {
 		config = json.RawMessage("{}")
 	}
 	if f, ok := d.elems[name]; ok {
 		return f(config)
 	}
-	return nil, errors.New("not found")
+	if strings.HasSuffix(name, ".so") {
+		hooks, err := loadPlugin(name, config)
+		return hooks, nil, err
+	}
+	return nil, nil, errors.New("not found")
 }
@@ -0,0 +1,394 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	// Force-load the native tracers, the archive is built on top of them
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+func init() {
+	tracers.LiveDirectory.RegisterArchive("archive", newArchiveTracer)
+}
+
+type archiveTracerConfig struct {
+	Path  string `json:"path"`  // Path to the directory where the traces will be stored
+	Limit uint64 `json:"limit"` // Number of recent blocks whose traces are retained, zero means all
+}
+
+// archiveAccount is the state of an account modified within a block, in the
+// same format as the prestateTracer in diff mode.
+type archiveAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// archiveStateDiff is the state modifications made by a block.
+type archiveStateDiff struct {
+	Pre  map[common.Address]*archiveAccount `json:"pre"`
+	Post map[common.Address]*archiveAccount `json:"post"`
+}
+
+// accountChange tracks the first and the last value of the account fields
+// modified within a block.
+type accountChange struct {
+	prevBalance, balance *big.Int
+	prevNonce, nonce     *uint64
+	prevCode, code       []byte
+	codeChanged          bool
+	prevStorage, storage map[common.Hash]common.Hash
+}
+
+// archiveTracer is a live tracer which archives the call traces and the state
+// modifications of every transaction, as well as the state modifications of
+// every block, into a freezer. The transaction traces are produced by the
+// callTracer and the prestateTracer in diff mode, so the archived traces can be
+// served in the same format as the debug_trace* methods, without re-executing
+// the blocks. The traces are kept in a rawdb.BlockArchive, so the traces of the
+// side chain blocks don't replace the archived ones unless a reorg happens.
+type archiveTracer struct {
+	config      archiveTracerConfig
+	chainConfig *params.ChainConfig
+	archive     *rawdb.BlockArchive
+
+	// Traces of the block being processed
+	block   *tracers.BlockTraces
+	parent  common.Hash
+	changes map[common.Address]*accountChange
+
+	// Tracers of the transaction being processed
+	txTracers []*tracers.Tracer
+	txHash    common.Hash
+	txIndex   int
+}
+
+func newArchiveTracer(cfg json.RawMessage) (*tracing.Hooks, tracers.TraceArchive, error) {
+	var config archiveTracerConfig
+	if err := json.Unmarshal(cfg, &config); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	if config.Path == "" {
+		return nil, nil, errors.New("archive tracer output path is required")
+	}
+	freezer, err := rawdb.NewTraceFreezer(config.Path, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	archive, err := rawdb.NewTraceArchive(freezer, config.Limit)
+	if err != nil {
+		freezer.Close()
+		return nil, nil, err
+	}
+	t := &archiveTracer{
+		config:  config,
+		archive: archive,
+	}
+	return &tracing.Hooks{
+		OnBlockchainInit: t.onBlockchainInit,
+		OnClose:          t.onClose,
+		OnBlockStart:     t.onBlockStart,
+		OnBlockEnd:       t.onBlockEnd,
+		OnTxStart:        t.onTxStart,
+		OnTxEnd:          t.onTxEnd,
+		OnEnter:          t.onEnter,
+		OnExit:           t.onExit,
+		OnOpcode:         t.onOpcode,
+		OnFault:          t.onFault,
+		OnLog:            t.onLog,
+		OnBalanceChange:  t.onBalanceChange,
+		OnNonceChange:    t.onNonceChange,
+		OnCodeChange:     t.onCodeChange,
+		OnStorageChange:  t.onStorageChange,
+	}, t, nil
+}
+
+func (t *archiveTracer) onBlockchainInit(chainConfig *params.ChainConfig) {
+	t.chainConfig = chainConfig
+}
+
+func (t *archiveTracer) onClose() {
+	if err := t.archive.Close(); err != nil {
+		log.Error("Failed to close trace archive", "err", err)
+	}
+}
+
+func (t *archiveTracer) onBlockStart(event tracing.BlockEvent) {
+	t.block = &tracers.BlockTraces{
+		Number:       hexutil.Uint64(event.Block.NumberU64()),
+		Hash:         event.Block.Hash(),
+		Transactions: make([]*tracers.TxTraces, 0, len(event.Block.Transactions())),
+	}
+	t.parent = event.Block.ParentHash()
+	t.changes = make(map[common.Address]*accountChange)
+	t.txIndex = 0
+}
+
+func (t *archiveTracer) onBlockEnd(err error) {
+	block, changes := t.block, t.changes
+	t.block, t.changes, t.txTracers = nil, nil, nil
+	if err != nil || block == nil {
+		return
+	}
+	if err := t.write(block, t.parent, changes); err != nil {
+		log.Error("Failed to archive block traces", "number", block.Number, "hash", block.Hash, "err", err)
+	}
+}
+
+func (t *archiveTracer) onTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	if t.block == nil {
+		return
+	}
+	ctx := &tracers.Context{
+		BlockHash:   t.block.Hash,
+		BlockNumber: env.BlockNumber,
+		TxIndex:     t.txIndex,
+		TxHash:      tx.Hash(),
+	}
+	t.txHash = tx.Hash()
+	t.txIndex++
+
+	calls, err := tracers.DefaultDirectory.New("callTracer", ctx, json.RawMessage(`{"withLog":true}`), t.chainConfig)
+	if err != nil {
+		log.Error("Failed to create call tracer", "err", err)
+		return
+	}
+	prestate, err := tracers.DefaultDirectory.New("prestateTracer", ctx, json.RawMessage(`{"diffMode":true}`), t.chainConfig)
+	if err != nil {
+		log.Error("Failed to create prestate tracer", "err", err)
+		return
+	}
+	t.txTracers = []*tracers.Tracer{calls, prestate}
+	for _, tracer := range t.txTracers {
+		if tracer.OnTxStart != nil {
+			tracer.OnTxStart(env, tx, from)
+		}
+	}
+}
+
+func (t *archiveTracer) onTxEnd(receipt *types.Receipt, err error) {
+	txTracers := t.txTracers
+	if txTracers == nil {
+		return
+	}
+	t.txTracers = nil
+
+	// The transaction failed validation, there is no receipt nor any traces
+	traces := &tracers.TxTraces{TxHash: t.txHash}
+	if err != nil {
+		traces.Error = err.Error()
+		t.block.Transactions = append(t.block.Transactions, traces)
+		return
+	}
+	for _, tracer := range txTracers {
+		if tracer.OnTxEnd != nil {
+			tracer.OnTxEnd(receipt, nil)
+		}
+	}
+	var errs []error
+	if traces.Calls, err = txTracers[0].GetResult(); err != nil {
+		errs = append(errs, err)
+	}
+	if traces.StateDiff, err = txTracers[1].GetResult(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		traces.Error = err.Error()
+	}
+	t.block.Transactions = append(t.block.Transactions, traces)
+}
+
+func (t *archiveTracer) onEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	for _, tracer := range t.txTracers {
+		if tracer.OnEnter != nil {
+			tracer.OnEnter(depth, typ, from, to, input, gas, value)
+		}
+	}
+}
+
+func (t *archiveTracer) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	for _, tracer := range t.txTracers {
+		if tracer.OnExit != nil {
+			tracer.OnExit(depth, output, gasUsed, err, reverted)
+		}
+	}
+}
+
+func (t *archiveTracer) onOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	for _, tracer := range t.txTracers {
+		if tracer.OnOpcode != nil {
+			tracer.OnOpcode(pc, op, gas, cost, scope, rData, depth, err)
+		}
+	}
+}
+
+func (t *archiveTracer) onFault(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+	for _, tracer := range t.txTracers {
+		if tracer.OnFault != nil {
+			tracer.OnFault(pc, op, gas, cost, scope, depth, err)
+		}
+	}
+}
+
+func (t *archiveTracer) onLog(l *types.Log) {
+	for _, tracer := range t.txTracers {
+		if tracer.OnLog != nil {
+			tracer.OnLog(l)
+		}
+	}
+}
+
+// change returns the tracked modifications of the given account.
+func (t *archiveTracer) change(addr common.Address) *accountChange {
+	if t.changes == nil {
+		return nil // state modified outside of a block, e.g. genesis
+	}
+	c := t.changes[addr]
+	if c == nil {
+		c = &accountChange{}
+		t.changes[addr] = c
+	}
+	return c
+}
+
+func (t *archiveTracer) onBalanceChange(addr common.Address, prev, next *big.Int, reason tracing.BalanceChangeReason) {
+	if c := t.change(addr); c != nil {
+		if c.prevBalance == nil {
+			c.prevBalance = new(big.Int).Set(prev)
+		}
+		c.balance = new(big.Int).Set(next)
+	}
+}
+
+func (t *archiveTracer) onNonceChange(addr common.Address, prev, next uint64) {
+	if c := t.change(addr); c != nil {
+		if c.prevNonce == nil {
+			c.prevNonce = &prev
+		}
+		c.nonce = &next
+	}
+}
+
+func (t *archiveTracer) onCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	if c := t.change(addr); c != nil {
+		if !c.codeChanged {
+			c.prevCode, c.codeChanged = bytes.Clone(prevCode), true
+		}
+		c.code = bytes.Clone(code)
+	}
+}
+
+func (t *archiveTracer) onStorageChange(addr common.Address, slot common.Hash, prev, next common.Hash) {
+	if c := t.change(addr); c != nil {
+		if c.storage == nil {
+			c.prevStorage = make(map[common.Hash]common.Hash)
+			c.storage = make(map[common.Hash]common.Hash)
+		}
+		if _, ok := c.prevStorage[slot]; !ok {
+			c.prevStorage[slot] = prev
+		}
+		c.storage[slot] = next
+	}
+}
+
+// stateDiff assembles the net state modifications made by the block, with the
+// fields reverted to their original values omitted.
+func stateDiff(changes map[common.Address]*accountChange) *archiveStateDiff {
+	diff := &archiveStateDiff{
+		Pre:  make(map[common.Address]*archiveAccount),
+		Post: make(map[common.Address]*archiveAccount),
+	}
+	for addr, c := range changes {
+		var (
+			pre      = new(archiveAccount)
+			post     = new(archiveAccount)
+			modified bool
+		)
+		if c.balance != nil && c.balance.Cmp(c.prevBalance) != 0 {
+			pre.Balance, post.Balance = (*hexutil.Big)(c.prevBalance), (*hexutil.Big)(c.balance)
+			modified = true
+		}
+		if c.nonce != nil && *c.nonce != *c.prevNonce {
+			pre.Nonce, post.Nonce = *c.prevNonce, *c.nonce
+			modified = true
+		}
+		if c.codeChanged && !bytes.Equal(c.code, c.prevCode) {
+			pre.Code, post.Code = c.prevCode, c.code
+			modified = true
+		}
+		for slot, val := range c.storage {
+			if prev := c.prevStorage[slot]; prev != val {
+				if pre.Storage == nil {
+					pre.Storage = make(map[common.Hash]common.Hash)
+					post.Storage = make(map[common.Hash]common.Hash)
+				}
+				pre.Storage[slot], post.Storage[slot] = prev, val
+				modified = true
+			}
+		}
+		if modified {
+			diff.Pre[addr], diff.Post[addr] = pre, post
+		}
+	}
+	return diff
+}
+
+// write archives the traces of the given block.
+func (t *archiveTracer) write(block *tracers.BlockTraces, parent common.Hash, changes map[common.Address]*accountChange) error {
+	diff, err := json.Marshal(stateDiff(changes))
+	if err != nil {
+		return err
+	}
+	block.StateDiff = diff
+
+	blob, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+	return t.archive.Write(uint64(block.Number), block.Hash, parent, blob)
+}
+
+// ReadTraces implements tracers.TraceArchive, retrieving the archived traces
+// of the given block.
+func (t *archiveTracer) ReadTraces(number uint64, hash common.Hash) *tracers.BlockTraces {
+	blob := t.archive.Read(number, hash)
+	if len(blob) == 0 {
+		return nil
+	}
+	var traces tracers.BlockTraces
+	if err := json.Unmarshal(blob, &traces); err != nil {
+		log.Error("Failed to decode archived traces", "number", number, "err", err)
+		return nil
+	}
+	return &traces
+}
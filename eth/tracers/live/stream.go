@@ -0,0 +1,578 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("stream", newStreamTracer)
+}
+
+// StreamVersion is the version of the serialized event format emitted by the
+// stream tracer. It's bumped on any backward incompatible change.
+const StreamVersion = 1
+
+const (
+	// streamQueueSize is the number of event batches queued for writing to the
+	// tracer process. If the tracer process falls further behind, it's dropped.
+	streamQueueSize = 64
+
+	// streamBatchSize is the size of the buffered events above which they are
+	// queued for writing without waiting for the end of the block.
+	streamBatchSize = 1024 * 1024
+
+	// streamWriteTimeout is the maximum time allowed for writing a batch of
+	// events to the tracer process.
+	streamWriteTimeout = 5 * time.Second
+)
+
+var (
+	errStreamClosed = errors.New("connection closed")
+	errStreamBehind = errors.New("tracer falling behind")
+)
+
+// The list of event types emitted by the stream tracer.
+const (
+	StreamEventHello           = "hello"
+	StreamEventBlockchainInit  = "blockchainInit"
+	StreamEventClose           = "close"
+	StreamEventGenesisBlock    = "genesisBlock"
+	StreamEventBlockStart      = "blockStart"
+	StreamEventBlockEnd        = "blockEnd"
+	StreamEventSkippedBlock    = "skippedBlock"
+	StreamEventSystemCallStart = "systemCallStart"
+	StreamEventSystemCallEnd   = "systemCallEnd"
+	StreamEventTxStart         = "txStart"
+	StreamEventTxEnd           = "txEnd"
+	StreamEventEnter           = "enter"
+	StreamEventExit            = "exit"
+	StreamEventOpcode          = "opcode"
+	StreamEventFault           = "fault"
+	StreamEventGasChange       = "gasChange"
+	StreamEventBalanceChange   = "balanceChange"
+	StreamEventNonceChange     = "nonceChange"
+	StreamEventCodeChange      = "codeChange"
+	StreamEventStorageChange   = "storageChange"
+	StreamEventLog             = "log"
+	StreamEventBlockHashRead   = "blockHashRead"
+)
+
+// StreamEvent is a single tracing hook invocation serialized by the stream
+// tracer. The events are written to the socket as newline delimited JSON, the
+// type of the event determines the type of the data, e.g. StreamBlockEvent for
+// the "blockStart" events.
+type StreamEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// StreamHello is the first event sent on every connection.
+type StreamHello struct {
+	Version int `json:"version"`
+}
+
+// StreamBlockchainInit is the data of the "blockchainInit" event.
+type StreamBlockchainInit struct {
+	ChainConfig *params.ChainConfig `json:"chainConfig"`
+}
+
+// StreamGenesisBlock is the data of the "genesisBlock" event.
+type StreamGenesisBlock struct {
+	Header *types.Header      `json:"header"`
+	Alloc  types.GenesisAlloc `json:"alloc"`
+}
+
+// StreamBlockEvent is the data of the "blockStart" and "skippedBlock" events.
+type StreamBlockEvent struct {
+	Header    *types.Header   `json:"header"`
+	TxHashes  []common.Hash   `json:"txHashes"`
+	Finalized *types.Header   `json:"finalized,omitempty"`
+	Safe      *types.Header   `json:"safe,omitempty"`
+	Uncles    []*types.Header `json:"uncles,omitempty"`
+}
+
+// StreamError is the data of the "blockEnd" event.
+type StreamError struct {
+	Error string `json:"error,omitempty"`
+}
+
+// StreamTxStart is the data of the "txStart" event.
+type StreamTxStart struct {
+	Tx   *types.Transaction `json:"tx"`
+	From common.Address     `json:"from"`
+}
+
+// StreamTxEnd is the data of the "txEnd" event.
+type StreamTxEnd struct {
+	Receipt *types.Receipt `json:"receipt,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// StreamEnter is the data of the "enter" event.
+type StreamEnter struct {
+	Depth int            `json:"depth"`
+	Type  string         `json:"type"`
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Input hexutil.Bytes  `json:"input"`
+	Gas   hexutil.Uint64 `json:"gas"`
+	Value *hexutil.Big   `json:"value,omitempty"`
+}
+
+// StreamExit is the data of the "exit" event.
+type StreamExit struct {
+	Depth    int            `json:"depth"`
+	Output   hexutil.Bytes  `json:"output"`
+	GasUsed  hexutil.Uint64 `json:"gasUsed"`
+	Error    string         `json:"error,omitempty"`
+	Reverted bool           `json:"reverted"`
+}
+
+// StreamOpcode is the data of the "opcode" and "fault" events.
+type StreamOpcode struct {
+	PC    uint64         `json:"pc"`
+	Op    string         `json:"op"`
+	Gas   hexutil.Uint64 `json:"gas"`
+	Cost  hexutil.Uint64 `json:"cost"`
+	Depth int            `json:"depth"`
+	Error string         `json:"error,omitempty"`
+}
+
+// StreamGasChange is the data of the "gasChange" event.
+type StreamGasChange struct {
+	Old    hexutil.Uint64 `json:"old"`
+	New    hexutil.Uint64 `json:"new"`
+	Reason string         `json:"reason"`
+}
+
+// StreamBalanceChange is the data of the "balanceChange" event.
+type StreamBalanceChange struct {
+	Address common.Address `json:"address"`
+	Prev    *hexutil.Big   `json:"prev"`
+	New     *hexutil.Big   `json:"new"`
+	Reason  string         `json:"reason"`
+}
+
+// StreamNonceChange is the data of the "nonceChange" event.
+type StreamNonceChange struct {
+	Address common.Address `json:"address"`
+	Prev    hexutil.Uint64 `json:"prev"`
+	New     hexutil.Uint64 `json:"new"`
+	Reason  string         `json:"reason"`
+}
+
+// StreamCodeChange is the data of the "codeChange" event.
+type StreamCodeChange struct {
+	Address      common.Address `json:"address"`
+	PrevCodeHash common.Hash    `json:"prevCodeHash"`
+	CodeHash     common.Hash    `json:"codeHash"`
+	Code         hexutil.Bytes  `json:"code"`
+}
+
+// StreamStorageChange is the data of the "storageChange" event.
+type StreamStorageChange struct {
+	Address common.Address `json:"address"`
+	Slot    common.Hash    `json:"slot"`
+	Prev    common.Hash    `json:"prev"`
+	New     common.Hash    `json:"new"`
+}
+
+// StreamBlockHashRead is the data of the "blockHashRead" event.
+type StreamBlockHashRead struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+type streamTracerConfig struct {
+	Network string `json:"network"` // Network of the socket, "unix" by default
+	Path    string `json:"path"`    // Address of the socket the tracer process listens on
+	Opcodes bool   `json:"opcodes"` // Whether to stream the opcode events, disabled by default as they are costly
+}
+
+// streamTracer is a live tracer which serializes the hook invocations and
+// streams them over a local socket to an out-of-process tracer.
+//
+// The events are buffered and queued for writing at the block boundaries, the
+// writing is done by a background goroutine not to stall the block processing.
+// If the tracer process is unreachable or falls behind, the connection is
+// dropped along with the events, and it's retried at the beginning of the next
+// block.
+type streamTracer struct {
+	config streamTracerConfig
+	conn   *streamConn
+	buffer bytes.Buffer
+}
+
+// streamConn is a connection to the tracer process, with the queued batches of
+// events written by a dedicated goroutine.
+type streamConn struct {
+	conn   net.Conn
+	queue  chan []byte
+	closed chan struct{} // Closed when the writer goroutine terminates
+}
+
+func newStreamConn(conn net.Conn) *streamConn {
+	c := &streamConn{
+		conn:   conn,
+		queue:  make(chan []byte, streamQueueSize),
+		closed: make(chan struct{}),
+	}
+	go c.loop()
+	return c
+}
+
+// loop writes the queued batches to the connection until the queue is closed
+// or a write fails.
+func (c *streamConn) loop() {
+	defer close(c.closed)
+	defer c.conn.Close()
+
+	for batch := range c.queue {
+		c.conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if _, err := c.conn.Write(batch); err != nil {
+			log.Warn("Tracer connection lost", "err", err)
+			return
+		}
+	}
+}
+
+// send queues a batch of events for writing, without blocking.
+func (c *streamConn) send(batch []byte) error {
+	select {
+	case <-c.closed:
+		return errStreamClosed
+	default:
+	}
+	select {
+	case c.queue <- batch:
+		return nil
+	default:
+		return errStreamBehind
+	}
+}
+
+// drop closes the connection, discarding the queued batches.
+func (c *streamConn) drop() {
+	close(c.queue)
+	c.conn.Close()
+}
+
+// close writes out the queued batches and closes the connection, waiting for
+// at most the write timeout.
+func (c *streamConn) close() {
+	close(c.queue)
+	select {
+	case <-c.closed:
+	case <-time.After(streamWriteTimeout):
+		c.conn.Close()
+	}
+}
+
+func newStreamTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
+	var config streamTracerConfig
+	if err := json.Unmarshal(cfg, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	if config.Path == "" {
+		return nil, errors.New("stream tracer socket path is required")
+	}
+	if config.Network == "" {
+		config.Network = "unix"
+	}
+	t := &streamTracer{config: config}
+	if err := t.connect(); err != nil {
+		return nil, err
+	}
+	hooks := &tracing.Hooks{
+		OnBlockchainInit:  t.onBlockchainInit,
+		OnClose:           t.onClose,
+		OnGenesisBlock:    t.onGenesisBlock,
+		OnBlockStart:      t.onBlockStart,
+		OnBlockEnd:        t.onBlockEnd,
+		OnSkippedBlock:    t.onSkippedBlock,
+		OnSystemCallStart: t.onSystemCallStart,
+		OnSystemCallEnd:   t.onSystemCallEnd,
+		OnTxStart:         t.onTxStart,
+		OnTxEnd:           t.onTxEnd,
+		OnEnter:           t.onEnter,
+		OnExit:            t.onExit,
+		OnFault:           t.onFault,
+		OnGasChange:       t.onGasChange,
+		OnBalanceChange:   t.onBalanceChange,
+		OnNonceChangeV2:   t.onNonceChange,
+		OnCodeChange:      t.onCodeChange,
+		OnStorageChange:   t.onStorageChange,
+		OnLog:             t.onLog,
+		OnBlockHashRead:   t.onBlockHashRead,
+	}
+	if config.Opcodes {
+		hooks.OnOpcode = t.onOpcode
+	}
+	return hooks, nil
+}
+
+// connect establishes the connection to the tracer process and sends the
+// hello event.
+func (t *streamTracer) connect() error {
+	conn, err := net.DialTimeout(t.config.Network, t.config.Path, streamWriteTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to tracer: %v", err)
+	}
+	t.conn = newStreamConn(conn)
+	t.emit(StreamEventHello, &StreamHello{Version: StreamVersion})
+	return nil
+}
+
+// disconnect drops the connection to the tracer process along with the pending
+// events, if any.
+func (t *streamTracer) disconnect() {
+	if t.conn != nil {
+		t.conn.drop()
+		t.conn = nil
+	}
+	t.buffer.Reset()
+}
+
+// emit serializes the event and writes it into the buffer.
+func (t *streamTracer) emit(typ string, data any) {
+	if t.conn == nil {
+		return
+	}
+	event := StreamEvent{Type: typ}
+	if data != nil {
+		blob, err := json.Marshal(data)
+		if err != nil {
+			log.Error("Failed to encode tracing event", "type", typ, "err", err)
+			return
+		}
+		event.Data = blob
+	}
+	blob, err := json.Marshal(event)
+	if err != nil {
+		log.Error("Failed to encode tracing event", "type", typ, "err", err)
+		return
+	}
+	t.buffer.Write(blob)
+	t.buffer.WriteByte('\n')
+	if t.buffer.Len() >= streamBatchSize {
+		t.flush()
+	}
+}
+
+// flush queues the buffered events for writing.
+func (t *streamTracer) flush() {
+	if t.conn == nil || t.buffer.Len() == 0 {
+		return
+	}
+	batch := bytes.Clone(t.buffer.Bytes())
+	t.buffer.Reset()
+
+	if err := t.conn.send(batch); err != nil {
+		if err == errStreamBehind {
+			log.Warn("Dropping tracer connection", "err", err)
+		}
+		t.disconnect()
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func newStreamBlockEvent(event tracing.BlockEvent) *StreamBlockEvent {
+	data := &StreamBlockEvent{
+		Header:    event.Block.Header(),
+		TxHashes:  make([]common.Hash, 0, len(event.Block.Transactions())),
+		Finalized: event.Finalized,
+		Safe:      event.Safe,
+		Uncles:    event.Block.Uncles(),
+	}
+	for _, tx := range event.Block.Transactions() {
+		data.TxHashes = append(data.TxHashes, tx.Hash())
+	}
+	return data
+}
+
+func (t *streamTracer) onBlockchainInit(chainConfig *params.ChainConfig) {
+	t.emit(StreamEventBlockchainInit, &StreamBlockchainInit{ChainConfig: chainConfig})
+	t.flush()
+}
+
+func (t *streamTracer) onClose() {
+	t.emit(StreamEventClose, nil)
+	t.flush()
+	if t.conn != nil {
+		t.conn.close()
+		t.conn = nil
+	}
+}
+
+func (t *streamTracer) onGenesisBlock(genesis *types.Block, alloc types.GenesisAlloc) {
+	t.emit(StreamEventGenesisBlock, &StreamGenesisBlock{Header: genesis.Header(), Alloc: alloc})
+	t.flush()
+}
+
+func (t *streamTracer) onBlockStart(event tracing.BlockEvent) {
+	if t.conn == nil {
+		if err := t.connect(); err != nil {
+			log.Debug("Failed to reconnect to tracer", "err", err)
+		}
+	}
+	t.emit(StreamEventBlockStart, newStreamBlockEvent(event))
+}
+
+func (t *streamTracer) onBlockEnd(err error) {
+	t.emit(StreamEventBlockEnd, &StreamError{Error: errString(err)})
+	t.flush()
+}
+
+func (t *streamTracer) onSkippedBlock(event tracing.BlockEvent) {
+	t.emit(StreamEventSkippedBlock, newStreamBlockEvent(event))
+	t.flush()
+}
+
+func (t *streamTracer) onSystemCallStart() {
+	t.emit(StreamEventSystemCallStart, nil)
+}
+
+func (t *streamTracer) onSystemCallEnd() {
+	t.emit(StreamEventSystemCallEnd, nil)
+}
+
+func (t *streamTracer) onTxStart(vm *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.emit(StreamEventTxStart, &StreamTxStart{Tx: tx, From: from})
+}
+
+func (t *streamTracer) onTxEnd(receipt *types.Receipt, err error) {
+	t.emit(StreamEventTxEnd, &StreamTxEnd{Receipt: receipt, Error: errString(err)})
+}
+
+func (t *streamTracer) onEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.emit(StreamEventEnter, &StreamEnter{
+		Depth: depth,
+		Type:  vm.OpCode(typ).String(),
+		From:  from,
+		To:    to,
+		Input: input,
+		Gas:   hexutil.Uint64(gas),
+		Value: (*hexutil.Big)(value),
+	})
+}
+
+func (t *streamTracer) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	t.emit(StreamEventExit, &StreamExit{
+		Depth:    depth,
+		Output:   output,
+		GasUsed:  hexutil.Uint64(gasUsed),
+		Error:    errString(err),
+		Reverted: reverted,
+	})
+}
+
+func (t *streamTracer) onOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	t.emit(StreamEventOpcode, &StreamOpcode{
+		PC:    pc,
+		Op:    vm.OpCode(op).String(),
+		Gas:   hexutil.Uint64(gas),
+		Cost:  hexutil.Uint64(cost),
+		Depth: depth,
+		Error: errString(err),
+	})
+}
+
+func (t *streamTracer) onFault(pc uint64, op byte, gas, cost uint64, _ tracing.OpContext, depth int, err error) {
+	t.emit(StreamEventFault, &StreamOpcode{
+		PC:    pc,
+		Op:    vm.OpCode(op).String(),
+		Gas:   hexutil.Uint64(gas),
+		Cost:  hexutil.Uint64(cost),
+		Depth: depth,
+		Error: errString(err),
+	})
+}
+
+func (t *streamTracer) onGasChange(old, new uint64, reason tracing.GasChangeReason) {
+	t.emit(StreamEventGasChange, &StreamGasChange{
+		Old:    hexutil.Uint64(old),
+		New:    hexutil.Uint64(new),
+		Reason: reason.String(),
+	})
+}
+
+func (t *streamTracer) onBalanceChange(addr common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+	t.emit(StreamEventBalanceChange, &StreamBalanceChange{
+		Address: addr,
+		Prev:    (*hexutil.Big)(prev),
+		New:     (*hexutil.Big)(new),
+		Reason:  reason.String(),
+	})
+}
+
+func (t *streamTracer) onNonceChange(addr common.Address, prev, new uint64, reason tracing.NonceChangeReason) {
+	t.emit(StreamEventNonceChange, &StreamNonceChange{
+		Address: addr,
+		Prev:    hexutil.Uint64(prev),
+		New:     hexutil.Uint64(new),
+		Reason:  reason.String(),
+	})
+}
+
+func (t *streamTracer) onCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	t.emit(StreamEventCodeChange, &StreamCodeChange{
+		Address:      addr,
+		PrevCodeHash: prevCodeHash,
+		CodeHash:     codeHash,
+		Code:         code,
+	})
+}
+
+func (t *streamTracer) onStorageChange(addr common.Address, slot common.Hash, prev, new common.Hash) {
+	t.emit(StreamEventStorageChange, &StreamStorageChange{
+		Address: addr,
+		Slot:    slot,
+		Prev:    prev,
+		New:     new,
+	})
+}
+
+func (t *streamTracer) onLog(l *types.Log) {
+	t.emit(StreamEventLog, l)
+}
+
+func (t *streamTracer) onBlockHashRead(number uint64, hash common.Hash) {
+	t.emit(StreamEventBlockHashRead, &StreamBlockHashRead{Number: hexutil.Uint64(number), Hash: hash})
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build (linux || darwin) && cgo
+
+package tracers
+
+import (
+	"encoding/json"
+	"fmt"
+	"plugin"
+
+	"github.com/ethereum/go-ethereum/core/tracing"
+)
+
+// PluginSymbol is the name of the constructor a Go plugin must export in order
+// to be loaded as a live tracer. The constructor must have the signature of
+//
+//	func(config json.RawMessage) (*tracing.Hooks, error)
+//
+// Note, Go plugins must be built with the same toolchain and the same versions
+// of all the shared dependencies (including go-ethereum itself) as the binary
+// loading them.
+const PluginSymbol = "NewTracer"
+
+// loadPlugin opens the Go plugin at the given path and instantiates the live
+// tracer from the constructor it exports.
+func loadPlugin(path string, config json.RawMessage) (*tracing.Hooks, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tracer plugin: %v", err)
+	}
+	sym, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tracer plugin: %v", err)
+	}
+	ctor, ok := sym.(func(json.RawMessage) (*tracing.Hooks, error))
+	if !ok {
+		return nil, fmt.Errorf("tracer plugin symbol %s has unexpected type %T", PluginSymbol, sym)
+	}
+	return ctor(config)
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("gasProfiler", newGasProfiler, false)
+}
+
+// The granularities of the gas profile leaves.
+const (
+	gasProfileOpcode = "opcode" // gas is grouped by opcode within a frame
+	gasProfilePC     = "pc"     // gas is grouped by program counter within a frame
+)
+
+type gasProfilerConfig struct {
+	Granularity string `json:"granularity"` // Either "opcode" (default) or "pc"
+}
+
+// gasProfileFrame is an active call frame of the profiled execution.
+type gasProfileFrame struct {
+	stack    string // Folded stack of the frame, including its ancestors
+	leaf     string // Leaf of the instruction awaiting its gas usage
+	gas      uint64 // Gas available prior to the pending instruction
+	pending  bool   // Whether an instruction is awaiting its gas usage
+	child    uint64 // Gas used by the frames spawned by the pending instruction
+	consumed uint64 // Gas accounted to the frame and its children so far
+}
+
+// gasProfiler is a native tracer which profiles the gas used by a transaction.
+// The gas is grouped by the call stack, where each frame is identified by the
+// contract address and the function selector, and by the opcode or program
+// counter executed within the frames. The result is a string in the folded
+// stack format consumed by flamegraph tools, one line per distinct stack:
+//
+//	0xaddr:0xselector;0xaddr:0xselector;SLOAD 4200
+//
+// The gas of the instructions spawning calls excludes the gas used by the
+// callees, so every unit of gas is reported exactly once. Refunds are not
+// reflected in the profile.
+type gasProfiler struct {
+	config    gasProfilerConfig
+	txGas     uint64
+	frames    []*gasProfileFrame
+	samples   map[string]uint64
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newGasProfiler returns a new gasProfiler.
+func newGasProfiler(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	var config gasProfilerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	switch config.Granularity {
+	case "":
+		config.Granularity = gasProfileOpcode
+	case gasProfileOpcode, gasProfilePC:
+	default:
+		return nil, fmt.Errorf("unsupported granularity %q", config.Granularity)
+	}
+	t := &gasProfiler{
+		config:  config,
+		samples: make(map[string]uint64),
+	}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnEnter:   t.OnEnter,
+			OnExit:    t.OnExit,
+			OnOpcode:  t.OnOpcode,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *gasProfiler) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.txGas = tx.Gas()
+}
+
+// OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *gasProfiler) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	label := to.Hex()
+	switch vm.OpCode(typ) {
+	case vm.CREATE, vm.CREATE2:
+		label += ":constructor"
+	case vm.SELFDESTRUCT:
+		label += ":selfdestruct"
+	default:
+		if len(input) >= 4 {
+			label += ":" + hexutil.Encode(input[:4])
+		}
+	}
+	frame := &gasProfileFrame{stack: label}
+	if depth == 0 {
+		// The gas withheld from the outermost frame is the intrinsic gas
+		if t.txGas > gas {
+			t.samples[label+";[intrinsic]"] += t.txGas - gas
+		}
+	} else if len(t.frames) > 0 {
+		frame.stack = t.frames[len(t.frames)-1].stack + ";" + label
+	}
+	t.frames = append(t.frames, frame)
+}
+
+// OnExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *gasProfiler) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.frames) != depth+1 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	// The gas not accounted yet is spent by the last executed instruction, or
+	// by the frame itself if no code was executed (e.g. precompiles).
+	if gasUsed > frame.consumed {
+		used := gasUsed - frame.consumed
+		if frame.pending {
+			t.samples[frame.stack+";"+frame.leaf] += used - min(used, frame.child)
+		} else {
+			t.samples[frame.stack] += used
+		}
+	}
+	if len(t.frames) > 0 {
+		t.frames[len(t.frames)-1].child += gasUsed
+	}
+}
+
+// OnOpcode is called before the execution of every instruction.
+func (t *gasProfiler) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	// The instructions are reported with the depth of the interpreter, which is
+	// one higher than the depth of the frame executing them.
+	if t.interrupt.Load() || len(t.frames) != depth {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	if frame.pending && frame.gas >= gas {
+		used := frame.gas - gas // gas used by the instruction and its callees
+		t.samples[frame.stack+";"+frame.leaf] += used - min(used, frame.child)
+		frame.consumed += used
+	}
+	frame.leaf = vm.OpCode(op).String()
+	if t.config.Granularity == gasProfilePC {
+		frame.leaf = fmt.Sprintf("%s@%d", frame.leaf, pc)
+	}
+	frame.gas, frame.pending, frame.child = gas, true, 0
+}
+
+// GetResult returns the gas profile in the folded stack format, as a json string.
+func (t *gasProfiler) GetResult() (json.RawMessage, error) {
+	stacks := make([]string, 0, len(t.samples))
+	for stack, gas := range t.samples {
+		if gas > 0 {
+			stacks = append(stacks, stack)
+		}
+	}
+	slices.Sort(stacks)
+
+	var folded strings.Builder
+	for _, stack := range stacks {
+		fmt.Fprintf(&folded, "%s %d\n", stack, t.samples[stack])
+	}
+	res, err := json.Marshal(folded.String())
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *gasProfiler) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that the gas profiler attributes the gas of the nested calls to the
+// callees only, and accounts for all the gas used.
+func TestGasProfiler(t *testing.T) {
+	var (
+		caller = common.HexToAddress("0xaa")
+		callee = common.HexToAddress("0xbb")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	// CALL(GAS, 0xbb, 0, 0, 0, 0, 0), STOP
+	statedb.SetCode(caller, common.FromHex("6000600060006000600060bb5af100"))
+	// SSTORE(0, 0x2a), STOP
+	statedb.SetCode(callee, common.FromHex("602a60005500"))
+
+	tracer, err := tracers.DefaultDirectory.New("gasProfiler", &tracers.Context{}, nil, params.MergedTestChainConfig)
+	require.NoError(t, err)
+
+	cfg := &runtime.Config{
+		State:       statedb,
+		GasLimit:    1000000,
+		ChainConfig: params.MergedTestChainConfig,
+		EVMConfig:   vm.Config{Tracer: tracer.Hooks},
+	}
+	_, leftOver, err := runtime.Call(caller, common.FromHex("0x12345678"), cfg)
+	require.NoError(t, err)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	var folded string
+	require.NoError(t, json.Unmarshal(res, &folded))
+
+	var (
+		total   uint64
+		samples = make(map[string]uint64)
+	)
+	for _, line := range strings.Split(strings.TrimSpace(folded), "\n") {
+		idx := strings.LastIndexByte(line, ' ')
+		gas, err := strconv.ParseUint(line[idx+1:], 10, 64)
+		require.NoError(t, err)
+		samples[line[:idx]] = gas
+		total += gas
+	}
+	require.Equal(t, cfg.GasLimit-leftOver, total)
+
+	var (
+		outer = caller.Hex() + ":0x12345678"
+		inner = outer + ";" + callee.Hex()
+	)
+	require.Equal(t, uint64(22100), samples[inner+";SSTORE"])
+	require.Equal(t, uint64(6), samples[inner+";PUSH1"])
+	require.Equal(t, uint64(2600), samples[outer+";CALL"])
+}
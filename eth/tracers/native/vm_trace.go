@@ -0,0 +1,282 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/internal"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("vmTracer", newVMTracer, false)
+}
+
+// vmTrace is the execution trace of a single call frame in the Parity vmTrace
+// format.
+type vmTrace struct {
+	Code hexutil.Bytes `json:"code"`
+	Ops  []*vmTraceOp  `json:"ops"`
+}
+
+// vmTraceOp is a single executed instruction, along with the call frame it
+// spawned, if any.
+type vmTraceOp struct {
+	Cost uint64     `json:"cost"`
+	Ex   *vmTraceEx `json:"ex"`
+	Pc   uint64     `json:"pc"`
+	Sub  *vmTrace   `json:"sub"`
+
+	op      vm.OpCode
+	memOff  uint64 // Offset of the memory written by the instruction
+	memSize uint64 // Size of the memory written by the instruction
+}
+
+// vmTraceEx is the outcome of an executed instruction.
+type vmTraceEx struct {
+	Mem   *vmTraceMem   `json:"mem"`
+	Push  []string      `json:"push"`
+	Store *vmTraceStore `json:"store"`
+	Used  uint64        `json:"used"` // Gas remaining after the instruction
+}
+
+type vmTraceMem struct {
+	Data hexutil.Bytes `json:"data"`
+	Off  uint64        `json:"off"`
+}
+
+type vmTraceStore struct {
+	Key string `json:"key"`
+	Val string `json:"val"`
+}
+
+// vmTraceFrame tracks the trace of an active call frame.
+type vmTraceFrame struct {
+	trace   *vmTrace
+	pending *vmTraceOp // Executed instruction awaiting its outcome
+}
+
+// vmTracer reports the executed instructions of a tx in the Parity vmTrace
+// format, with the call frames nested into the instructions spawning them.
+type vmTracer struct {
+	root      *vmTrace
+	frames    []*vmTraceFrame
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newVMTracer returns a new vmTracer.
+func newVMTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	t := &vmTracer{}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnEnter:  t.OnEnter,
+			OnExit:   t.OnExit,
+			OnOpcode: t.OnOpcode,
+			OnFault:  t.OnFault,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *vmTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	trace := &vmTrace{Code: []byte{}, Ops: []*vmTraceOp{}}
+	if depth == 0 {
+		t.root = trace
+	} else {
+		// The frame is spawned by the instruction being executed in the
+		// parent frame. Selfdestructs don't spawn any frames in Parity.
+		if vm.OpCode(typ) == vm.SELFDESTRUCT || len(t.frames) == 0 {
+			return
+		}
+		parent := t.frames[len(t.frames)-1]
+		if parent.pending == nil {
+			return
+		}
+		parent.pending.Sub = trace
+	}
+	t.frames = append(t.frames, &vmTraceFrame{trace: trace})
+}
+
+// OnExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *vmTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() {
+		return
+	}
+	if len(t.frames) != depth+1 {
+		return // selfdestruct frame, or a frame spawned without an instruction
+	}
+	// The last instruction of the frame halts the execution, so there is no
+	// stack or memory outcome to report.
+	frame := t.frames[len(t.frames)-1]
+	if op := frame.pending; op != nil && op.Ex != nil {
+		op.Ex.Used -= op.Cost
+	}
+	t.frames = t.frames[:len(t.frames)-1]
+}
+
+// OnOpcode is called before the execution of every instruction.
+func (t *vmTracer) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	// The instructions are reported with the depth of the interpreter, which is
+	// one higher than the depth of the frame executing them.
+	if t.interrupt.Load() || len(t.frames) != depth {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	if len(frame.trace.Ops) == 0 {
+		frame.trace.Code = scope.ContractCode()
+	}
+	// Fill in the outcome of the previous instruction from the current state
+	if prev := frame.pending; prev != nil && prev.Ex != nil {
+		prev.Ex.Used = gas
+		prev.Ex.Push = stackPushed(prev.op, scope.StackData())
+		if prev.memSize > 0 {
+			data, err := internal.GetMemoryCopyPadded(scope.MemoryData(), int64(prev.memOff), int64(prev.memSize))
+			if err == nil {
+				prev.Ex.Mem = &vmTraceMem{Data: data, Off: prev.memOff}
+			}
+		}
+	}
+	op := &vmTraceOp{
+		Cost: cost,
+		Pc:   pc,
+		Ex:   &vmTraceEx{Push: []string{}, Used: gas},
+		op:   vm.OpCode(opcode),
+	}
+	// Retrieve the storage and memory locations written by the instruction
+	// prior to its execution, as they are consumed from the stack.
+	stack := scope.StackData()
+	switch op.op {
+	case vm.SSTORE:
+		if len(stack) >= 2 {
+			op.Ex.Store = &vmTraceStore{Key: stack[len(stack)-1].Hex(), Val: stack[len(stack)-2].Hex()}
+		}
+	case vm.MSTORE:
+		op.memOff, op.memSize = memoryWrite(stack, 1, 0, 32)
+	case vm.MSTORE8:
+		op.memOff, op.memSize = memoryWrite(stack, 1, 0, 1)
+	case vm.CALLDATACOPY, vm.CODECOPY, vm.RETURNDATACOPY, vm.MCOPY:
+		op.memOff, op.memSize = memoryWrite(stack, 1, 3, 0)
+	case vm.EXTCODECOPY:
+		op.memOff, op.memSize = memoryWrite(stack, 2, 4, 0)
+	case vm.CALL, vm.CALLCODE:
+		op.memOff, op.memSize = memoryWrite(stack, 6, 7, 0)
+	case vm.DELEGATECALL, vm.STATICCALL:
+		op.memOff, op.memSize = memoryWrite(stack, 5, 6, 0)
+	}
+	// Parity reports no outcome for the failed instructions
+	if err != nil {
+		op.Ex = nil
+	}
+	frame.trace.Ops = append(frame.trace.Ops, op)
+	frame.pending = op
+}
+
+// OnFault is called when the execution of an instruction fails.
+func (t *vmTracer) OnFault(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+	if t.interrupt.Load() || len(t.frames) != depth {
+		return
+	}
+	if op := t.frames[len(t.frames)-1].pending; op != nil {
+		op.Ex = nil
+	}
+}
+
+// GetResult returns the json-encoded vmTrace of the tx, and any error arising
+// from the encoding or forceful termination (via `Stop`).
+func (t *vmTracer) GetResult() (json.RawMessage, error) {
+	if t.root == nil {
+		return nil, errors.New("no execution traced")
+	}
+	res, err := json.Marshal(t.root)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *vmTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+// memoryWrite returns the memory location written by an instruction, with the
+// offset and the size taken from the given stack positions (counted from the
+// top, starting at 1). A zero size position denotes a fixed size write.
+func memoryWrite(stack []uint256.Int, off, size int, fixed uint64) (uint64, uint64) {
+	if len(stack) < off || len(stack) < size {
+		return 0, 0
+	}
+	offset := stack[len(stack)-off]
+	if !offset.IsUint64() {
+		return 0, 0
+	}
+	if size == 0 {
+		return offset.Uint64(), fixed
+	}
+	length := stack[len(stack)-size]
+	if !length.IsUint64() {
+		return 0, 0
+	}
+	return offset.Uint64(), length.Uint64()
+}
+
+// stackPushed returns the stack items placed by the given instruction, deepest
+// first. Following Parity, the duplicated and swapped items are all reported.
+func stackPushed(op vm.OpCode, stack []uint256.Int) []string {
+	var n int
+	switch {
+	case op >= vm.DUP1 && op <= vm.DUP16:
+		n = int(op-vm.DUP1) + 2
+	case op >= vm.SWAP1 && op <= vm.SWAP16:
+		n = int(op-vm.SWAP1) + 2
+	default:
+		switch op {
+		case vm.STOP, vm.POP, vm.MSTORE, vm.MSTORE8, vm.SSTORE, vm.TSTORE, vm.JUMP, vm.JUMPI, vm.JUMPDEST,
+			vm.LOG0, vm.LOG1, vm.LOG2, vm.LOG3, vm.LOG4, vm.CALLDATACOPY, vm.CODECOPY, vm.EXTCODECOPY,
+			vm.RETURNDATACOPY, vm.MCOPY, vm.RETURN, vm.REVERT, vm.INVALID, vm.SELFDESTRUCT:
+			n = 0
+		default:
+			n = 1
+		}
+	}
+	if n > len(stack) {
+		n = len(stack)
+	}
+	pushed := make([]string, n)
+	for i := 0; i < n; i++ {
+		pushed[i] = stack[len(stack)-n+i].Hex()
+	}
+	return pushed
+}
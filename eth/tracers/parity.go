@@ -0,0 +1,582 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// maxTraceFilterBlocks is the maximum number of blocks a single trace_filter
+	// request is allowed to re-execute.
+	maxTraceFilterBlocks = 100
+
+	// The native tracers producing the outputs of the trace namespace.
+	parityTraceTracer     = "flatCallTracer"
+	parityStateDiffTracer = "prestateTracer"
+	parityVMTraceTracer   = "vmTracer"
+	parityMuxTracer       = "muxTracer"
+)
+
+// The trace types which can be requested from the replaying methods.
+const (
+	ParityTraceTypeTrace     = "trace"
+	ParityTraceTypeStateDiff = "stateDiff"
+	ParityTraceTypeVMTrace   = "vmTrace"
+)
+
+// The configurations of the tracers producing the outputs of the trace namespace.
+var (
+	parityTraceConfig     = json.RawMessage(`{"convertParityErrors":true}`)
+	parityStateDiffConfig = json.RawMessage(`{"diffMode":true}`)
+	parityVMTraceConfig   = json.RawMessage(`{}`)
+)
+
+// TraceFilterArgs represents the arguments of trace_filter.
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// TraceResults is the result of replaying a transaction with the requested trace
+// types, those not requested are left empty.
+type TraceResults struct {
+	Output          hexutil.Bytes   `json:"output"`
+	StateDiff       json.RawMessage `json:"stateDiff"`
+	Trace           json.RawMessage `json:"trace"`
+	VMTrace         json.RawMessage `json:"vmTrace"`
+	TransactionHash *common.Hash    `json:"transactionHash,omitempty"`
+}
+
+// TraceAPI is the collection of tracing APIs compatible with the trace namespace
+// of Parity/OpenEthereum, built on top of the native tracers.
+type TraceAPI struct {
+	api *API
+}
+
+// NewTraceAPI creates a new API definition for the Parity-compatible tracing
+// methods of the Ethereum service.
+func NewTraceAPI(backend Backend) *TraceAPI {
+	return &TraceAPI{api: NewAPI(backend)}
+}
+
+// Block returns the call traces of all the transactions in the given block, as
+// well as the mining rewards of the pre-merge blocks.
+func (api *TraceAPI) Block(ctx context.Context, number rpc.BlockNumber) ([]json.RawMessage, error) {
+	block, err := api.api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return api.blockTraces(ctx, block)
+}
+
+// Transaction returns the call traces of the given transaction.
+func (api *TraceAPI) Transaction(ctx context.Context, hash common.Hash) ([]json.RawMessage, error) {
+	tracer := parityTraceTracer
+	result, err := api.api.TraceTransaction(ctx, hash, &TraceConfig{Tracer: &tracer, TracerConfig: parityTraceConfig})
+	if err != nil {
+		return nil, err
+	}
+	return flatTraces(result)
+}
+
+// Filter returns the call traces within the given block range, matching the
+// given sender and recipient addresses.
+func (api *TraceAPI) Filter(ctx context.Context, args TraceFilterArgs) ([]json.RawMessage, error) {
+	from, err := api.blockNumber(ctx, args.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.blockNumber(ctx, args.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	if to-from >= maxTraceFilterBlocks {
+		return nil, fmt.Errorf("block range %d-%d exceeds the limit of %d blocks", from, to, maxTraceFilterBlocks)
+	}
+	var (
+		skip    uint64
+		results = []json.RawMessage{}
+	)
+	if args.After != nil {
+		skip = *args.After
+	}
+	for number := from; number <= to; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := api.api.blockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		if number == 0 {
+			continue // genesis is not traceable
+		}
+		traces, err := api.blockTraces(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		for _, trace := range traces {
+			if !matchTrace(trace, args.FromAddress, args.ToAddress) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			results = append(results, trace)
+			if args.Count != nil && uint64(len(results)) >= *args.Count {
+				return results, nil
+			}
+		}
+	}
+	return results, nil
+}
+
+// ReplayBlockTransactions replays all the transactions in the given block and
+// returns the requested trace types for each of them.
+func (api *TraceAPI) ReplayBlockTransactions(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, traceTypes []string) ([]*TraceResults, error) {
+	config, err := replayConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	var block *types.Block
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = api.api.blockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		block, err = api.api.blockByNumber(ctx, number)
+	} else {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+	if err != nil {
+		return nil, err
+	}
+	traces, err := api.api.traceBlock(ctx, block, config)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*TraceResults, len(traces))
+	for i, trace := range traces {
+		if trace.Error != "" {
+			return nil, errors.New(trace.Error)
+		}
+		if results[i], err = replayResults(trace.Result, traceTypes); err != nil {
+			return nil, err
+		}
+		results[i].TransactionHash = &trace.TxHash
+	}
+	return results, nil
+}
+
+// Call executes the given call on top of the given block, and returns the
+// requested trace types. The latest block is used if none is specified.
+func (api *TraceAPI) Call(ctx context.Context, args ethapi.TransactionArgs, traceTypes []string, blockNrOrHash *rpc.BlockNumberOrHash) (*TraceResults, error) {
+	config, err := replayConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	result, err := api.api.TraceCall(ctx, args, *blockNrOrHash, &TraceCallConfig{TraceConfig: *config})
+	if err != nil {
+		return nil, err
+	}
+	return replayResults(result, traceTypes)
+}
+
+// blockNumber resolves the given block number, defaulting to the latest block.
+func (api *TraceAPI) blockNumber(ctx context.Context, number *rpc.BlockNumber) (uint64, error) {
+	n := rpc.LatestBlockNumber
+	if number != nil {
+		n = *number
+	}
+	header, err := api.api.backend.HeaderByNumber(ctx, n)
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, fmt.Errorf("block #%d not found", n)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// blockTraces returns the call traces of all the transactions in the given
+// block, followed by the traces of the mining rewards.
+func (api *TraceAPI) blockTraces(ctx context.Context, block *types.Block) ([]json.RawMessage, error) {
+	tracer := parityTraceTracer
+	results, err := api.api.traceBlock(ctx, block, &TraceConfig{Tracer: &tracer, TracerConfig: parityTraceConfig})
+	if err != nil {
+		return nil, err
+	}
+	traces := []json.RawMessage{}
+	for _, result := range results {
+		if result.Error != "" {
+			return nil, errors.New(result.Error)
+		}
+		flat, err := flatTraces(result.Result)
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, flat...)
+	}
+	rewards, err := api.rewardTraces(block)
+	if err != nil {
+		return nil, err
+	}
+	return append(traces, rewards...), nil
+}
+
+// parityRewardAction is the action of a mining reward trace.
+type parityRewardAction struct {
+	Author     common.Address `json:"author"`
+	RewardType string         `json:"rewardType"`
+	Value      *hexutil.Big   `json:"value"`
+}
+
+// parityRewardTrace is the trace of a mining reward, which is not attributed
+// to any transaction.
+type parityRewardTrace struct {
+	Action              parityRewardAction `json:"action"`
+	BlockHash           common.Hash        `json:"blockHash"`
+	BlockNumber         uint64             `json:"blockNumber"`
+	Result              *struct{}          `json:"result"`
+	Subtraces           int                `json:"subtraces"`
+	TraceAddress        []int              `json:"traceAddress"`
+	TransactionHash     *common.Hash       `json:"transactionHash"`
+	TransactionPosition *uint64            `json:"transactionPosition"`
+	Type                string             `json:"type"`
+}
+
+// rewardTraces returns the traces of the mining rewards credited by the ethash
+// engine for the given block, mirroring its reward schedule.
+func (api *TraceAPI) rewardTraces(block *types.Block) ([]json.RawMessage, error) {
+	config := api.api.backend.ChainConfig()
+	if config.Ethash == nil || block.Difficulty().Sign() == 0 {
+		return nil, nil
+	}
+	blockReward := ethash.FrontierBlockReward
+	if config.IsByzantium(block.Number()) {
+		blockReward = ethash.ByzantiumBlockReward
+	}
+	if config.IsConstantinople(block.Number()) {
+		blockReward = ethash.ConstantinopleBlockReward
+	}
+	newTrace := func(author common.Address, typ string, value *uint256.Int) (json.RawMessage, error) {
+		return json.Marshal(&parityRewardTrace{
+			Action:       parityRewardAction{Author: author, RewardType: typ, Value: (*hexutil.Big)(value.ToBig())},
+			BlockHash:    block.Hash(),
+			BlockNumber:  block.NumberU64(),
+			TraceAddress: []int{},
+			Type:         "reward",
+		})
+	}
+	var (
+		traces []json.RawMessage
+		reward = new(uint256.Int).Set(blockReward)
+		number = uint256.NewInt(block.NumberU64())
+	)
+	for _, uncle := range block.Uncles() {
+		r := uint256.NewInt(uncle.Number.Uint64())
+		r.AddUint64(r, 8)
+		r.Sub(r, number)
+		r.Mul(r, blockReward)
+		r.Rsh(r, 3)
+		trace, err := newTrace(uncle.Coinbase, "uncle", r)
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, trace)
+		reward.Add(reward, new(uint256.Int).Rsh(blockReward, 5))
+	}
+	trace, err := newTrace(block.Coinbase(), "block", reward)
+	if err != nil {
+		return nil, err
+	}
+	// The block reward is reported ahead of the uncle rewards
+	return append([]json.RawMessage{trace}, traces...), nil
+}
+
+// flatTraces splits the result of the flatCallTracer into the individual traces.
+func flatTraces(result interface{}) ([]json.RawMessage, error) {
+	raw, ok := result.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected trace result %T", result)
+	}
+	var traces []json.RawMessage
+	if err := json.Unmarshal(raw, &traces); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+// parityTraceAddresses is the subset of a call or reward trace identifying the
+// parties involved.
+type parityTraceAddresses struct {
+	Action struct {
+		From          *common.Address `json:"from"`
+		To            *common.Address `json:"to"`
+		Address       *common.Address `json:"address"`
+		RefundAddress *common.Address `json:"refundAddress"`
+		Author        *common.Address `json:"author"`
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"`
+	} `json:"result"`
+}
+
+// matchTrace reports whether the sender and the recipient of the given trace
+// are contained in the given address lists. An empty list matches any address.
+func matchTrace(trace json.RawMessage, fromAddresses, toAddresses []common.Address) bool {
+	if len(fromAddresses) == 0 && len(toAddresses) == 0 {
+		return true
+	}
+	var addrs parityTraceAddresses
+	if err := json.Unmarshal(trace, &addrs); err != nil {
+		return false
+	}
+	var from, to *common.Address
+	switch {
+	case addrs.Action.Author != nil: // reward
+		to = addrs.Action.Author
+	case addrs.Action.RefundAddress != nil: // selfdestruct
+		from, to = addrs.Action.Address, addrs.Action.RefundAddress
+	case addrs.Action.To != nil: // call
+		from, to = addrs.Action.From, addrs.Action.To
+	default: // create
+		from = addrs.Action.From
+		if addrs.Result != nil {
+			to = addrs.Result.Address
+		}
+	}
+	match := func(addr *common.Address, list []common.Address) bool {
+		return len(list) == 0 || (addr != nil && slices.Contains(list, *addr))
+	}
+	return match(from, fromAddresses) && match(to, toAddresses)
+}
+
+// replayConfig assembles the tracer configuration producing the requested trace
+// types. The call traces are always produced, as they carry the output.
+func replayConfig(traceTypes []string) (*TraceConfig, error) {
+	tracers := map[string]json.RawMessage{
+		parityTraceTracer: parityTraceConfig,
+	}
+	for _, typ := range traceTypes {
+		switch typ {
+		case ParityTraceTypeTrace:
+		case ParityTraceTypeStateDiff:
+			tracers[parityStateDiffTracer] = parityStateDiffConfig
+		case ParityTraceTypeVMTrace:
+			tracers[parityVMTraceTracer] = parityVMTraceConfig
+		default:
+			return nil, fmt.Errorf("unsupported trace type %q", typ)
+		}
+	}
+	config, err := json.Marshal(tracers)
+	if err != nil {
+		return nil, err
+	}
+	tracer := parityMuxTracer
+	return &TraceConfig{Tracer: &tracer, TracerConfig: config}, nil
+}
+
+// replayResults converts the result of the tracers assembled by replayConfig
+// into the requested trace types.
+func replayResults(result interface{}, traceTypes []string) (*TraceResults, error) {
+	raw, ok := result.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected trace result %T", result)
+	}
+	var outputs map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &outputs); err != nil {
+		return nil, err
+	}
+	// Retrieve the output from the outermost call trace
+	var (
+		results = &TraceResults{Output: []byte{}}
+		calls   []struct {
+			Type   string `json:"type"`
+			Result *struct {
+				Code   hexutil.Bytes `json:"code"`
+				Output hexutil.Bytes `json:"output"`
+			} `json:"result"`
+		}
+	)
+	if err := json.Unmarshal(outputs[parityTraceTracer], &calls); err != nil {
+		return nil, err
+	}
+	if len(calls) > 0 && calls[0].Result != nil {
+		if calls[0].Type == "create" {
+			results.Output = calls[0].Result.Code
+		} else {
+			results.Output = calls[0].Result.Output
+		}
+	}
+	for _, typ := range traceTypes {
+		switch typ {
+		case ParityTraceTypeTrace:
+			results.Trace = outputs[parityTraceTracer]
+		case ParityTraceTypeStateDiff:
+			diff, err := parityStateDiff(outputs[parityStateDiffTracer])
+			if err != nil {
+				return nil, err
+			}
+			results.StateDiff = diff
+		case ParityTraceTypeVMTrace:
+			results.VMTrace = outputs[parityVMTraceTracer]
+		}
+	}
+	return results, nil
+}
+
+// prestateAccount is an account in the result of the prestateTracer.
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Code    hexutil.Bytes               `json:"code"`
+	Nonce   uint64                      `json:"nonce"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// parityAccountDiff is the modification of an account in the Parity stateDiff
+// format. Every field is either "=" if unchanged, or an object keyed by "+" if
+// the account was created, "-" if it was deleted and "*" if it was modified.
+type parityAccountDiff struct {
+	Balance interface{}                 `json:"balance"`
+	Code    interface{}                 `json:"code"`
+	Nonce   interface{}                 `json:"nonce"`
+	Storage map[common.Hash]interface{} `json:"storage"`
+}
+
+// parityChange is a modified value in the Parity stateDiff format.
+type parityChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// parityUnchanged denotes an unmodified value in the Parity stateDiff format.
+const parityUnchanged = "="
+
+// parityStateDiff converts the result of the prestateTracer in diff mode into
+// the Parity stateDiff format.
+func parityStateDiff(raw json.RawMessage) (json.RawMessage, error) {
+	var diff struct {
+		Pre  map[common.Address]*prestateAccount `json:"pre"`
+		Post map[common.Address]*prestateAccount `json:"post"`
+	}
+	if err := json.Unmarshal(raw, &diff); err != nil {
+		return nil, err
+	}
+	balance := func(acc *prestateAccount) *hexutil.Big {
+		if acc.Balance == nil {
+			return (*hexutil.Big)(new(big.Int))
+		}
+		return acc.Balance
+	}
+	code := func(acc *prestateAccount) hexutil.Bytes {
+		if acc.Code == nil {
+			return []byte{}
+		}
+		return acc.Code
+	}
+	// The accounts empty in the pre state are considered non-existent as per
+	// EIP-161, they are reported as created if modified, or not at all.
+	exists := func(acc *prestateAccount) bool {
+		return acc != nil && (balance(acc).ToInt().Sign() != 0 || acc.Nonce != 0 || len(acc.Code) != 0)
+	}
+	result := make(map[common.Address]*parityAccountDiff)
+	for addr, post := range diff.Post {
+		pre := diff.Pre[addr]
+		if !exists(pre) {
+			// The account was created, the post state holds all of its fields
+			acc := &parityAccountDiff{
+				Balance: map[string]interface{}{"+": balance(post)},
+				Code:    map[string]interface{}{"+": code(post)},
+				Nonce:   map[string]interface{}{"+": hexutil.Uint64(post.Nonce)},
+				Storage: make(map[common.Hash]interface{}),
+			}
+			for key, val := range post.Storage {
+				acc.Storage[key] = map[string]interface{}{"+": val}
+			}
+			result[addr] = acc
+			continue
+		}
+		// The account was modified, the post state holds the changed fields only
+		acc := &parityAccountDiff{
+			Balance: parityUnchanged,
+			Code:    parityUnchanged,
+			Nonce:   parityUnchanged,
+			Storage: make(map[common.Hash]interface{}),
+		}
+		if post.Balance != nil {
+			acc.Balance = map[string]interface{}{"*": parityChange{From: balance(pre), To: post.Balance}}
+		}
+		if post.Code != nil {
+			acc.Code = map[string]interface{}{"*": parityChange{From: code(pre), To: post.Code}}
+		}
+		if post.Nonce != 0 {
+			acc.Nonce = map[string]interface{}{"*": parityChange{From: hexutil.Uint64(pre.Nonce), To: hexutil.Uint64(post.Nonce)}}
+		}
+		// The zero slots are omitted by the prestateTracer on both sides
+		for key, val := range post.Storage {
+			acc.Storage[key] = map[string]interface{}{"*": parityChange{From: pre.Storage[key], To: val}}
+		}
+		for key, val := range pre.Storage {
+			if _, ok := post.Storage[key]; !ok {
+				acc.Storage[key] = map[string]interface{}{"*": parityChange{From: val, To: common.Hash{}}}
+			}
+		}
+		result[addr] = acc
+	}
+	for addr, pre := range diff.Pre {
+		if _, ok := diff.Post[addr]; ok || !exists(pre) {
+			continue
+		}
+		// The account was deleted, the pre state holds all of its fields
+		acc := &parityAccountDiff{
+			Balance: map[string]interface{}{"-": balance(pre)},
+			Code:    map[string]interface{}{"-": code(pre)},
+			Nonce:   map[string]interface{}{"-": hexutil.Uint64(pre.Nonce)},
+			Storage: make(map[common.Hash]interface{}),
+		}
+		for key, val := range pre.Storage {
+			acc.Storage[key] = map[string]interface{}{"-": val}
+		}
+		result[addr] = acc
+	}
+	return json.Marshal(result)
+}
@@ -0,0 +1,255 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	// Force-load the native tracers backing the trace namespace
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+type parityTrace struct {
+	Action struct {
+		From     *common.Address `json:"from"`
+		To       *common.Address `json:"to"`
+		Author   *common.Address `json:"author"`
+		CallType string          `json:"callType"`
+		Value    *hexutil.Big    `json:"value"`
+	} `json:"action"`
+	Result *struct {
+		Output hexutil.Bytes `json:"output"`
+	} `json:"result"`
+	BlockNumber     uint64       `json:"blockNumber"`
+	TransactionHash *common.Hash `json:"transactionHash"`
+	Type            string       `json:"type"`
+}
+
+func decodeTraces(t *testing.T, raw []json.RawMessage) []*parityTrace {
+	t.Helper()
+	traces := make([]*parityTrace, len(raw))
+	for i, r := range raw {
+		if err := json.Unmarshal(r, &traces[i]); err != nil {
+			t.Fatalf("Failed to decode trace %s: %v", r, err)
+		}
+	}
+	return traces
+}
+
+func TestParityTraceAPI(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender   = crypto.PubkeyToAddress(key.PublicKey)
+		receiver = common.Address{0xee}
+		contract = common.Address{0xcc}
+
+		// SSTORE(0, 0x2a), MSTORE(0, 0x2a), RETURN(0, 32)
+		code    = common.FromHex("602a600055602a60005260206000f3")
+		output  = common.LeftPadBytes([]byte{0x2a}, 32)
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender:   {Balance: big.NewInt(params.Ether)},
+				contract: {Code: code},
+			},
+		}
+		txHashes []common.Hash
+	)
+	backend, teardown := tracers.NewTestBackend(t, 2, genesis, func(i int, b *core.BlockGen) {
+		to := contract
+		if i == 1 {
+			to = receiver
+		}
+		tx := types.MustSignNewTx(key, b.Signer(), &types.LegacyTx{
+			Nonce:    b.TxNonce(sender),
+			To:       &to,
+			Value:    big.NewInt(1000),
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+		})
+		b.AddTx(tx)
+		txHashes = append(txHashes, tx.Hash())
+	})
+	defer teardown()
+
+	api := tracers.NewTraceAPI(backend)
+	ctx := context.Background()
+
+	// Check the call and reward traces of a block
+	raw, err := api.Block(ctx, rpc.BlockNumber(1))
+	if err != nil {
+		t.Fatalf("Failed to trace block: %v", err)
+	}
+	traces := decodeTraces(t, raw)
+	if len(traces) != 2 {
+		t.Fatalf("Unexpected trace count: have %d, want 2", len(traces))
+	}
+	if call := traces[0]; call.Type != "call" || call.Action.CallType != "call" || *call.Action.From != sender || *call.Action.To != contract ||
+		*call.TransactionHash != txHashes[0] || call.Result == nil || !bytes.Equal(call.Result.Output, output) {
+		t.Fatalf("Unexpected call trace: %s", raw[0])
+	}
+	if reward := traces[1]; reward.Type != "reward" || *reward.Action.Author != (common.Address{}) || reward.Action.Value.ToInt().Cmp(big.NewInt(2e18)) != 0 {
+		t.Fatalf("Unexpected reward trace: %s", raw[1])
+	}
+	// Check the traces of a single transaction
+	raw, err = api.Transaction(ctx, txHashes[1])
+	if err != nil {
+		t.Fatalf("Failed to trace transaction: %v", err)
+	}
+	if traces := decodeTraces(t, raw); len(traces) != 1 || traces[0].BlockNumber != 2 || *traces[0].Action.To != receiver {
+		t.Fatalf("Unexpected transaction traces: %s", raw)
+	}
+	// Check the filtering of the traces
+	var (
+		from, to = rpc.BlockNumber(1), rpc.BlockNumber(2)
+		after    = uint64(1)
+		count    = uint64(1)
+	)
+	filters := []struct {
+		args tracers.TraceFilterArgs
+		want []common.Hash
+	}{
+		{tracers.TraceFilterArgs{FromBlock: &from, ToBlock: &to}, []common.Hash{txHashes[0], {}, txHashes[1], {}}},
+		{tracers.TraceFilterArgs{FromBlock: &from, ToBlock: &to, FromAddress: []common.Address{sender}}, txHashes},
+		{tracers.TraceFilterArgs{FromBlock: &from, ToBlock: &to, ToAddress: []common.Address{receiver}}, txHashes[1:]},
+		{tracers.TraceFilterArgs{FromBlock: &from, ToBlock: &to, FromAddress: []common.Address{sender}, ToAddress: []common.Address{receiver}}, txHashes[1:]},
+		{tracers.TraceFilterArgs{FromBlock: &from, ToBlock: &to, FromAddress: []common.Address{sender}, After: &after, Count: &count}, txHashes[1:]},
+		{tracers.TraceFilterArgs{FromBlock: &from, ToBlock: &to, FromAddress: []common.Address{receiver}}, nil},
+	}
+	for i, filter := range filters {
+		raw, err := api.Filter(ctx, filter.args)
+		if err != nil {
+			t.Fatalf("Filter %d: failed to filter traces: %v", i, err)
+		}
+		traces := decodeTraces(t, raw)
+		if len(traces) != len(filter.want) {
+			t.Fatalf("Filter %d: unexpected trace count: have %d, want %d", i, len(traces), len(filter.want))
+		}
+		for j, trace := range traces {
+			var hash common.Hash
+			if trace.TransactionHash != nil {
+				hash = *trace.TransactionHash
+			}
+			if hash != filter.want[j] {
+				t.Fatalf("Filter %d: trace %d: unexpected transaction %x, want %x", i, j, hash, filter.want[j])
+			}
+		}
+	}
+	// Check the replayed outputs of a block
+	results, err := api.ReplayBlockTransactions(ctx, rpc.BlockNumberOrHashWithNumber(1), []string{"trace", "stateDiff", "vmTrace"})
+	if err != nil {
+		t.Fatalf("Failed to replay block: %v", err)
+	}
+	if len(results) != 1 || *results[0].TransactionHash != txHashes[0] || !bytes.Equal(results[0].Output, output) {
+		t.Fatalf("Unexpected replay results: %+v", results)
+	}
+	var calls []json.RawMessage
+	if err := json.Unmarshal(results[0].Trace, &calls); err != nil || len(calls) != 1 {
+		t.Fatalf("Unexpected replayed trace: %s", results[0].Trace)
+	}
+	var diff map[common.Address]struct {
+		Nonce   json.RawMessage                        `json:"nonce"`
+		Code    json.RawMessage                        `json:"code"`
+		Storage map[common.Hash]map[string]interface{} `json:"storage"`
+	}
+	if err := json.Unmarshal(results[0].StateDiff, &diff); err != nil {
+		t.Fatalf("Failed to decode state diff: %v", err)
+	}
+	if nonce := string(diff[sender].Nonce); nonce != `{"*":{"from":"0x0","to":"0x1"}}` {
+		t.Fatalf("Unexpected sender nonce diff: %s", nonce)
+	}
+	if code := string(diff[contract].Code); code != `"="` {
+		t.Fatalf("Unexpected contract code diff: %s", code)
+	}
+	slot := diff[contract].Storage[common.Hash{}]["*"].(map[string]interface{})
+	if slot["from"] != (common.Hash{}).Hex() || slot["to"] != common.BytesToHash([]byte{0x2a}).Hex() {
+		t.Fatalf("Unexpected contract storage diff: %v", slot)
+	}
+	// Check the account created by the transfer is reported as born
+	replayed, err := api.ReplayBlockTransactions(ctx, rpc.BlockNumberOrHashWithNumber(2), []string{"stateDiff"})
+	if err != nil {
+		t.Fatalf("Failed to replay block: %v", err)
+	}
+	var created map[common.Address]struct {
+		Balance json.RawMessage `json:"balance"`
+		Nonce   json.RawMessage `json:"nonce"`
+	}
+	if err := json.Unmarshal(replayed[0].StateDiff, &created); err != nil {
+		t.Fatalf("Failed to decode state diff: %v", err)
+	}
+	if balance, nonce := string(created[receiver].Balance), string(created[receiver].Nonce); balance != `{"+":"0x3e8"}` || nonce != `{"+":"0x0"}` {
+		t.Fatalf("Unexpected created account diff: balance %s, nonce %s", balance, nonce)
+	}
+	var vmTrace struct {
+		Code hexutil.Bytes `json:"code"`
+		Ops  []struct {
+			Pc   uint64 `json:"pc"`
+			Cost uint64 `json:"cost"`
+			Ex   struct {
+				Push []string `json:"push"`
+				Used uint64   `json:"used"`
+				Mem  *struct {
+					Off  uint64        `json:"off"`
+					Data hexutil.Bytes `json:"data"`
+				} `json:"mem"`
+				Store *struct {
+					Key string `json:"key"`
+					Val string `json:"val"`
+				} `json:"store"`
+			} `json:"ex"`
+		} `json:"ops"`
+	}
+	if err := json.Unmarshal(results[0].VMTrace, &vmTrace); err != nil {
+		t.Fatalf("Failed to decode vm trace: %v", err)
+	}
+	if !bytes.Equal(vmTrace.Code, code) || len(vmTrace.Ops) != 9 {
+		t.Fatalf("Unexpected vm trace: %s", results[0].VMTrace)
+	}
+	if ops := vmTrace.Ops; len(ops[0].Ex.Push) != 1 || ops[0].Ex.Push[0] != "0x2a" || ops[0].Ex.Used != ops[1].Ex.Used+ops[1].Cost ||
+		ops[2].Ex.Store == nil || ops[2].Ex.Store.Key != "0x0" || ops[2].Ex.Store.Val != "0x2a" ||
+		ops[5].Ex.Mem == nil || ops[5].Ex.Mem.Off != 0 || !bytes.Equal(ops[5].Ex.Mem.Data, output) {
+		t.Fatalf("Unexpected vm trace ops: %s", results[0].VMTrace)
+	}
+	// Check the outputs of a call, with only the requested ones filled
+	data := hexutil.Bytes{}
+	result, err := api.Call(ctx, ethapi.TransactionArgs{From: &receiver, To: &contract, Data: &data}, []string{"stateDiff"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to trace call: %v", err)
+	}
+	if !bytes.Equal(result.Output, output) || result.Trace != nil || result.VMTrace != nil || result.StateDiff == nil {
+		t.Fatalf("Unexpected call results: %+v", result)
+	}
+	if _, err := api.Call(ctx, ethapi.TransactionArgs{From: &receiver, To: &contract}, []string{"unknown"}, nil); err == nil {
+		t.Fatal("Unsupported trace type accepted")
+	}
+}
@@ -67,7 +67,7 @@ func newTestBackend(t *testing.T) (*node.Node, []*types.Block) {
 	if err != nil {
 		t.Fatalf("can't create new ethereum service: %v", err)
 	}
-	n.RegisterAPIs(tracers.APIs(ethservice.APIBackend))
+	n.RegisterAPIs(tracers.APIs(ethservice.APIBackend, nil))
 
 	filterSystem := filters.NewFilterSystem(ethservice.APIBackend, filters.Config{})
 	n.RegisterAPIs([]rpc.API{{
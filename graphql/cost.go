@@ -0,0 +1,125 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	gqlErrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace"
+)
+
+// fieldCosts are the costs of resolving the expensive fields, which re-execute
+// transactions or iterate the state. All other fields cost one unit each time
+// they are resolved.
+var fieldCosts = map[string]uint64{
+	"Transaction.trace":    100,
+	"Block.stateDiff":      1000,
+	"Account.storageRange": 100,
+}
+
+// queryBudget tracks the cost spent by a query against its limit.
+type queryBudget struct {
+	limit    uint64
+	spent    atomic.Uint64
+	exceeded atomic.Bool
+	cancel   context.CancelFunc
+}
+
+type budgetKey struct{}
+
+// withBudget attaches a cost budget to the context of a query. The given cancel
+// function is invoked to abort the query once the budget is exceeded.
+func withBudget(ctx context.Context, limit uint64, cancel context.CancelFunc) (context.Context, *queryBudget) {
+	budget := &queryBudget{limit: limit, cancel: cancel}
+	return context.WithValue(ctx, budgetKey{}, budget), budget
+}
+
+// charge adds the given cost to the budget, aborting the query if the limit is
+// exceeded.
+func (b *queryBudget) charge(cost uint64) {
+	if b.spent.Add(cost) > b.limit {
+		b.exceeded.Store(true)
+		b.cancel()
+	}
+}
+
+// subscriptionBudget hands out a budget of its own to each event of a subscription.
+// The executor resolves the events one by one, each under a context derived from
+// the one of the subscription, so a new event is recognised by its context.
+type subscriptionBudget struct {
+	limit    uint64
+	exceeded atomic.Bool
+	cancel   context.CancelFunc
+
+	lock  sync.Mutex
+	event <-chan struct{} // Done channel of the context of the current event
+	spent *queryBudget    // Budget of the current event
+}
+
+// withSubscriptionBudget attaches a per-event cost budget to the context of a
+// subscription. The given cancel function is invoked to abort the subscription
+// once the budget of an event is exceeded.
+func withSubscriptionBudget(ctx context.Context, limit uint64, cancel context.CancelFunc) (context.Context, *subscriptionBudget) {
+	budget := &subscriptionBudget{limit: limit, cancel: cancel}
+	return context.WithValue(ctx, budgetKey{}, budget), budget
+}
+
+// budget returns the budget of the event resolved under the given context.
+func (b *subscriptionBudget) budget(ctx context.Context) *queryBudget {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if done := ctx.Done(); b.spent == nil || done != b.event {
+		b.event = done
+		b.spent = &queryBudget{limit: b.limit, cancel: func() {
+			b.exceeded.Store(true)
+			b.cancel()
+		}}
+	}
+	return b.spent
+}
+
+// costTracer charges the resolution of each field to the budget of the query.
+// The executor doesn't invoke resolvers anymore once the query is aborted, so
+// the fields exceeding the budget are refused without doing the work.
+type costTracer struct{}
+
+func (costTracer) TraceQuery(ctx context.Context, queryString string, operationName string, variables map[string]interface{}, varTypes map[string]*introspection.Type) (context.Context, trace.TraceQueryFinishFunc) {
+	return ctx, func([]*gqlErrors.QueryError) {}
+}
+
+func (costTracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	var budget *queryBudget
+	switch b := ctx.Value(budgetKey{}).(type) {
+	case *queryBudget:
+		budget = b
+	case *subscriptionBudget:
+		budget = b.budget(ctx)
+	}
+	if budget != nil {
+		cost, ok := fieldCosts[typeName+"."+fieldName]
+		if !ok {
+			cost = 1
+		}
+		budget.charge(cost)
+	}
+	return ctx, func(*gqlErrors.QueryError) {}
+}
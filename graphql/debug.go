@@ -0,0 +1,333 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// maxStorageRangeLimit is the maximum number of storage slots returned by a
+// single storage range query.
+const maxStorageRangeLimit = 1024
+
+var (
+	errTracingUnavailable = errors.New("tracing is not supported by the backend")
+	errStorageRangeLimit  = fmt.Errorf("storage range limit must be between 1 and %d", maxStorageRangeLimit)
+)
+
+func (t *Transaction) Trace(ctx context.Context, args struct {
+	Tracer *string
+	Config *JSON
+}) (*JSON, error) {
+	if t.r.tracer == nil {
+		return nil, errTracingUnavailable
+	}
+	tx, block := t.resolve(ctx)
+	if tx == nil || block == nil {
+		return nil, nil
+	}
+	config := &tracers.TraceConfig{Tracer: args.Tracer}
+	if args.Config != nil {
+		config.TracerConfig = json.RawMessage(*args.Config)
+	}
+	result, err := t.r.tracer.TraceTransaction(ctx, t.hash, config)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	trace := JSON(enc)
+	return &trace, nil
+}
+
+// StorageRange is a range of storage slots of an account.
+type StorageRange struct {
+	entries []*StorageEntry
+	nextKey *common.Hash
+}
+
+func (s *StorageRange) Entries(ctx context.Context) []*StorageEntry {
+	return s.entries
+}
+
+func (s *StorageRange) NextKey(ctx context.Context) *common.Hash {
+	return s.nextKey
+}
+
+// StorageEntry is a storage slot of an account.
+type StorageEntry struct {
+	hashedKey common.Hash
+	key       *common.Hash
+	value     common.Hash
+}
+
+func (e *StorageEntry) HashedKey(ctx context.Context) common.Hash {
+	return e.hashedKey
+}
+
+func (e *StorageEntry) Key(ctx context.Context) *common.Hash {
+	return e.key
+}
+
+func (e *StorageEntry) Value(ctx context.Context) common.Hash {
+	return e.value
+}
+
+func (a *Account) StorageRange(ctx context.Context, args struct {
+	Start *common.Hash
+	Limit int32
+}) (*StorageRange, error) {
+	if args.Limit <= 0 || args.Limit > maxStorageRangeLimit {
+		return nil, errStorageRangeLimit
+	}
+	// The storage is iterated from the trie, which isn't committed for the
+	// pending state.
+	if blockNr, ok := a.blockNrOrHash.Number(); ok && blockNr == rpc.PendingBlockNumber {
+		return nil, errors.New("storage range is not available for the pending state")
+	}
+	statedb, header, err := a.r.backend.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	result := new(StorageRange)
+
+	storageRoot := statedb.GetStorageRoot(a.address)
+	if storageRoot == types.EmptyRootHash || storageRoot == (common.Hash{}) {
+		return result, nil // empty storage
+	}
+	id := trie.StorageTrieID(header.Root, crypto.Keccak256Hash(a.address.Bytes()), storageRoot)
+	tr, err := trie.NewStateTrie(id, statedb.Database().TrieDB())
+	if err != nil {
+		return nil, err
+	}
+	var start []byte
+	if args.Start != nil {
+		start = args.Start.Bytes()
+	}
+	nodeIt, err := tr.NodeIterator(start)
+	if err != nil {
+		return nil, err
+	}
+	it := trie.NewIterator(nodeIt)
+	for i := 0; i < int(args.Limit) && it.Next(); i++ {
+		_, content, _, err := rlp.Split(it.Value)
+		if err != nil {
+			return nil, err
+		}
+		entry := &StorageEntry{
+			hashedKey: common.BytesToHash(it.Key),
+			value:     common.BytesToHash(content),
+		}
+		if preimage := tr.GetKey(it.Key); preimage != nil {
+			key := common.BytesToHash(preimage)
+			entry.key = &key
+		}
+		result.entries = append(result.entries, entry)
+	}
+	// Add the next key if the range didn't reach the end of the storage
+	if it.Next() {
+		next := common.BytesToHash(it.Key)
+		result.nextKey = &next
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+	return result, nil
+}
+
+// AccountDiff is the change of an account caused by a block.
+type AccountDiff struct {
+	address       common.Address
+	balanceBefore hexutil.Big
+	balanceAfter  hexutil.Big
+	nonceBefore   uint64
+	nonceAfter    uint64
+	codeBefore    []byte
+	codeAfter     []byte
+	storage       []*StorageDiff
+}
+
+func (d *AccountDiff) Address(ctx context.Context) common.Address {
+	return d.address
+}
+
+func (d *AccountDiff) BalanceBefore(ctx context.Context) hexutil.Big {
+	return d.balanceBefore
+}
+
+func (d *AccountDiff) BalanceAfter(ctx context.Context) hexutil.Big {
+	return d.balanceAfter
+}
+
+func (d *AccountDiff) NonceBefore(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(d.nonceBefore)
+}
+
+func (d *AccountDiff) NonceAfter(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(d.nonceAfter)
+}
+
+func (d *AccountDiff) CodeBefore(ctx context.Context) hexutil.Bytes {
+	return d.codeBefore
+}
+
+func (d *AccountDiff) CodeAfter(ctx context.Context) hexutil.Bytes {
+	return d.codeAfter
+}
+
+func (d *AccountDiff) Storage(ctx context.Context) []*StorageDiff {
+	return d.storage
+}
+
+// StorageDiff is the change of a storage slot caused by a block.
+type StorageDiff struct {
+	key    common.Hash
+	before common.Hash
+	after  common.Hash
+}
+
+func (d *StorageDiff) Key(ctx context.Context) common.Hash {
+	return d.key
+}
+
+func (d *StorageDiff) Before(ctx context.Context) common.Hash {
+	return d.before
+}
+
+func (d *StorageDiff) After(ctx context.Context) common.Hash {
+	return d.after
+}
+
+// blockReplayer is implemented by the backends able to re-execute blocks.
+type blockReplayer interface {
+	ReplayBlock(ctx context.Context, block *types.Block, hooks *tracing.Hooks) error
+}
+
+func (b *Block) StateDiff(ctx context.Context) ([]*AccountDiff, error) {
+	replayer, ok := b.r.backend.(blockReplayer)
+	if !ok {
+		return nil, errTracingUnavailable
+	}
+	block, err := b.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not executable")
+	}
+	// Collect the accounts and storage slots modified while processing the block,
+	// the actual values are read from the states around the block afterwards.
+	touched := make(map[common.Address]map[common.Hash]struct{})
+	touch := func(addr common.Address) map[common.Hash]struct{} {
+		if _, ok := touched[addr]; !ok {
+			touched[addr] = make(map[common.Hash]struct{})
+		}
+		return touched[addr]
+	}
+	hooks := &tracing.Hooks{
+		OnBalanceChange: func(addr common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+			touch(addr)
+		},
+		OnNonceChangeV2: func(addr common.Address, prev, new uint64, reason tracing.NonceChangeReason) {
+			touch(addr)
+		},
+		OnCodeChange: func(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+			touch(addr)
+		},
+		OnStorageChange: func(addr common.Address, slot common.Hash, prev, new common.Hash) {
+			touch(addr)[slot] = struct{}{}
+		},
+	}
+	if err := replayer.ReplayBlock(ctx, block, hooks); err != nil {
+		return nil, err
+	}
+	// The DAO hard-fork is applied to the state directly, bypassing the hooks
+	config := b.r.backend.ChainConfig()
+	if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(block.Number()) == 0 {
+		touch(params.DAORefundContract)
+		for _, addr := range params.DAODrainList() {
+			touch(addr)
+		}
+	}
+	before, _, err := b.r.backend.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithHash(block.ParentHash(), false))
+	if err != nil {
+		return nil, err
+	}
+	after, _, err := b.r.backend.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]common.Address, 0, len(touched))
+	for addr := range touched {
+		addrs = append(addrs, addr)
+	}
+	slices.SortFunc(addrs, common.Address.Cmp)
+
+	diffs := make([]*AccountDiff, 0, len(addrs))
+	for _, addr := range addrs {
+		keys := make([]common.Hash, 0, len(touched[addr]))
+		for key := range touched[addr] {
+			keys = append(keys, key)
+		}
+		slices.SortFunc(keys, common.Hash.Cmp)
+
+		diff := &AccountDiff{
+			address:       addr,
+			balanceBefore: hexutil.Big(*before.GetBalance(addr).ToBig()),
+			balanceAfter:  hexutil.Big(*after.GetBalance(addr).ToBig()),
+			nonceBefore:   before.GetNonce(addr),
+			nonceAfter:    after.GetNonce(addr),
+			codeBefore:    before.GetCode(addr),
+			codeAfter:     after.GetCode(addr),
+		}
+		for _, key := range keys {
+			prev, next := before.GetState(addr, key), after.GetState(addr, key)
+			if prev != next {
+				diff.storage = append(diff.storage, &StorageDiff{key: key, before: prev, after: next})
+			}
+		}
+		// Skip the accounts which were only touched
+		if len(diff.storage) == 0 && diff.nonceBefore == diff.nonceAfter &&
+			diff.balanceBefore.ToInt().Cmp(diff.balanceAfter.ToInt()) == 0 &&
+			before.GetCodeHash(addr) == after.GetCodeHash(addr) {
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
@@ -19,6 +19,7 @@ package graphql
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -34,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -77,6 +79,37 @@ func (b *Long) UnmarshalGraphQL(input interface{}) error {
 	return err
 }
 
+// JSON is an arbitrary JSON value.
+type JSON json.RawMessage
+
+// ImplementsGraphQLType returns true if JSON implements the provided GraphQL type.
+func (j JSON) ImplementsGraphQLType(name string) bool { return name == "JSON" }
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (j *JSON) UnmarshalGraphQL(input interface{}) error {
+	if input, ok := input.(string); ok {
+		if !json.Valid([]byte(input)) {
+			return errors.New("invalid JSON value")
+		}
+		*j = JSON(input)
+		return nil
+	}
+	enc, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	*j = enc
+	return nil
+}
+
+// MarshalJSON embeds the value into the response as is.
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
 // Account represents an Ethereum account at a particular block.
 type Account struct {
 	r             *Resolver
@@ -169,6 +202,10 @@ func (l *Log) Data(ctx context.Context) hexutil.Bytes {
 	return l.log.Data
 }
 
+func (l *Log) Removed(ctx context.Context) bool {
+	return l.log.Removed
+}
+
 // AccessTuple represents EIP-2930
 type AccessTuple struct {
 	address     common.Address
@@ -1279,6 +1316,7 @@ func (p *Pending) EstimateGas(ctx context.Context, args struct {
 type Resolver struct {
 	backend      ethapi.Backend
 	filterSystem *filters.FilterSystem
+	tracer       *tracers.API // nil if the backend doesn't support tracing
 }
 
 func (r *Resolver) Block(ctx context.Context, args struct {
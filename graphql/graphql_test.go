@@ -23,6 +23,7 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -40,8 +41,12 @@ import (
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/gorilla/websocket"
 
 	"github.com/stretchr/testify/assert"
+
+	// Force-load the native tracers backing the transaction traces
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
 )
 
 func TestBuildSchema(t *testing.T) {
@@ -55,7 +60,7 @@ func TestBuildSchema(t *testing.T) {
 	}
 	defer stack.Close()
 	// Make sure the schema can be parsed and matched up to the object model.
-	if _, err := newHandler(stack, nil, nil, []string{}, []string{}); err != nil {
+	if _, err := newHandler(stack, nil, nil, []string{}, []string{}, 0); err != nil {
 		t.Errorf("Could not construct GraphQL handler: %v", err)
 	}
 }
@@ -430,6 +435,275 @@ func TestWithdrawals(t *testing.T) {
 	}
 }
 
+// Tests that subscriptions are served over websockets on the GraphQL endpoint.
+func TestGraphQLSubscriptions(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000000000)
+	)
+	stack := createNode(t)
+	defer stack.Close()
+	genesis := &core.Genesis{
+		Config:     params.AllEthashProtocolChanges,
+		GasLimit:   11500000,
+		Difficulty: big.NewInt(1048576),
+		Alloc:      types.GenesisAlloc{address: {Balance: funds}},
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+	}
+	newGQLService(t, stack, false, genesis, 0, func(i int, gen *core.BlockGen) {})
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	url := "ws" + strings.TrimPrefix(stack.HTTPEndpoint(), "http") + "/graphql"
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-transport-ws"}}
+
+	// Subscribing before initialising the connection is refused
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	conn.WriteJSON(wsMessage{ID: "1", Type: "subscribe", Payload: json.RawMessage(`{"query":"subscription { newHeads { number } }"}`)})
+	if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, closeUnauthorized) {
+		t.Fatalf("expected unauthorized close, got %v", err)
+	}
+	conn.Close()
+
+	// Subscribe to pending transactions and send one through the mutation
+	conn, _, err = dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	var msg wsMessage
+	conn.WriteJSON(wsMessage{Type: "connection_init"})
+	if err := conn.ReadJSON(&msg); err != nil || msg.Type != "connection_ack" {
+		t.Fatalf("expected connection ack, got %v (err %v)", msg.Type, err)
+	}
+	conn.WriteJSON(wsMessage{ID: "1", Type: "subscribe", Payload: json.RawMessage(`{"query":"subscription { pendingTransactions { hash from { address } } }"}`)})
+	conn.WriteJSON(wsMessage{Type: "ping"})
+	if err := conn.ReadJSON(&msg); err != nil || msg.Type != "pong" {
+		t.Fatalf("expected pong, got %v (err %v)", msg.Type, err)
+	}
+	tx, _ := types.SignNewTx(key, types.LatestSigner(genesis.Config), &types.LegacyTx{
+		Nonce:    0,
+		To:       &common.Address{0xaa},
+		Value:    big.NewInt(100),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(2 * params.InitialBaseFee),
+	})
+	enc, _ := tx.MarshalBinary()
+	body := fmt.Sprintf(`{"query": "mutation { sendRawTransaction(data: \"%#x\") }"}`, enc)
+	resp, err := http.Post(fmt.Sprintf("%s/graphql", stack.HTTPEndpoint()), "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("could not post: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("failed to send transaction: status %d", resp.StatusCode)
+	}
+	if err := conn.ReadJSON(&msg); err != nil || msg.Type != "next" || msg.ID != "1" {
+		t.Fatalf("expected subscription result, got %v (err %v)", msg.Type, err)
+	}
+	want := fmt.Sprintf(`{"data":{"pendingTransactions":{"hash":"%s","from":{"address":"%s"}}}}`, tx.Hash().Hex(), strings.ToLower(address.Hex()))
+	if have := string(msg.Payload); have != want {
+		t.Errorf("subscription result mismatch:\nhave:\n%s\nwant:\n%s", have, want)
+	}
+	// Completing the subscription frees up its id
+	conn.WriteJSON(wsMessage{ID: "1", Type: "complete"})
+	conn.WriteJSON(wsMessage{ID: "1", Type: "subscribe", Payload: json.RawMessage(`{"query":"subscription { newHeads { number } }"}`)})
+	conn.WriteJSON(wsMessage{Type: "ping"})
+	if err := conn.ReadJSON(&msg); err != nil || msg.Type != "pong" {
+		t.Fatalf("expected pong, got %v (err %v)", msg.Type, err)
+	}
+}
+
+// Tests that the events of subscriptions are resolved within the query cost limit,
+// aborting the subscriptions exceeding it.
+func TestGraphQLSubscriptionCost(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000000000)
+	)
+	stack := createNode(t)
+	defer stack.Close()
+	genesis := &core.Genesis{
+		Config:     params.AllEthashProtocolChanges,
+		GasLimit:   11500000,
+		Difficulty: big.NewInt(1048576),
+		Alloc:      types.GenesisAlloc{address: {Balance: funds}},
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+	}
+	handler, _ := newGQLService(t, stack, false, genesis, 0, func(i int, gen *core.BlockGen) {})
+	handler.subscriptions.costLimit = 3
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	url := "ws" + strings.TrimPrefix(stack.HTTPEndpoint(), "http") + "/graphql"
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-transport-ws"}}
+
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	var msg wsMessage
+	conn.WriteJSON(wsMessage{Type: "connection_init"})
+	if err := conn.ReadJSON(&msg); err != nil || msg.Type != "connection_ack" {
+		t.Fatalf("expected connection ack, got %v (err %v)", msg.Type, err)
+	}
+	// Subscribe with a query within the limit and another one exceeding it
+	conn.WriteJSON(wsMessage{ID: "cheap", Type: "subscribe", Payload: json.RawMessage(`{"query":"subscription { pendingTransactions { hash from { address } } }"}`)})
+	conn.WriteJSON(wsMessage{ID: "costly", Type: "subscribe", Payload: json.RawMessage(`{"query":"subscription { pendingTransactions { hash nonce from { address balance } } }"}`)})
+	conn.WriteJSON(wsMessage{Type: "ping"})
+	if err := conn.ReadJSON(&msg); err != nil || msg.Type != "pong" {
+		t.Fatalf("expected pong, got %v (err %v)", msg.Type, err)
+	}
+	// Send multiple transactions to ensure the budget is not shared by the events
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx, _ := types.SignNewTx(key, types.LatestSigner(genesis.Config), &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &common.Address{0xaa},
+			Value:    big.NewInt(100),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+		})
+		enc, _ := tx.MarshalBinary()
+		body := fmt.Sprintf(`{"query": "mutation { sendRawTransaction(data: \"%#x\") }"}`, enc)
+		resp, err := http.Post(fmt.Sprintf("%s/graphql", stack.HTTPEndpoint()), "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("could not post: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("failed to send transaction: status %d", resp.StatusCode)
+		}
+	}
+	var results int
+	for i := 0; i < 3; i++ {
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("failed to read subscription message: %v", err)
+		}
+		switch msg.ID {
+		case "cheap":
+			if msg.Type != "next" || strings.Contains(string(msg.Payload), "errors") {
+				t.Errorf("cheap subscription: unexpected message %v: %s", msg.Type, msg.Payload)
+			}
+			results++
+		case "costly":
+			if msg.Type != "error" || !strings.Contains(string(msg.Payload), "query cost limit of 3 exceeded") {
+				t.Errorf("costly subscription: unexpected message %v: %s", msg.Type, msg.Payload)
+			}
+		default:
+			t.Fatalf("unexpected subscription id %q", msg.ID)
+		}
+	}
+	if results != 2 {
+		t.Errorf("cheap subscription result count mismatch: have %d, want 2", results)
+	}
+}
+
+// Tests the fields re-executing transactions or iterating the state, along with
+// the query cost limit guarding them.
+func TestGraphQLTracing(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000000000)
+		dad     = common.HexToAddress("0x0000000000000000000000000000000000000dad")
+	)
+	stack := createNode(t)
+	defer stack.Close()
+	genesis := &core.Genesis{
+		Config:     params.AllEthashProtocolChanges,
+		GasLimit:   11500000,
+		Difficulty: big.NewInt(1048576),
+		Alloc: types.GenesisAlloc{
+			address: {Balance: funds},
+			// The address 0xdad sstores 0x2a into slot 0x01
+			dad: {
+				Code:    []byte{byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0x01, byte(vm.SSTORE)},
+				Storage: map[common.Hash]common.Hash{{}: {0x01}},
+				Balance: big.NewInt(0),
+			},
+		},
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	signer := types.LatestSigner(genesis.Config)
+	handler, _ := newGQLService(t, stack, false, genesis, 1, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(common.Address{1})
+		tx, _ := types.SignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(0),
+			To:       &dad,
+			Gas:      50000,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+		})
+		gen.AddTx(tx)
+	})
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{
+			body: `{"query": "{block{transactions{trace(tracer: \"callTracer\", config: {onlyTopCall: true})}}}"}`,
+			want: `{"data":{"block":{"transactions":[{"trace":{"from":"0x71562b71999873db5b286df957af199ec94617f7","gas":"0xc350","gasUsed":"0xa862","to":"0x0000000000000000000000000000000000000dad","input":"0x","value":"0x0","type":"CALL"}}]}}}`,
+		},
+		{
+			body: `{"query": "{block{stateDiff{address nonceBefore nonceAfter storage{key before after}}}}"}`,
+			want: `{"data":{"block":{"stateDiff":[{"address":"0x0000000000000000000000000000000000000dad","nonceBefore":"0x0","nonceAfter":"0x0","storage":[{"key":"0x0000000000000000000000000000000000000000000000000000000000000001","before":"0x0000000000000000000000000000000000000000000000000000000000000000","after":"0x000000000000000000000000000000000000000000000000000000000000002a"}]},{"address":"0x0100000000000000000000000000000000000000","nonceBefore":"0x0","nonceAfter":"0x0","storage":[]},{"address":"0x71562b71999873db5b286df957af199ec94617f7","nonceBefore":"0x0","nonceAfter":"0x1","storage":[]}]}}}`,
+		},
+		{
+			body: `{"query": "{block{account(address: \"0x0000000000000000000000000000000000000dad\"){storageRange(limit: 1){entries{value} nextKey}}}}"}`,
+			want: `{"data":{"block":{"account":{"storageRange":{"entries":[{"value":"0x0100000000000000000000000000000000000000000000000000000000000000"}],"nextKey":"0xb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf6"}}}}}`,
+		},
+		{
+			body: `{"query": "{block(number: 0){account(address: \"0x0000000000000000000000000000000000000dad\"){storageRange(limit: 10){entries{value} nextKey}}}}"}`,
+			want: `{"data":{"block":{"account":{"storageRange":{"entries":[{"value":"0x0100000000000000000000000000000000000000000000000000000000000000"}],"nextKey":null}}}}}`,
+		},
+	} {
+		resp, err := http.Post(fmt.Sprintf("%s/graphql", stack.HTTPEndpoint()), "application/json", strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatalf("could not post: %v", err)
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("could not read from response body: %v", err)
+		}
+		if have := string(bodyBytes); have != tt.want {
+			t.Errorf("testcase %d %s,\nhave:\n%v\nwant:\n%v", i, tt.body, have, tt.want)
+		}
+	}
+	// Ensure expensive queries are refused once the cost limit is reached
+	limited := *handler
+	limited.costLimit = 500
+	for i, tt := range []struct {
+		body string
+		code int
+	}{
+		{body: `{"query": "{block{number transactions{trace}}}"}`, code: http.StatusOK},
+		{body: `{"query": "{block{number stateDiff{address}}}"}`, code: http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body))
+		rec := httptest.NewRecorder()
+		limited.ServeHTTP(rec, req)
+		if rec.Code != tt.code {
+			t.Errorf("testcase %d: status mismatch: have %d, want %d (%s)", i, rec.Code, tt.code, rec.Body)
+		}
+		if tt.code == http.StatusBadRequest && !strings.Contains(rec.Body.String(), "query cost limit of 500 exceeded") {
+			t.Errorf("testcase %d: unexpected response: %s", i, rec.Body)
+		}
+	}
+}
+
 func createNode(t *testing.T) *node.Node {
 	stack, err := node.New(&node.Config{
 		HTTPHost:     "127.0.0.1",
@@ -478,7 +752,7 @@ func newGQLService(t *testing.T, stack *node.Node, shanghai bool, gspec *core.Ge
 	}
 	// Set up handler
 	filterSystem := filters.NewFilterSystem(ethBackend.APIBackend, filters.Config{})
-	handler, err := newHandler(stack, ethBackend.APIBackend, filterSystem, []string{}, []string{})
+	handler, err := newHandler(stack, ethBackend.APIBackend, filterSystem, []string{}, []string{}, 0)
 	if err != nil {
 		t.Fatalf("could not create graphql service: %v", err)
 	}
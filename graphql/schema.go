@@ -16,7 +16,33 @@
 
 package graphql
 
+// schema is the GraphQL schema answering queries and mutations.
 const schema string = `
+    schema {
+        query: Query
+        mutation: Mutation
+    }
+` + typeDefs
+
+// subscriptionSchema is the GraphQL schema answering subscriptions. It has a
+// root resolver of its own, as the logs subscription would otherwise clash with
+// the logs query.
+const subscriptionSchema string = `
+    schema {
+        query: SubscriptionQuery
+        subscription: Subscription
+    }
+
+    # SubscriptionQuery is the query root of the subscription endpoint. Queries
+    # are meant to be sent to the regular endpoint.
+    type SubscriptionQuery {
+        # ChainID returns the current chain ID for transaction replay protection.
+        chainID: BigInt!
+    }
+` + typeDefs
+
+// typeDefs are the type definitions shared by the query and subscription schemas.
+const typeDefs string = `
     # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
     scalar Bytes32
     # Address is a 20 byte Ethereum address, represented as 0x-prefixed hexadecimal.
@@ -32,11 +58,9 @@ const schema string = `
     # Strings may be either decimal or 0x-prefixed hexadecimal. Output values are all
     # 0x-prefixed hexadecimal.
     scalar Long
-
-    schema {
-        query: Query
-        mutation: Mutation
-    }
+    # JSON is an arbitrary JSON value. Input is accepted as either a JSON-encoded
+    # string or as a GraphQL object.
+    scalar JSON
 
     # Account is an Ethereum account at a particular block.
     type Account {
@@ -54,6 +78,59 @@ const schema string = `
         # Storage provides access to the storage of a contract account, indexed
         # by its 32 byte slot identifier.
         storage(slot: Bytes32!): Bytes32!
+        # StorageRange returns up to limit storage slots of a contract account,
+        # ordered by the hash of their slot identifier and starting at the given
+        # hashed slot. The limit may be at most 1024.
+        storageRange(start: Bytes32, limit: Int!): StorageRange!
+    }
+
+    # StorageRange is a range of storage slots of an account.
+    type StorageRange {
+        # Entries are the storage slots in the range.
+        entries: [StorageEntry!]!
+        # NextKey is the hashed slot identifier following the range, or null if
+        # the range reached the end of the storage.
+        nextKey: Bytes32
+    }
+
+    # StorageEntry is a storage slot of an account.
+    type StorageEntry {
+        # HashedKey is the hash of the slot identifier.
+        hashedKey: Bytes32!
+        # Key is the slot identifier, null if its preimage is unknown.
+        key: Bytes32
+        # Value is the value of the storage slot.
+        value: Bytes32!
+    }
+
+    # AccountDiff is the change of an account caused by a block.
+    type AccountDiff {
+        # Address is the address of the modified account.
+        address: Address!
+        # BalanceBefore is the balance of the account before the block, in wei.
+        balanceBefore: BigInt!
+        # BalanceAfter is the balance of the account after the block, in wei.
+        balanceAfter: BigInt!
+        # NonceBefore is the nonce of the account before the block.
+        nonceBefore: Long!
+        # NonceAfter is the nonce of the account after the block.
+        nonceAfter: Long!
+        # CodeBefore is the code of the account before the block.
+        codeBefore: Bytes!
+        # CodeAfter is the code of the account after the block.
+        codeAfter: Bytes!
+        # Storage is the list of modified storage slots of the account.
+        storage: [StorageDiff!]!
+    }
+
+    # StorageDiff is the change of a storage slot caused by a block.
+    type StorageDiff {
+        # Key is the slot identifier.
+        key: Bytes32!
+        # Before is the value of the slot before the block.
+        before: Bytes32!
+        # After is the value of the slot after the block.
+        after: Bytes32!
     }
 
     # Log is an Ethereum event log.
@@ -69,6 +146,9 @@ const schema string = `
         data: Bytes!
         # Transaction is the transaction that generated this log entry.
         transaction: Transaction!
+        # Removed is true if the log was reverted due to a chain reorganisation.
+        # Only logs delivered by subscriptions can be removed.
+        removed: Boolean!
     }
 
     # EIP-2718
@@ -170,6 +250,11 @@ const schema string = `
         rawReceipt: Bytes!
         # BlobVersionedHashes is a set of hash outputs from the blobs in the transaction.
         blobVersionedHashes: [Bytes32!]
+        # Trace returns the execution trace of the transaction produced by the
+        # given tracer, configured with the tracer specific config. The opcode
+        # logger is used if no tracer is given. Pending transactions can't be
+        # traced, null is returned for them.
+        trace(tracer: String, config: JSON): JSON
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -276,6 +361,11 @@ const schema string = `
         blobGasUsed: Long
         # ExcessBlobGas is a running total of blob gas consumed in excess of the target, prior to the block.
         excessBlobGas: Long
+        # StateDiff returns the accounts modified by the block, along with their
+        # state before and after it. Next to the accounts modified by transactions,
+        # the fee recipient, the withdrawal recipients and the system contracts
+        # updated around the transactions are included.
+        stateDiff: [AccountDiff!]!
     }
 
     # CallData represents the data associated with a local contract call.
@@ -388,4 +478,17 @@ const schema string = `
         # SendRawTransaction sends an RLP-encoded transaction to the network.
         sendRawTransaction(data: Bytes!): Bytes32!
     }
+
+    type Subscription {
+        # NewHeads fires each time a new block is appended to the chain, including
+        # the blocks of chain reorganisations.
+        newHeads: Block!
+        # Logs fires for each new log entry matching the provided filter. In case
+        # of chain reorganisations, the reverted logs are delivered again with
+        # the removed field set.
+        logs(filter: BlockFilterCriteria!): Log!
+        # PendingTransactions fires for each transaction entering the pending
+        # state of the transaction pool.
+        pendingTransactions: Transaction!
+    }
 `
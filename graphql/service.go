@@ -19,29 +19,42 @@ package graphql
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
 	"github.com/graph-gophers/graphql-go"
 	gqlErrors "github.com/graph-gophers/graphql-go/errors"
 )
 
+// queryParams are the parameters of a GraphQL request.
+type queryParams struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
 type handler struct {
-	Schema *graphql.Schema
+	Schema        *graphql.Schema
+	subscriptions *wsHandler
+	costLimit     uint64 // maximum cost of a query, 0 if unlimited
 }
 
 func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	var params struct {
-		Query         string                 `json:"query"`
-		OperationName string                 `json:"operationName"`
-		Variables     map[string]interface{} `json:"variables"`
+	// Subscriptions are served over websockets
+	if h.subscriptions != nil && websocket.IsWebSocketUpgrade(r) {
+		h.subscriptions.ServeHTTP(w, r)
+		return
 	}
+	var params queryParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -56,6 +69,11 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel = context.WithCancel(ctx)
 	defer cancel()
 
+	var budget *queryBudget
+	if h.costLimit > 0 {
+		ctx, budget = withBudget(ctx, h.costLimit, cancel)
+	}
+
 	if timeout, ok := rpc.ContextRequestTimeout(ctx); ok {
 		timer = time.AfterFunc(timeout, func() {
 			responded.Do(func() {
@@ -91,6 +109,12 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if timer != nil {
 		timer.Stop()
 	}
+	// Partial results of aborted queries are dropped
+	if budget != nil && budget.exceeded.Load() {
+		response = &graphql.Response{
+			Errors: []*gqlErrors.QueryError{{Message: fmt.Sprintf("query cost limit of %d exceeded", h.costLimit)}},
+		}
+	}
 	responded.Do(func() {
 		responseJSON, err := json.Marshal(response)
 		if err != nil {
@@ -105,22 +129,31 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// New constructs a new GraphQL service instance.
-func New(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string) error {
-	_, err := newHandler(stack, backend, filterSystem, cors, vhosts)
+// New constructs a new GraphQL service instance. Queries exceeding the cost limit
+// are refused and subscriptions exceeding it for an event are aborted, unless it
+// is zero.
+func New(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string, costLimit uint64) error {
+	_, err := newHandler(stack, backend, filterSystem, cors, vhosts, costLimit)
 	return err
 }
 
-// newHandler returns a new `http.Handler` that will answer GraphQL queries.
-// It additionally exports an interactive query browser on the / endpoint.
-func newHandler(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string) (*handler, error) {
-	q := Resolver{backend, filterSystem}
-
-	s, err := graphql.ParseSchema(schema, &q)
+// newHandler returns a new `http.Handler` that will answer GraphQL queries, as
+// well as subscriptions over websockets. It additionally exports an interactive
+// query browser on the / endpoint.
+func newHandler(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string, costLimit uint64) (*handler, error) {
+	q := Resolver{backend: backend, filterSystem: filterSystem}
+	if backend, ok := backend.(tracers.Backend); ok {
+		q.tracer = tracers.NewAPI(backend)
+	}
+	s, err := graphql.ParseSchema(schema, &q, graphql.Tracer(costTracer{}))
+	if err != nil {
+		return nil, err
+	}
+	ss, err := graphql.ParseSchema(subscriptionSchema, &subscriptionResolver{Resolver: &q}, graphql.Tracer(costTracer{}))
 	if err != nil {
 		return nil, err
 	}
-	h := handler{Schema: s}
+	h := handler{Schema: s, subscriptions: newWSHandler(ss, cors, costLimit), costLimit: costLimit}
 	handler := node.NewHTTPHandlerStack(h, cors, vhosts, nil)
 
 	stack.RegisterHandler("GraphQL UI", "/graphql/ui", GraphiQL{})
@@ -0,0 +1,152 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// subscriptionResolver is the root resolver of the subscription schema. The
+// events are sourced from the same event system backing the filter API.
+type subscriptionResolver struct {
+	*Resolver
+
+	eventsOnce sync.Once
+	events     *filters.EventSystem
+}
+
+// eventSystem returns the event system, creating it upon first use.
+func (r *subscriptionResolver) eventSystem() *filters.EventSystem {
+	r.eventsOnce.Do(func() {
+		r.events = filters.NewEventSystem(r.filterSystem)
+	})
+	return r.events
+}
+
+func (r *subscriptionResolver) NewHeads(ctx context.Context) (<-chan *Block, error) {
+	var (
+		headers = make(chan *types.Header)
+		sub     = r.eventSystem().SubscribeNewHeads(headers)
+		blocks  = make(chan *Block)
+	)
+	go func() {
+		defer close(blocks)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case header := <-headers:
+				numberOrHash := rpc.BlockNumberOrHashWithHash(header.Hash(), false)
+				block := &Block{
+					r:            r.Resolver,
+					numberOrHash: &numberOrHash,
+					hash:         header.Hash(),
+					header:       header,
+				}
+				select {
+				case blocks <- block:
+				case <-ctx.Done():
+					return
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return blocks, nil
+}
+
+func (r *subscriptionResolver) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) (<-chan *Log, error) {
+	var crit ethereum.FilterQuery
+	if args.Filter.Addresses != nil {
+		crit.Addresses = *args.Filter.Addresses
+	}
+	if args.Filter.Topics != nil {
+		crit.Topics = *args.Filter.Topics
+	}
+	matches := make(chan []*types.Log)
+	sub, err := r.eventSystem().SubscribeLogs(crit, matches)
+	if err != nil {
+		return nil, err
+	}
+	logs := make(chan *Log)
+	go func() {
+		defer close(logs)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case batch := <-matches:
+				for _, log := range batch {
+					entry := &Log{
+						r:           r.Resolver,
+						transaction: &Transaction{r: r.Resolver, hash: log.TxHash},
+						log:         log,
+					}
+					select {
+					case logs <- entry:
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return logs, nil
+}
+
+func (r *subscriptionResolver) PendingTransactions(ctx context.Context) (<-chan *Transaction, error) {
+	var (
+		pending = make(chan []*types.Transaction)
+		sub     = r.eventSystem().SubscribePendingTxs(pending)
+		txs     = make(chan *Transaction)
+	)
+	go func() {
+		defer close(txs)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case batch := <-pending:
+				for _, tx := range batch {
+					select {
+					case txs <- &Transaction{r: r.Resolver, hash: tx.Hash(), tx: tx}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return txs, nil
+}
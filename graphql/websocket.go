@@ -0,0 +1,391 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+)
+
+const (
+	wsReadBuffer   = 1024
+	wsWriteBuffer  = 1024
+	wsReadLimit    = 1024 * 1024
+	wsWriteTimeout = 10 * time.Second
+	wsInitTimeout  = 10 * time.Second
+)
+
+// Subprotocols of the websocket transport. The graphql-transport-ws protocol is
+// the one of the graphql-ws library, the graphql-ws protocol is the legacy one of
+// the subscriptions-transport-ws library, which is still widely used.
+const (
+	protocolTransportWS = "graphql-transport-ws"
+	protocolLegacyWS    = "graphql-ws"
+)
+
+// Message types of the websocket protocols. Where the legacy protocol names a
+// message differently, both types are listed.
+const (
+	msgConnectionInit      = "connection_init"
+	msgConnectionAck       = "connection_ack"
+	msgConnectionTerminate = "connection_terminate" // legacy only
+	msgPing                = "ping"
+	msgPong                = "pong"
+	msgSubscribe           = "subscribe"
+	msgStart               = "start" // legacy subscribe
+	msgNext                = "next"
+	msgData                = "data" // legacy next
+	msgError               = "error"
+	msgComplete            = "complete"
+	msgStop                = "stop" // legacy complete
+)
+
+// Close codes of the graphql-transport-ws protocol, which are also used for the
+// legacy protocol.
+const (
+	closeBadRequest          = 4400
+	closeUnauthorized        = 4401
+	closeSubprotocolNotValid = 4406
+	closeInitTimeout         = 4408
+	closeSubscriberExists    = 4409
+	closeTooManyInits        = 4429
+)
+
+// wsMessage is a message exchanged over the websocket transport.
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// wsHandler serves GraphQL subscriptions over websockets.
+type wsHandler struct {
+	schema    *graphql.Schema
+	upgrader  websocket.Upgrader
+	costLimit uint64 // maximum cost of resolving a subscription event, 0 if unlimited
+}
+
+func newWSHandler(schema *graphql.Schema, allowedOrigins []string, costLimit uint64) *wsHandler {
+	return &wsHandler{
+		schema:    schema,
+		costLimit: costLimit,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  wsReadBuffer,
+			WriteBufferSize: wsWriteBuffer,
+			Subprotocols:    []string{protocolTransportWS, protocolLegacyWS},
+			CheckOrigin:     wsOriginValidator(allowedOrigins),
+		},
+	}
+}
+
+// wsOriginValidator returns a function checking the origin of websocket requests
+// against the allowed CORS origins. Requests without an origin or from the same
+// origin are always accepted.
+func wsOriginValidator(allowedOrigins []string) func(*http.Request) bool {
+	origins := make(map[string]struct{})
+	for _, origin := range allowedOrigins {
+		origins[strings.ToLower(origin)] = struct{}{}
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if _, ok := origins["*"]; ok {
+			return true
+		}
+		if _, ok := origins[strings.ToLower(origin)]; ok {
+			return true
+		}
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+		log.Warn("Rejected GraphQL websocket connection", "origin", origin)
+		return false
+	}
+}
+
+func (h *wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Debug("GraphQL websocket upgrade failed", "err", err)
+		return
+	}
+	c := &wsConn{
+		schema:    h.schema,
+		costLimit: h.costLimit,
+		conn:      conn,
+		legacy:    conn.Subprotocol() == protocolLegacyWS,
+		subs:      make(map[string]context.CancelFunc),
+	}
+	if conn.Subprotocol() == "" {
+		c.close(closeSubprotocolNotValid, "Subprotocol not acceptable")
+		return
+	}
+	c.serve()
+}
+
+// wsConn is a websocket connection serving subscriptions.
+type wsConn struct {
+	schema    *graphql.Schema
+	costLimit uint64
+	conn      *websocket.Conn
+	legacy    bool // whether the legacy graphql-ws protocol is spoken
+
+	writeLock sync.Mutex // serialises writes to the connection
+
+	lock        sync.Mutex
+	initialised bool
+	subs        map[string]context.CancelFunc
+}
+
+// serve reads and handles the messages of the client until the connection is
+// closed. Running subscriptions are stopped when returning.
+func (c *wsConn) serve() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer c.conn.Close()
+
+	c.conn.SetReadLimit(wsReadLimit)
+	timer := time.AfterFunc(wsInitTimeout, func() {
+		c.lock.Lock()
+		initialised := c.initialised
+		c.lock.Unlock()
+
+		if !initialised {
+			c.close(closeInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer timer.Stop()
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg wsMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.close(closeBadRequest, "Invalid message received")
+			return
+		}
+		if !c.handle(ctx, &msg) {
+			return
+		}
+	}
+}
+
+// handle processes a message of the client, returning false if the connection
+// needs to be closed.
+func (c *wsConn) handle(ctx context.Context, msg *wsMessage) bool {
+	switch msg.Type {
+	case msgConnectionInit:
+		c.lock.Lock()
+		initialised := c.initialised
+		c.initialised = true
+		c.lock.Unlock()
+
+		if initialised {
+			c.close(closeTooManyInits, "Too many initialisation requests")
+			return false
+		}
+		c.write(&wsMessage{Type: msgConnectionAck})
+
+	case msgPing:
+		if !c.legacy {
+			c.write(&wsMessage{Type: msgPong, Payload: msg.Payload})
+		}
+
+	case msgPong:
+		// Replies to pings aren't sent by the server
+
+	case msgSubscribe, msgStart:
+		if (msg.Type == msgStart) != c.legacy {
+			c.close(closeBadRequest, fmt.Sprintf("Invalid message type %q", msg.Type))
+			return false
+		}
+		c.lock.Lock()
+		initialised := c.initialised
+		c.lock.Unlock()
+
+		if !initialised {
+			c.close(closeUnauthorized, "Unauthorized")
+			return false
+		}
+		if msg.ID == "" {
+			c.close(closeBadRequest, "Missing subscription id")
+			return false
+		}
+		var params queryParams
+		if err := json.Unmarshal(msg.Payload, &params); err != nil {
+			c.close(closeBadRequest, "Invalid subscription payload")
+			return false
+		}
+		if !c.subscribe(ctx, msg.ID, &params) {
+			c.close(closeSubscriberExists, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+			return false
+		}
+
+	case msgComplete, msgStop:
+		c.unsubscribe(msg.ID)
+
+	case msgConnectionTerminate:
+		return false
+
+	default:
+		c.close(closeBadRequest, fmt.Sprintf("Invalid message type %q", msg.Type))
+		return false
+	}
+	return true
+}
+
+// subscribe starts executing a subscription, streaming its results to the
+// client. False is returned if a subscription with the same id exists.
+func (c *wsConn) subscribe(ctx context.Context, id string, params *queryParams) bool {
+	c.lock.Lock()
+	if _, ok := c.subs[id]; ok {
+		c.lock.Unlock()
+		return false
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c.subs[id] = cancel
+	c.lock.Unlock()
+
+	// The budget aborts the execution on a context of its own, so a subscription
+	// exceeding it is told apart from one stopped by the client.
+	var (
+		execCtx = ctx
+		budget  *subscriptionBudget
+	)
+	if c.costLimit > 0 {
+		var abort context.CancelFunc
+		execCtx, abort = context.WithCancel(ctx)
+		execCtx, budget = withSubscriptionBudget(execCtx, c.costLimit, abort)
+	}
+	// The resolvers are invoked before handling further messages, so the events
+	// caused by the client after subscribing are never missed.
+	responses, err := c.schema.Subscribe(execCtx, params.Query, params.OperationName, params.Variables)
+	if err != nil {
+		if c.finish(ctx, id) {
+			c.writeError(id, err)
+		}
+		return true
+	}
+	go func() {
+		defer cancel()
+
+		// The results need to be drained until the channel is closed, otherwise
+		// the goroutine of the executor leaks.
+		next := msgNext
+		if c.legacy {
+			next = msgData
+		}
+		for response := range responses {
+			// Partial results of aborted events are dropped
+			if budget != nil && budget.exceeded.Load() {
+				continue
+			}
+			payload, err := json.Marshal(response)
+			if err != nil {
+				log.Warn("Failed to encode GraphQL subscription result", "err", err)
+				continue
+			}
+			c.write(&wsMessage{ID: id, Type: next, Payload: payload})
+		}
+		// Subscriptions stopped by the client don't need to be completed
+		if c.finish(ctx, id) {
+			if budget != nil && budget.exceeded.Load() {
+				c.writeError(id, fmt.Errorf("query cost limit of %d exceeded", c.costLimit))
+			} else {
+				c.write(&wsMessage{ID: id, Type: msgComplete})
+			}
+		}
+	}()
+	return true
+}
+
+// unsubscribe stops a running subscription, returning whether it existed.
+func (c *wsConn) unsubscribe(id string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	cancel, ok := c.subs[id]
+	if ok {
+		cancel()
+		delete(c.subs, id)
+	}
+	return ok
+}
+
+// finish removes a subscription which ended on its own, returning false if it was
+// stopped already. The id might be reused by the client after stopping, so the
+// context of the subscription is checked instead of the id.
+func (c *wsConn) finish(ctx context.Context, id string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if ctx.Err() != nil {
+		return false
+	}
+	c.subs[id]()
+	delete(c.subs, id)
+	return true
+}
+
+// writeError sends an error message for a subscription. The legacy protocol
+// expects a single error object, while the newer one expects a list.
+func (c *wsConn) writeError(id string, err error) {
+	var payload []byte
+	if c.legacy {
+		payload, _ = json.Marshal(map[string]string{"message": err.Error()})
+	} else {
+		payload, _ = json.Marshal([]map[string]string{{"message": err.Error()}})
+	}
+	c.write(&wsMessage{ID: id, Type: msgError, Payload: payload})
+}
+
+// write sends a message to the client. Failures close the connection, which in
+// turn stops the read loop.
+func (c *wsConn) write(msg *wsMessage) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err := c.conn.WriteJSON(msg); err != nil {
+		log.Debug("Failed to write GraphQL websocket message", "err", err)
+		c.conn.Close()
+	}
+}
+
+// close terminates the connection with the given close code and reason.
+func (c *wsConn) close(code int, reason string) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	deadline := time.Now().Add(wsWriteTimeout)
+	c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), deadline)
+	c.conn.Close()
+}
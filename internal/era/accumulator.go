@@ -47,6 +47,54 @@ func ComputeAccumulator(hashes []common.Hash, tds []*big.Int) (common.Hash, erro
 	return hh.HashRoot()
 }
 
+// ComputeBlockSummaryRoot calculates the block summary root of the slot era
+// of the given post-merge blocks, which is the hash_tree_root of the beacon block
+// roots of the era tracked by the beacon chain's historical summaries:
+//
+//	block-summary-root := hash_tree_root(Vector[Root, 8192])
+//
+// The beacon block root of each slot is the root of the latest beacon block at
+// or before the slot. As every execution block records the root of its parent
+// beacon block, the vector is reconstructed from the slots and the parent beacon
+// roots of the blocks, along with the beacon root of the last block, which is
+// recorded by its successor.
+func ComputeBlockSummaryRoot(slots []uint64, parents []common.Hash, last common.Hash) (common.Hash, error) {
+	if len(slots) == 0 {
+		return common.Hash{}, errors.New("no records")
+	}
+	if len(slots) != len(parents) {
+		return common.Hash{}, errors.New("must have equal number of slots as parent roots")
+	}
+	var (
+		start = slots[0] / SlotsPerEra * SlotsPerEra
+		roots = make([]common.Hash, SlotsPerEra)
+	)
+	for i, slot := range slots {
+		from := start
+		if i > 0 {
+			if slot <= slots[i-1] {
+				return common.Hash{}, fmt.Errorf("slots not increasing: %d after %d", slot, slots[i-1])
+			}
+			from = slots[i-1]
+		}
+		if slot >= start+SlotsPerEra {
+			return common.Hash{}, fmt.Errorf("slot %d beyond era starting at %d", slot, start)
+		}
+		for s := from; s < slot; s++ {
+			roots[s-start] = parents[i]
+		}
+	}
+	for s := slots[len(slots)-1]; s < start+SlotsPerEra; s++ {
+		roots[s-start] = last
+	}
+	hh := ssz.NewHasher()
+	for i := range roots {
+		hh.Append(roots[i][:])
+	}
+	hh.Merkleize(0)
+	return hh.HashRoot()
+}
+
 // headerRecord is an individual record for a historical header.
 //
 // See https://github.com/ethereum/portal-network-specs/blob/master/history/history-network.md#the-historical-hashes-accumulator
@@ -71,14 +71,40 @@ import (
 //
 // Due to the accumulator size limit of 8192, the maximum number of blocks in
 // an Era1 batch is also 8192.
+//
+// Post-merge execution blocks are archived per slot era, namely the blocks of
+// the 8192 slots summarized by an entry of the beacon chain's historical
+// summaries. The structure is the same, except that the total difficulty is no
+// longer tracked:
+//
+//	erae := Version | block-tuple* | other-entries* | BeaconAnchor | BlockAccumulator | BlockIndex
+//	block-tuple :=  CompressedHeader | CompressedBody | CompressedReceipts
+//
+//	BeaconAnchor       = { type: [0x09, 0x00], data: first-slot | last-beacon-root }
+//	BlockAccumulator   = { type: [0x08, 0x00], data: block-summary-root }
+//
+// The slot of each block follows from its timestamp relative to the first block.
+// The block summary root is the block_summary_root of the historical summary of
+// the era, it's reconstructed from the parent beacon roots recorded by the
+// headers along with the beacon root of the last block, see
+// ComputeBlockSummaryRoot. As the parent beacon root is only recorded since the
+// Cancun fork, the earlier blocks can only be archived in Era1 files.
 type Builder struct {
-	w        *e2store.Writer
-	startNum *uint64
-	startTd  *big.Int
-	indexes  []uint64
-	hashes   []common.Hash
-	tds      []*big.Int
-	written  int
+	w         *e2store.Writer
+	postMerge bool
+	startNum  *uint64
+	startTd   *big.Int
+	indexes   []uint64
+	hashes    []common.Hash
+	tds       []*big.Int
+	written   int
+
+	// Post-merge archives only.
+	firstSlot uint64        // Slot of the first block
+	firstTime uint64        // Timestamp of the first block
+	slots     []uint64      // Slots of the blocks
+	parents   []common.Hash // Parent beacon roots of the blocks
+	lastRoot  *common.Hash  // Beacon root of the last block
 
 	buf    *bytes.Buffer
 	snappy *snappy.Writer
@@ -94,8 +120,26 @@ func NewBuilder(w io.Writer) *Builder {
 	}
 }
 
+// NewPostMergeBuilder returns a new Builder instance creating post-merge Era
+// archives, in which the total difficulty is not tracked. The slot of the first
+// block to be added is required to place the blocks within the slot era.
+func NewPostMergeBuilder(w io.Writer, firstSlot uint64) *Builder {
+	b := NewBuilder(w)
+	b.postMerge = true
+	b.firstSlot = firstSlot
+	return b
+}
+
+// SetLastBeaconRoot sets the beacon block root of the last block added to a
+// post-merge archive, which is recorded as the parent beacon root of its
+// successor. It must be set before finalizing the archive.
+func (b *Builder) SetLastBeaconRoot(root common.Hash) {
+	b.lastRoot = &root
+}
+
 // Add writes a compressed block entry and compressed receipts entry to the
-// underlying e2store file.
+// underlying e2store file. The total difficulty is ignored for post-merge
+// archives and can be nil.
 func (b *Builder) Add(block *types.Block, receipts types.Receipts, td *big.Int) error {
 	eh, err := rlp.EncodeToBytes(block.Header())
 	if err != nil {
@@ -113,7 +157,8 @@ func (b *Builder) Add(block *types.Block, receipts types.Receipts, td *big.Int)
 }
 
 // AddRLP writes a compressed block entry and compressed receipts entry to the
-// underlying e2store file.
+// underlying e2store file. The total difficulty is ignored for post-merge
+// archives and can be nil.
 func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash common.Hash, td, difficulty *big.Int) error {
 	// Write Era1 version entry before first block.
 	if b.startNum == nil {
@@ -123,16 +168,27 @@ func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash comm
 		}
 		startNum := number
 		b.startNum = &startNum
-		b.startTd = new(big.Int).Sub(td, difficulty)
+		if !b.postMerge {
+			b.startTd = new(big.Int).Sub(td, difficulty)
+		}
 		b.written += n
 	}
 	if len(b.indexes) >= MaxEra1Size {
 		return fmt.Errorf("exceeds maximum batch size of %d", MaxEra1Size)
 	}
-
+	if b.postMerge {
+		if difficulty != nil && difficulty.Sign() != 0 {
+			return fmt.Errorf("block %d with non-zero difficulty in post-merge era", number)
+		}
+		if err := b.addSlot(header, number); err != nil {
+			return err
+		}
+	}
 	b.indexes = append(b.indexes, uint64(b.written))
 	b.hashes = append(b.hashes, hash)
-	b.tds = append(b.tds, td)
+	if !b.postMerge {
+		b.tds = append(b.tds, td)
+	}
 
 	// Write block data.
 	if err := b.snappyWrite(TypeCompressedHeader, header); err != nil {
@@ -144,6 +200,9 @@ func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash comm
 	if err := b.snappyWrite(TypeCompressedReceipts, receipts); err != nil {
 		return err
 	}
+	if b.postMerge {
+		return nil
+	}
 
 	// Also write total difficulty, but don't snappy encode.
 	btd := bigToBytes32(td)
@@ -156,6 +215,34 @@ func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash comm
 	return nil
 }
 
+// addSlot tracks the slot and the parent beacon root of a block added to a
+// post-merge archive.
+func (b *Builder) addSlot(header []byte, number uint64) error {
+	var h types.Header
+	if err := rlp.DecodeBytes(header, &h); err != nil {
+		return fmt.Errorf("error decoding header %d: %w", number, err)
+	}
+	if h.ParentBeaconRoot == nil {
+		return fmt.Errorf("block %d without parent beacon root in post-merge era", number)
+	}
+	if len(b.slots) == 0 {
+		b.firstTime = h.Time
+	}
+	if h.Time < b.firstTime || (h.Time-b.firstTime)%SecondsPerSlot != 0 {
+		return fmt.Errorf("block %d timestamp %d not on a slot boundary", number, h.Time)
+	}
+	slot := b.firstSlot + (h.Time-b.firstTime)/SecondsPerSlot
+	if slot/SlotsPerEra != b.firstSlot/SlotsPerEra {
+		return fmt.Errorf("block %d at slot %d beyond the era of slot %d", number, slot, b.firstSlot)
+	}
+	if n := len(b.slots); n > 0 && slot <= b.slots[n-1] {
+		return fmt.Errorf("block %d at slot %d not after slot %d", number, slot, b.slots[n-1])
+	}
+	b.slots = append(b.slots, slot)
+	b.parents = append(b.parents, *h.ParentBeaconRoot)
+	return nil
+}
+
 // Finalize computes the accumulator and block index values, then writes the
 // corresponding e2store entries.
 func (b *Builder) Finalize() (common.Hash, error) {
@@ -163,11 +250,32 @@ func (b *Builder) Finalize() (common.Hash, error) {
 		return common.Hash{}, errors.New("finalize called on empty builder")
 	}
 	// Compute accumulator root and write entry.
-	root, err := ComputeAccumulator(b.hashes, b.tds)
+	var (
+		root common.Hash
+		typ  = TypeAccumulator
+		err  error
+	)
+	if b.postMerge {
+		if b.lastRoot == nil {
+			return common.Hash{}, errors.New("beacon root of the last block is not set")
+		}
+		anchor := make([]byte, beaconAnchorSize)
+		binary.LittleEndian.PutUint64(anchor, b.firstSlot)
+		copy(anchor[8:], b.lastRoot[:])
+		n, werr := b.w.Write(TypeBeaconAnchor, anchor)
+		b.written += n
+		if werr != nil {
+			return common.Hash{}, fmt.Errorf("error writing beacon anchor: %w", werr)
+		}
+		root, err = ComputeBlockSummaryRoot(b.slots, b.parents, *b.lastRoot)
+		typ = TypeBlockAccumulator
+	} else {
+		root, err = ComputeAccumulator(b.hashes, b.tds)
+	}
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("error calculating accumulator root: %w", err)
 	}
-	n, err := b.w.Write(TypeAccumulator, root[:])
+	n, err := b.w.Write(typ, root[:])
 	b.written += n
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("error writing accumulator: %w", err)
@@ -18,6 +18,7 @@ package era
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -41,20 +42,52 @@ var (
 	TypeCompressedReceipts uint16 = 0x05
 	TypeTotalDifficulty    uint16 = 0x06
 	TypeAccumulator        uint16 = 0x07
+	TypeBlockAccumulator   uint16 = 0x08
+	TypeBeaconAnchor       uint16 = 0x09
 	TypeBlockIndex         uint16 = 0x3266
 
 	MaxEra1Size = 8192
+
+	// SlotsPerEra is the number of beacon chain slots grouped in a post-merge
+	// Era, matching the period of the beacon chain's historical summaries.
+	SlotsPerEra uint64 = 8192
+
+	// SecondsPerSlot is the duration of a beacon chain slot.
+	SecondsPerSlot uint64 = 12
 )
 
+// beaconAnchorSize is the size of the beacon anchor entry of post-merge Era
+// files, consisting of the first slot and the beacon root of the last block.
+const beaconAnchorSize = 8 + common.HashLength
+
 // Filename returns a recognizable Era1-formatted file name for the specified
 // epoch and network.
 func Filename(network string, epoch int, root common.Hash) string {
 	return fmt.Sprintf("%s-%05d-%s.era1", network, epoch, root.Hex()[2:10])
 }
 
+// PostMergeFilename returns a recognizable file name for the post-merge Era
+// archive of the specified network. The epoch of post-merge archives is the
+// index of the slot era, rather than a block range.
+func PostMergeFilename(network string, epoch int, root common.Hash) string {
+	return fmt.Sprintf("%s-%05d-%s.erae", network, epoch, root.Hex()[2:10])
+}
+
 // ReadDir reads all the era1 files in a directory for a given network.
 // Format: <network>-<epoch>-<hexroot>.era1
 func ReadDir(dir, network string) ([]string, error) {
+	return readDir(dir, network, ".era1", true)
+}
+
+// ReadPostMergeDir reads all the post-merge Era files in a directory for a
+// given network. The epochs are expected to be contiguous, but not necessarily
+// starting from zero.
+// Format: <network>-<epoch>-<hexroot>.erae
+func ReadPostMergeDir(dir, network string) ([]string, error) {
+	return readDir(dir, network, ".erae", false)
+}
+
+func readDir(dir, network, ext string, fromGenesis bool) ([]string, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("error reading directory %s: %w", dir, err)
@@ -64,17 +97,22 @@ func ReadDir(dir, network string) ([]string, error) {
 		eras []string
 	)
 	for _, entry := range entries {
-		if path.Ext(entry.Name()) != ".era1" {
+		if path.Ext(entry.Name()) != ext {
 			continue
 		}
 		parts := strings.Split(entry.Name(), "-")
 		if len(parts) != 3 || parts[0] != network {
-			// Invalid era1 filename, skip.
+			// Invalid era filename, skip.
 			continue
 		}
-		if epoch, err := strconv.ParseUint(parts[1], 10, 64); err != nil {
-			return nil, fmt.Errorf("malformed era1 filename: %s", entry.Name())
-		} else if epoch != next {
+		epoch, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed era filename: %s", entry.Name())
+		}
+		if len(eras) == 0 && !fromGenesis {
+			next = epoch
+		}
+		if epoch != next {
 			return nil, fmt.Errorf("missing epoch %d", next)
 		}
 		next += 1
@@ -89,7 +127,7 @@ type ReadAtSeekCloser interface {
 	io.Closer
 }
 
-// Era reads and Era1 file.
+// Era reads an Era1 or a post-merge Era file.
 type Era struct {
 	f   ReadAtSeekCloser // backing era1 file
 	s   *e2store.Reader  // e2store reader over f
@@ -104,9 +142,32 @@ func From(f ReadAtSeekCloser) (*Era, error) {
 	if err != nil {
 		return nil, err
 	}
+	s := e2store.NewReader(f)
+
+	// The accumulator entry precedes the block index directly, its type tells
+	// the format of the archive.
+	m.accumulator = m.length - 24 - int64(m.count)*8 - 8 - common.HashLength
+	typ, _, err := s.ReadMetadataAt(m.accumulator)
+	if err != nil {
+		return nil, fmt.Errorf("error reading accumulator: %w", err)
+	}
+	switch typ {
+	case TypeAccumulator:
+	case TypeBlockAccumulator:
+		// The beacon anchor precedes the accumulator in post-merge archives.
+		m.postMerge = true
+		m.anchor = m.accumulator - 8 - beaconAnchorSize
+		if typ, _, err := s.ReadMetadataAt(m.anchor); err != nil {
+			return nil, fmt.Errorf("error reading beacon anchor: %w", err)
+		} else if typ != TypeBeaconAnchor {
+			return nil, fmt.Errorf("unexpected beacon anchor type %#x", typ)
+		}
+	default:
+		return nil, fmt.Errorf("unknown accumulator type %#x", typ)
+	}
 	return &Era{
 		f:  f,
-		s:  e2store.NewReader(f),
+		s:  s,
 		m:  m,
 		mu: new(sync.Mutex),
 	}, nil
@@ -197,18 +258,45 @@ func (e *Era) GetRawReceiptsByNumber(num uint64) ([]byte, error) {
 	return io.ReadAll(r)
 }
 
-// Accumulator reads the accumulator entry in the Era1 file.
+// Accumulator reads the accumulator entry in the Era file. It's the total
+// difficulty accumulator for Era1 files and the block summary root for the
+// post-merge ones.
 func (e *Era) Accumulator() (common.Hash, error) {
-	entry, err := e.s.Find(TypeAccumulator)
-	if err != nil {
+	var entry e2store.Entry
+	if _, err := e.s.ReadAt(&entry, e.m.accumulator); err != nil {
 		return common.Hash{}, err
 	}
 	return common.BytesToHash(entry.Value), nil
 }
 
+// BeaconAnchor reads the beacon anchor of a post-merge Era file, which is the
+// slot of the first block and the beacon block root of the last block.
+func (e *Era) BeaconAnchor() (uint64, common.Hash, error) {
+	if !e.m.postMerge {
+		return 0, common.Hash{}, errors.New("beacon anchor is only tracked in post-merge era")
+	}
+	var entry e2store.Entry
+	if _, err := e.s.ReadAt(&entry, e.m.anchor); err != nil {
+		return 0, common.Hash{}, err
+	}
+	if len(entry.Value) != beaconAnchorSize {
+		return 0, common.Hash{}, fmt.Errorf("invalid beacon anchor size %d", len(entry.Value))
+	}
+	return binary.LittleEndian.Uint64(entry.Value), common.BytesToHash(entry.Value[8:]), nil
+}
+
+// IsPostMerge reports whether the file is a post-merge Era archive, in which
+// the total difficulty values are not tracked.
+func (e *Era) IsPostMerge() bool {
+	return e.m.postMerge
+}
+
 // InitialTD returns initial total difficulty before the difficulty of the
 // first block of the Era1 is applied.
 func (e *Era) InitialTD() (*big.Int, error) {
+	if e.m.postMerge {
+		return nil, errors.New("total difficulty is not tracked in post-merge era")
+	}
 	var (
 		r      io.Reader
 		header types.Header
@@ -290,9 +378,12 @@ func newSnappyReader(e *e2store.Reader, expectedType uint16, off int64) (io.Read
 
 // metadata wraps the metadata in the block index.
 type metadata struct {
-	start  uint64
-	count  uint64
-	length int64
+	start       uint64
+	count       uint64
+	length      int64
+	accumulator int64 // offset of the accumulator entry
+	anchor      int64 // offset of the beacon anchor entry, post-merge only
+	postMerge   bool  // whether the file is a post-merge archive
 }
 
 // readMetadata reads the metadata stored in an Era1 file's block index.
@@ -18,10 +18,12 @@ package era
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"math/big"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -143,6 +145,181 @@ func TestEra1Builder(t *testing.T) {
 	}
 }
 
+func TestPostMergeBuilder(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.CreateTemp(t.TempDir(), "erae-test")
+	if err != nil {
+		t.Fatalf("error creating temp file: %v", err)
+	}
+	defer f.Close()
+
+	var (
+		firstSlot = 5*SlotsPerEra + 10
+		builder   = NewPostMergeBuilder(f, firstSlot)
+		chain     = testchain{}
+		hashes    []common.Hash
+		slots     []uint64
+		parents   []common.Hash
+		last      = common.Hash{0xff}
+		start     = uint64(1000)
+	)
+	for i := 0; i < 128; i++ {
+		slot := firstSlot + 2*uint64(i)
+		header := &types.Header{
+			Number:           big.NewInt(int64(start) + int64(i)),
+			Time:             1000 + (slot-firstSlot)*SecondsPerSlot,
+			BaseFee:          new(big.Int),
+			WithdrawalsHash:  &types.EmptyWithdrawalsHash,
+			BlobGasUsed:      new(uint64),
+			ExcessBlobGas:    new(uint64),
+			ParentBeaconRoot: &common.Hash{0x01, byte(i)},
+		}
+		slots = append(slots, slot)
+		parents = append(parents, *header.ParentBeaconRoot)
+		chain.headers = append(chain.headers, mustEncode(header))
+		chain.bodies = append(chain.bodies, mustEncode(&types.Body{Transactions: []*types.Transaction{types.NewTransaction(0, common.Address{byte(i)}, nil, 0, nil, nil)}}))
+		chain.receipts = append(chain.receipts, mustEncode(&types.Receipts{{CumulativeGasUsed: uint64(i)}}))
+		hashes = append(hashes, common.Hash{byte(i)})
+	}
+	for i := 0; i < len(chain.headers); i++ {
+		if err = builder.AddRLP(chain.headers[i], chain.bodies[i], chain.receipts[i], start+uint64(i), hashes[i], nil, new(big.Int)); err != nil {
+			t.Fatalf("error adding entry: %v", err)
+		}
+	}
+	if err := builder.AddRLP(chain.headers[0], chain.bodies[0], chain.receipts[0], start+128, common.Hash{}, nil, big.NewInt(1)); err == nil {
+		t.Fatal("expected error adding block with non-zero difficulty")
+	}
+	if err := builder.AddRLP(chain.headers[0], chain.bodies[0], chain.receipts[0], start+128, common.Hash{}, nil, new(big.Int)); err == nil {
+		t.Fatal("expected error adding block at a preceding slot")
+	}
+	if _, err := builder.Finalize(); err == nil {
+		t.Fatal("expected error finalizing era without the last beacon root")
+	}
+	builder.SetLastBeaconRoot(last)
+	root, err := builder.Finalize()
+	if err != nil {
+		t.Fatalf("error finalizing era: %v", err)
+	}
+	want, err := ComputeBlockSummaryRoot(slots, parents, last)
+	if err != nil {
+		t.Fatalf("error computing accumulator: %v", err)
+	}
+	if root != want {
+		t.Fatalf("mismatched accumulator: want %x, got %x", want, root)
+	}
+
+	// Verify the contents.
+	e, err := Open(f.Name())
+	if err != nil {
+		t.Fatalf("failed to open era: %v", err)
+	}
+	defer e.Close()
+
+	if !e.IsPostMerge() {
+		t.Fatal("expected post-merge era")
+	}
+	if e.Start() != start || e.Count() != uint64(len(chain.headers)) {
+		t.Fatalf("mismatched range: want [%d, +%d], got [%d, +%d]", start, len(chain.headers), e.Start(), e.Count())
+	}
+	if acc, err := e.Accumulator(); err != nil || acc != want {
+		t.Fatalf("mismatched accumulator: want %x, got %x (err: %v)", want, acc, err)
+	}
+	if _, err := e.InitialTD(); err == nil {
+		t.Fatal("expected error reading total difficulty")
+	}
+	if slot, root, err := e.BeaconAnchor(); err != nil || slot != firstSlot || root != last {
+		t.Fatalf("mismatched beacon anchor: want (%d, %x), got (%d, %x) (err: %v)", firstSlot, last, slot, root, err)
+	}
+	it, err := NewRawIterator(e)
+	if err != nil {
+		t.Fatalf("failed to make iterator: %s", err)
+	}
+	for i := 0; i < len(chain.headers); i++ {
+		if !it.Next() {
+			t.Fatalf("expected more entries")
+		}
+		if it.Error() != nil {
+			t.Fatalf("unexpected error %v", it.Error())
+		}
+		rawHeader, err := io.ReadAll(it.Header)
+		if err != nil {
+			t.Fatalf("error reading header from iterator: %v", err)
+		}
+		if !bytes.Equal(rawHeader, chain.headers[i]) {
+			t.Fatalf("mismatched header: want %s, got %s", chain.headers[i], rawHeader)
+		}
+		if it.TotalDifficulty != nil {
+			t.Fatal("unexpected total difficulty in post-merge era")
+		}
+		body, err := e.GetRawBodyByNumber(start + uint64(i))
+		if err != nil {
+			t.Fatalf("error reading body: %v", err)
+		}
+		if !bytes.Equal(body, chain.bodies[i]) {
+			t.Fatalf("mismatched body: want %s, got %s", chain.bodies[i], body)
+		}
+		receipts, err := e.GetRawReceiptsByNumber(start + uint64(i))
+		if err != nil {
+			t.Fatalf("error reading receipts: %v", err)
+		}
+		if !bytes.Equal(receipts, chain.receipts[i]) {
+			t.Fatalf("mismatched receipts: want %s, got %s", chain.receipts[i], receipts)
+		}
+	}
+	if it.Next() {
+		t.Fatal("expected no more entries")
+	}
+}
+
+func TestComputeBlockSummaryRoot(t *testing.T) {
+	t.Parallel()
+
+	// Blocks at the second and the fifth slots of the era, the remaining slots
+	// are filled with the root of the latest preceding block.
+	var (
+		first  = 7 * SlotsPerEra
+		slots  = []uint64{first + 1, first + 4}
+		a, b   = common.Hash{0xa}, common.Hash{0xb}
+		p      = common.Hash{0x0}
+		roots  = make([][]byte, SlotsPerEra)
+		hasher = sha256.New()
+	)
+	for i := range roots {
+		switch {
+		case i < 1:
+			roots[i] = p[:]
+		case i < 4:
+			roots[i] = a[:]
+		default:
+			roots[i] = b[:]
+		}
+	}
+	for len(roots) > 1 {
+		var next [][]byte
+		for i := 0; i < len(roots); i += 2 {
+			hasher.Reset()
+			hasher.Write(roots[i])
+			hasher.Write(roots[i+1])
+			next = append(next, hasher.Sum(nil))
+		}
+		roots = next
+	}
+	have, err := ComputeBlockSummaryRoot(slots, []common.Hash{p, a}, b)
+	if err != nil {
+		t.Fatalf("error computing block summary root: %v", err)
+	}
+	if want := common.BytesToHash(roots[0]); have != want {
+		t.Fatalf("mismatched block summary root: want %x, got %x", want, have)
+	}
+	if _, err := ComputeBlockSummaryRoot([]uint64{first + 4, first + 1}, []common.Hash{p, a}, b); err == nil {
+		t.Fatal("expected error for unordered slots")
+	}
+	if _, err := ComputeBlockSummaryRoot([]uint64{first + 1, first + SlotsPerEra}, []common.Hash{p, a}, b); err == nil {
+		t.Fatal("expected error for slots beyond the era")
+	}
+}
+
 func TestEraFilename(t *testing.T) {
 	t.Parallel()
 
@@ -158,6 +335,10 @@ func TestEraFilename(t *testing.T) {
 		if tt.expected != got {
 			t.Errorf("test %d: invalid filename: want %s, got %s", i, tt.expected, got)
 		}
+		got = PostMergeFilename(tt.network, tt.epoch, tt.root)
+		if want := strings.TrimSuffix(tt.expected, ".era1") + ".erae"; want != got {
+			t.Errorf("test %d: invalid post-merge filename: want %s, got %s", i, want, got)
+		}
 	}
 }
 
@@ -101,8 +101,11 @@ func (it *Iterator) BlockAndReceipts() (*types.Block, types.Receipts, error) {
 }
 
 // TotalDifficulty returns the total difficulty for the iterator's current
-// position.
+// position. It's not available for post-merge archives.
 func (it *Iterator) TotalDifficulty() (*big.Int, error) {
+	if it.inner.TotalDifficulty == nil {
+		return nil, errors.New("total difficulty is not available")
+	}
 	td, err := io.ReadAll(it.inner.TotalDifficulty)
 	if err != nil {
 		return nil, err
@@ -166,9 +169,11 @@ func (it *RawIterator) Next() bool {
 		return true
 	}
 	off += n
-	if it.TotalDifficulty, _, it.err = it.e.s.ReaderAt(TypeTotalDifficulty, off); it.err != nil {
-		it.clear()
-		return true
+	if !it.e.m.postMerge {
+		if it.TotalDifficulty, _, it.err = it.e.s.ReaderAt(TypeTotalDifficulty, off); it.err != nil {
+			it.clear()
+			return true
+		}
 	}
 	it.next += 1
 	return true
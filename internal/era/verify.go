@@ -0,0 +1,133 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Verify checks that the accumulator of the Era matches the data in it, using
+// the given hasher to derive the transaction and receipt roots. Note the
+// accumulator itself must be checked against a trusted value separately.
+func Verify(e *Era, hasher types.TrieHasher) error {
+	var (
+		err    error
+		want   common.Hash
+		td     *big.Int
+		tds    = make([]*big.Int, 0)
+		hashes = make([]common.Hash, 0)
+
+		// Post-merge archives only
+		firstSlot uint64
+		firstTime uint64
+		lastRoot  common.Hash
+		slots     = make([]uint64, 0)
+		parents   = make([]common.Hash, 0)
+	)
+	if want, err = e.Accumulator(); err != nil {
+		return fmt.Errorf("error reading accumulator: %w", err)
+	}
+	if e.IsPostMerge() {
+		if firstSlot, lastRoot, err = e.BeaconAnchor(); err != nil {
+			return fmt.Errorf("error reading beacon anchor: %w", err)
+		}
+	} else {
+		if td, err = e.InitialTD(); err != nil {
+			return fmt.Errorf("error reading total difficulty: %w", err)
+		}
+	}
+	it, err := NewIterator(e)
+	if err != nil {
+		return fmt.Errorf("error making era iterator: %w", err)
+	}
+	// To fully verify an era the following attributes must be checked:
+	//   1) the block index is constructed correctly
+	//   2) the tx root matches the value in the block
+	//   3) the receipts root matches the value in the block
+	//   4) the starting total difficulty value is correct
+	//   5) the accumulator is correct by recomputing it locally, which verifies
+	//      the blocks are all correct (via hash)
+	//
+	// The total difficulty is not tracked in post-merge eras, 4) is skipped and
+	// the accumulator is computed over the beacon roots of the slots, as recorded
+	// by the headers, which are linked by their parent hashes in turn.
+	//
+	// The attributes 1), 2), and 3) are checked for each block. 4) and 5) require
+	// accumulation across the entire set and are verified at the end.
+	var parent common.Hash
+	for it.Next() {
+		// 1) next() walks the block index, so we're able to implicitly verify it.
+		if it.Error() != nil {
+			return fmt.Errorf("error reading block %d: %w", it.Number(), it.Error())
+		}
+		block, receipts, err := it.BlockAndReceipts()
+		if err != nil {
+			return fmt.Errorf("error reading block %d: %w", it.Number(), err)
+		}
+		// 2) recompute tx root and verify against header.
+		tr := types.DeriveSha(block.Transactions(), hasher)
+		if tr != block.TxHash() {
+			return fmt.Errorf("tx root in block %d mismatch: want %s, got %s", block.NumberU64(), block.TxHash(), tr)
+		}
+		// 3) recompute receipt root and check value against block.
+		rr := types.DeriveSha(receipts, hasher)
+		if rr != block.ReceiptHash() {
+			return fmt.Errorf("receipt root in block %d mismatch: want %s, got %s", block.NumberU64(), block.ReceiptHash(), rr)
+		}
+		hashes = append(hashes, block.Hash())
+		if td != nil {
+			td.Add(td, block.Difficulty())
+			tds = append(tds, new(big.Int).Set(td))
+			continue
+		}
+		if len(slots) > 0 && block.ParentHash() != parent {
+			return fmt.Errorf("block %d not linked to its predecessor", block.NumberU64())
+		}
+		parent = block.Hash()
+
+		root := block.BeaconRoot()
+		if root == nil {
+			return fmt.Errorf("block %d without parent beacon root", block.NumberU64())
+		}
+		if len(slots) == 0 {
+			firstTime = block.Time()
+		}
+		if block.Time() < firstTime || (block.Time()-firstTime)%SecondsPerSlot != 0 {
+			return fmt.Errorf("block %d timestamp %d not on a slot boundary", block.NumberU64(), block.Time())
+		}
+		slots = append(slots, firstSlot+(block.Time()-firstTime)/SecondsPerSlot)
+		parents = append(parents, *root)
+	}
+	// 4+5) Verify accumulator and total difficulty.
+	var got common.Hash
+	if e.IsPostMerge() {
+		got, err = ComputeBlockSummaryRoot(slots, parents, lastRoot)
+	} else {
+		got, err = ComputeAccumulator(hashes, tds)
+	}
+	if err != nil {
+		return fmt.Errorf("error computing accumulator: %w", err)
+	}
+	if got != want {
+		return fmt.Errorf("expected accumulator root does not match calculated: got %s, want %s", got, want)
+	}
+	return nil
+}
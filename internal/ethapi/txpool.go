@@ -0,0 +1,281 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// defaultTxPoolQueryLimit is the number of transactions returned by a pool
+	// query if the request doesn't specify a limit.
+	defaultTxPoolQueryLimit = 100
+
+	// maxTxPoolQueryLimit is the maximum number of transactions returned by a
+	// single pool query.
+	maxTxPoolQueryLimit = 1000
+)
+
+// TxPoolFilter is the set of criteria a pooled transaction needs to satisfy to
+// be returned by a pool query or to be reported by a pool event subscription.
+// Empty criteria match all transactions.
+type TxPoolFilter struct {
+	From   []common.Address `json:"from"`
+	To     []common.Address `json:"to"`
+	MinTip *hexutil.Big     `json:"minTip"` // Minimum effective tip at the base fee of the next block
+	Types  []hexutil.Uint64 `json:"types"`
+	Blobs  *bool            `json:"blobs"` // Match only blob (true) or non-blob (false) transactions
+}
+
+// match reports whether a transaction satisfies the filter. The transaction
+// might be nil if the pool doesn't hold it in memory, in which case the criteria
+// depending on its contents are considered satisfied.
+func (f *TxPoolFilter) match(from common.Address, typ uint8, tx *types.Transaction, baseFee *big.Int) bool {
+	if !f.matchKind(from, typ) {
+		return false
+	}
+	if tx == nil {
+		return true
+	}
+	if !f.matchTo(tx) {
+		return false
+	}
+	if f.MinTip != nil {
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil || tip.Cmp(f.MinTip.ToInt()) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// matchEntry reports whether a pooled transaction handle satisfies the criteria
+// of the filter that can be checked without resolving the transaction.
+func (f *TxPoolFilter) matchEntry(entry *txpool.TxEntry, baseFee *uint256.Int) bool {
+	if !f.matchKind(entry.From, entry.Type) {
+		return false
+	}
+	if f.MinTip != nil {
+		if entry.GasFeeCap.Lt(baseFee) {
+			return false
+		}
+		tip := new(uint256.Int).Sub(entry.GasFeeCap, baseFee)
+		if tip.Gt(entry.GasTipCap) {
+			tip = entry.GasTipCap
+		}
+		if tip.ToBig().Cmp(f.MinTip.ToInt()) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// matchKind reports whether the sender and the type of a transaction satisfy
+// the filter.
+func (f *TxPoolFilter) matchKind(from common.Address, typ uint8) bool {
+	if len(f.From) > 0 && !slices.Contains(f.From, from) {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, hexutil.Uint64(typ)) {
+		return false
+	}
+	if f.Blobs != nil && *f.Blobs != (typ == types.BlobTxType) {
+		return false
+	}
+	return true
+}
+
+// matchTo reports whether the recipient of a transaction satisfies the filter.
+func (f *TxPoolFilter) matchTo(tx *types.Transaction) bool {
+	return len(f.To) == 0 || (tx.To() != nil && slices.Contains(f.To, *tx.To()))
+}
+
+// TxPoolCursor is the position of a transaction in the pool query results,
+// which are ordered by sender and nonce.
+type TxPoolCursor struct {
+	From  common.Address `json:"from"`
+	Nonce hexutil.Uint64 `json:"nonce"`
+}
+
+// TxPoolQuery is a request for a page of the pooled transactions matching the
+// filter.
+type TxPoolQuery struct {
+	TxPoolFilter
+	Status string         `json:"status"` // Either "pending" or "queued", both if empty
+	After  *TxPoolCursor  `json:"after"`  // Position to continue a previous query from
+	Limit  hexutil.Uint64 `json:"limit"`  // Maximum number of transactions to return
+}
+
+// TxPoolEntry is a pooled transaction returned by a pool query.
+type TxPoolEntry struct {
+	*RPCTransaction
+	Status string `json:"status"`
+}
+
+// TxPoolPage is a page of the pool query results. Next is set if there might be
+// more matching transactions, and can be used as the starting point of the query
+// retrieving the next page.
+type TxPoolPage struct {
+	Transactions []*TxPoolEntry `json:"transactions"`
+	Next         *TxPoolCursor  `json:"next"`
+}
+
+// Query returns a page of the pooled transactions matching the query, ordered
+// by sender and nonce.
+//
+// The pool content is filtered and ordered on the transaction metadata, and only
+// the transactions ending up on the page are resolved.
+func (api *TxPoolAPI) Query(query TxPoolQuery) (*TxPoolPage, error) {
+	limit := uint64(query.Limit)
+	if limit == 0 {
+		limit = defaultTxPoolQueryLimit
+	}
+	if limit > maxTxPoolQueryLimit {
+		return nil, fmt.Errorf("limit %d exceeds the maximum of %d", limit, maxTxPoolQueryLimit)
+	}
+	if query.Status != "" && query.Status != "pending" && query.Status != "queued" {
+		return nil, fmt.Errorf("invalid status %q", query.Status)
+	}
+	var (
+		head    = api.b.CurrentHeader()
+		baseFee = uint256.MustFromBig(eip1559.CalcBaseFee(api.b.ChainConfig(), head))
+		entries []*txpool.TxEntry
+	)
+	// Skip the transactions returned by the previous pages and the ones not
+	// matching the filter before ordering the rest
+	for _, entry := range api.b.TxPoolEntries(query.From) {
+		if after := query.After; after != nil {
+			if cmp := entry.From.Cmp(after.From); cmp < 0 || (cmp == 0 && entry.Nonce <= uint64(after.Nonce)) {
+				continue
+			}
+		}
+		if query.Status != "" && query.Status != txPoolStatus(entry) {
+			continue
+		}
+		if !query.matchEntry(entry, baseFee) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b *txpool.TxEntry) int {
+		if cmp := a.From.Cmp(b.From); cmp != 0 {
+			return cmp
+		}
+		return cmp.Compare(a.Nonce, b.Nonce)
+	})
+	// Resolve the transactions in order until the page is full, checking the
+	// criteria depending on the transaction contents
+	page := &TxPoolPage{Transactions: []*TxPoolEntry{}}
+	for _, entry := range entries {
+		if uint64(len(page.Transactions)) == limit {
+			last := page.Transactions[len(page.Transactions)-1]
+			page.Next = &TxPoolCursor{From: last.From, Nonce: last.Nonce}
+			break
+		}
+		tx := entry.Resolve()
+		if tx == nil || !query.matchTo(tx) {
+			continue // Dropped since retrieving the handles, or filtered out
+		}
+		page.Transactions = append(page.Transactions, &TxPoolEntry{
+			RPCTransaction: NewRPCPendingTransaction(tx.WithoutBlobTxSidecar(), head, api.b.ChainConfig()),
+			Status:         txPoolStatus(entry),
+		})
+	}
+	return page, nil
+}
+
+// txPoolStatus returns the status of a pooled transaction as reported by the
+// pool queries.
+func txPoolStatus(entry *txpool.TxEntry) string {
+	if entry.Pending {
+		return "pending"
+	}
+	return "queued"
+}
+
+// TxPoolEvent is a notification about a change in the life of a pooled
+// transaction.
+type TxPoolEvent struct {
+	Type       string         `json:"type"` // One of "add", "drop", "replace" or "include"
+	Hash       common.Hash    `json:"hash"`
+	From       common.Address `json:"from"`
+	Nonce      hexutil.Uint64 `json:"nonce"`
+	Reason     string         `json:"reason,omitempty"`     // Reason code of drop events
+	ReplacedBy *common.Hash   `json:"replacedBy,omitempty"` // Replacement transaction of replace events
+}
+
+// Events creates a subscription that is notified whenever a transaction matching
+// the filter is added to, replaced in, included from or dropped from the pool.
+//
+// Note, the pool doesn't hold the evicted blob transactions in memory, so only
+// the sender and type criteria of the filter are checked for them.
+func (api *TxPoolAPI) Events(ctx context.Context, filter *TxPoolFilter) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if filter == nil {
+		filter = new(TxPoolFilter)
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan []txpool.TxEvent, 128)
+		sub := api.b.SubscribeTxPoolEvents(events)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case batch := <-events:
+				baseFee := eip1559.CalcBaseFee(api.b.ChainConfig(), api.b.CurrentHeader())
+				for _, ev := range batch {
+					if !filter.match(ev.From, ev.TxType, ev.Tx, baseFee) {
+						continue
+					}
+					notification := &TxPoolEvent{
+						Type:   ev.Type.String(),
+						Hash:   ev.Hash,
+						From:   ev.From,
+						Nonce:  hexutil.Uint64(ev.Nonce),
+						Reason: ev.Reason,
+					}
+					if ev.Type == txpool.TxEventReplace {
+						notification.ReplacedBy = &ev.By
+					}
+					notifier.Notify(rpcSub.ID, notification)
+				}
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
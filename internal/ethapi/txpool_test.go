@@ -0,0 +1,256 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// txPoolBackend is a backend serving a fixed transaction pool content.
+type txPoolBackend struct {
+	Backend
+
+	pending map[common.Address][]*types.Transaction
+	queued  map[common.Address][]*types.Transaction
+	blobs   map[common.Address][]*types.Transaction
+
+	resolved int // Number of blob transactions resolved
+}
+
+func (b *txPoolBackend) CurrentHeader() *types.Header {
+	return &types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(params.GWei), GasLimit: 30_000_000, GasUsed: 15_000_000}
+}
+func (b *txPoolBackend) ChainConfig() *params.ChainConfig { return params.MergedTestChainConfig }
+func (b *txPoolBackend) TxPoolEntries(senders []common.Address) []*txpool.TxEntry {
+	var entries []*txpool.TxEntry
+	collect := func(content map[common.Address][]*types.Transaction, pending bool, resolved bool) {
+		for from, txs := range content {
+			if len(senders) > 0 && !slices.Contains(senders, from) {
+				continue
+			}
+			for _, tx := range txs {
+				lazy := &txpool.LazyTransaction{
+					Pool:      b,
+					Hash:      tx.Hash(),
+					GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+					GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+					Gas:       tx.Gas(),
+					BlobGas:   tx.BlobGas(),
+				}
+				if resolved {
+					lazy.Tx = tx
+				}
+				entries = append(entries, &txpool.TxEntry{
+					LazyTransaction: lazy,
+					From:            from,
+					Nonce:           tx.Nonce(),
+					Type:            tx.Type(),
+					Pending:         pending,
+				})
+			}
+		}
+	}
+	collect(b.pending, true, true)
+	collect(b.queued, false, true)
+	collect(b.blobs, true, false)
+	return entries
+}
+
+// Get resolves the blob transactions, which are not kept in memory by the pool.
+func (b *txPoolBackend) Get(hash common.Hash) *types.Transaction {
+	for _, txs := range b.blobs {
+		for _, tx := range txs {
+			if tx.Hash() == hash {
+				b.resolved++
+				return tx
+			}
+		}
+	}
+	return nil
+}
+
+func TestTxPoolQuery(t *testing.T) {
+	t.Parallel()
+
+	var (
+		signer = types.LatestSigner(params.MergedTestChainConfig)
+		keys   = make([]*ecdsa.PrivateKey, 3)
+		addrs  = make([]common.Address, 3)
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	dynamicTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, tip int64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.MergedTestChainConfig.ChainID,
+			Nonce:     nonce,
+			To:        &to,
+			Gas:       21000,
+			GasTipCap: big.NewInt(tip * params.GWei),
+			GasFeeCap: big.NewInt(100 * params.GWei),
+		})
+	}
+	legacyTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &to,
+			Gas:      21000,
+			GasPrice: big.NewInt(2 * params.GWei),
+		})
+	}
+	blobTx := types.MustSignNewTx(keys[2], signer, &types.BlobTx{
+		ChainID:    uint256.MustFromBig(params.MergedTestChainConfig.ChainID),
+		Nonce:      0,
+		Gas:        21000,
+		GasTipCap:  uint256.NewInt(3 * params.GWei),
+		GasFeeCap:  uint256.NewInt(100 * params.GWei),
+		BlobFeeCap: uint256.NewInt(params.GWei),
+		BlobHashes: []common.Hash{{0x01}},
+	})
+	var (
+		to1, to2 = common.Address{0x01}, common.Address{0x02}
+
+		pending0 = dynamicTx(keys[0], 0, to1, 1)
+		pending1 = legacyTx(keys[0], 1, to2)
+		queued3  = dynamicTx(keys[0], 3, to1, 1)
+		pending2 = dynamicTx(keys[1], 0, to2, 5)
+	)
+	backend := &txPoolBackend{
+		pending: map[common.Address][]*types.Transaction{
+			addrs[0]: {pending0, pending1},
+			addrs[1]: {pending2},
+		},
+		queued: map[common.Address][]*types.Transaction{
+			addrs[0]: {queued3},
+		},
+		blobs: map[common.Address][]*types.Transaction{
+			addrs[2]: {blobTx},
+		},
+	}
+	api := NewTxPoolAPI(backend)
+
+	yes, no := true, false
+	tests := []struct {
+		query TxPoolQuery
+		want  []*types.Transaction
+	}{
+		{TxPoolQuery{}, []*types.Transaction{pending0, pending1, queued3, pending2, blobTx}},
+		{TxPoolQuery{TxPoolFilter: TxPoolFilter{From: []common.Address{addrs[0]}}, Status: "queued"}, []*types.Transaction{queued3}},
+		{TxPoolQuery{TxPoolFilter: TxPoolFilter{From: []common.Address{addrs[2]}}}, []*types.Transaction{blobTx}},
+		{TxPoolQuery{TxPoolFilter: TxPoolFilter{To: []common.Address{to2}}}, []*types.Transaction{pending1, pending2}},
+		{TxPoolQuery{TxPoolFilter: TxPoolFilter{MinTip: (*hexutil.Big)(big.NewInt(2 * params.GWei))}}, []*types.Transaction{pending2, blobTx}},
+		{TxPoolQuery{TxPoolFilter: TxPoolFilter{Types: []hexutil.Uint64{types.LegacyTxType}}}, []*types.Transaction{pending1}},
+		{TxPoolQuery{TxPoolFilter: TxPoolFilter{Blobs: &yes}}, []*types.Transaction{blobTx}},
+		{TxPoolQuery{TxPoolFilter: TxPoolFilter{Blobs: &no}, Status: "pending"}, []*types.Transaction{pending0, pending1, pending2}},
+	}
+	for i, tt := range tests {
+		page, err := api.Query(tt.query)
+		if err != nil {
+			t.Fatalf("test %d: query failed: %v", i, err)
+		}
+		if page.Next != nil {
+			t.Errorf("test %d: unexpected next page", i)
+		}
+		have := make(map[common.Hash]bool)
+		for _, entry := range page.Transactions {
+			have[entry.Hash] = true
+		}
+		if len(have) != len(tt.want) {
+			t.Errorf("test %d: result count mismatch: have %d, want %d", i, len(have), len(tt.want))
+		}
+		for _, tx := range tt.want {
+			if !have[tx.Hash()] {
+				t.Errorf("test %d: missing transaction %x", i, tx.Hash())
+			}
+		}
+	}
+	// Page through the entire pool and ensure all transactions are returned once,
+	// in sender and nonce order
+	var (
+		after *TxPoolCursor
+		seen  []*TxPoolEntry
+	)
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatalf("too many pages")
+		}
+		page, err := api.Query(TxPoolQuery{After: after, Limit: 2})
+		if err != nil {
+			t.Fatalf("page %d: query failed: %v", pages, err)
+		}
+		seen = append(seen, page.Transactions...)
+		if page.Next == nil {
+			break
+		}
+		after = page.Next
+	}
+	if len(seen) != 5 {
+		t.Fatalf("paged result count mismatch: have %d, want 5", len(seen))
+	}
+	for i := 1; i < len(seen); i++ {
+		prev, cur := seen[i-1], seen[i]
+		if prev.From == cur.From && prev.Nonce >= cur.Nonce {
+			t.Errorf("entry %d: nonce order violated", i)
+		}
+		if prev.From != cur.From && prev.From.Cmp(cur.From) > 0 {
+			t.Errorf("entry %d: sender order violated", i)
+		}
+	}
+	for _, entry := range seen {
+		if want := map[bool]string{true: "queued", false: "pending"}[entry.Hash == queued3.Hash()]; entry.Status != want {
+			t.Errorf("transaction %x: status mismatch: have %s, want %s", entry.Hash, entry.Status, want)
+		}
+	}
+	// Ensure blob transactions are only resolved if they end up on the page
+	backend.resolved = 0
+	if _, err := api.Query(TxPoolQuery{Limit: 2}); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	want := 0
+	if seen[0].Hash == blobTx.Hash() || seen[1].Hash == blobTx.Hash() {
+		want = 1
+	}
+	if backend.resolved != want {
+		t.Errorf("resolved blob transaction count mismatch: have %d, want %d", backend.resolved, want)
+	}
+	backend.resolved = 0
+	if _, err := api.Query(TxPoolQuery{TxPoolFilter: TxPoolFilter{Blobs: &yes}}); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if backend.resolved != 1 {
+		t.Errorf("resolved blob transaction count mismatch: have %d, want 1", backend.resolved)
+	}
+	// Ensure invalid queries are rejected
+	if _, err := api.Query(TxPoolQuery{Limit: maxTxPoolQueryLimit + 1}); err == nil {
+		t.Errorf("expected error for limit over maximum")
+	}
+	if _, err := api.Query(TxPoolQuery{Status: "included"}); err == nil {
+		t.Errorf("expected error for invalid status")
+	}
+}
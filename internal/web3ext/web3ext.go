@@ -18,15 +18,18 @@
 package web3ext
 
 var Modules = map[string]string{
-	"admin":  AdminJs,
-	"clique": CliqueJs,
-	"debug":  DebugJs,
-	"eth":    EthJs,
-	"miner":  MinerJs,
-	"net":    NetJs,
-	"rpc":    RpcJs,
-	"txpool": TxpoolJs,
-	"dev":    DevJs,
+	"admin":   AdminJs,
+	"clique":  CliqueJs,
+	"debug":   DebugJs,
+	"eth":     EthJs,
+	"miner":   MinerJs,
+	"net":     NetJs,
+	"rpc":     RpcJs,
+	"trace":   TraceJs,
+	"txpool":  TxpoolJs,
+	"dev":     DevJs,
+	"builder": BuilderJs,
+	"mev":     MevJs,
 }
 
 const CliqueJs = `
@@ -420,6 +423,18 @@ web3._extend({
 			call: 'debug_storageRangeAt',
 			params: 5,
 		}),
+		new web3._extend.Method({
+			name: 'getBlockAccessList',
+			call: 'debug_getBlockAccessList',
+			params: 1,
+			inputFormatter: [null],
+		}),
+		new web3._extend.Method({
+			name: 'executionWitness',
+			call: 'debug_executionWitness',
+			params: 1,
+			inputFormatter: [null],
+		}),
 		new web3._extend.Method({
 			name: 'getModifiedAccountsByNumber',
 			call: 'debug_getModifiedAccountsByNumber',
@@ -555,6 +570,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.toHex]
 		}),
+		new web3._extend.Method({
+			name: 'sendPrivateRawTransaction',
+			call: 'eth_sendPrivateRawTransaction',
+			params: 2,
+			inputFormatter: [null, web3._extend.utils.toHex]
+		}),
 		new web3._extend.Method({
 			name: 'getProof',
 			call: 'eth_getProof',
@@ -670,6 +691,42 @@ web3._extend({
 });
 `
 
+const TraceJs = `
+web3._extend({
+	property: 'trace',
+	methods: [
+		new web3._extend.Method({
+			name: 'block',
+			call: 'trace_block',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'transaction',
+			call: 'trace_transaction',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'filter',
+			call: 'trace_filter',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'replayBlockTransactions',
+			call: 'trace_replayBlockTransactions',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'call',
+			call: 'trace_call',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputCallFormatter, null, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+	]
+});
+`
+
 const TxpoolJs = `
 web3._extend({
 	property: 'txpool',
@@ -698,6 +755,11 @@ web3._extend({
 			call: 'txpool_contentFrom',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'query',
+			call: 'txpool_query',
+			params: 1,
+		}),
 	]
 });
 `
@@ -720,3 +782,43 @@ web3._extend({
 	],
 });
 `
+
+const BuilderJs = `
+web3._extend({
+	property: 'builder',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'registerValidators',
+			call: 'builder_registerValidators',
+			params: 1
+		}),
+	],
+	properties:
+	[
+		new web3._extend.Property({
+			name: 'validators',
+			getter: 'builder_validators'
+		}),
+	]
+});
+`
+
+const MevJs = `
+web3._extend({
+	property: 'mev',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'sendBundle',
+			call: 'mev_sendBundle',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'callBundle',
+			call: 'mev_callBundle',
+			params: 1
+		}),
+	],
+});
+`
@@ -0,0 +1,69 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"fmt"
+	"sort"
+)
+
+// API provides access to the validator registrations known by the block builder.
+type API struct {
+	b *Builder
+}
+
+// ValidatorDuty is an upcoming block proposal the builder submits bids for.
+type ValidatorDuty struct {
+	Slot         Decimal64                    `json:"slot"`
+	Registration *SignedValidatorRegistration `json:"entry"`
+	Relays       []string                     `json:"relays"`
+}
+
+// RegisterValidators verifies and stores validator registrations. They take
+// precedence over the older registrations reported by the relays.
+func (api *API) RegisterValidators(regs []*SignedValidatorRegistration) error {
+	for i, reg := range regs {
+		if reg == nil || reg.Message == nil {
+			return fmt.Errorf("registration %d: missing message", i)
+		}
+		if err := api.b.register(reg); err != nil {
+			return fmt.Errorf("registration %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Validators returns the upcoming block proposals of the registered validators,
+// ordered by slot.
+func (api *API) Validators() ([]*ValidatorDuty, error) {
+	api.b.lock.RLock()
+	defer api.b.lock.RUnlock()
+
+	duties := make([]*ValidatorDuty, 0, len(api.b.duties))
+	for slot, duty := range api.b.duties {
+		entry := &ValidatorDuty{
+			Slot:         Decimal64(slot),
+			Registration: api.b.registrations[duty.pubkey],
+		}
+		for _, r := range duty.relays {
+			entry.Relays = append(entry.Relays, r.url)
+		}
+		duties = append(duties, entry)
+	}
+	sort.Slice(duties, func(i, j int) bool { return duties[i].Slot < duties[j].Slot })
+	return duties, nil
+}
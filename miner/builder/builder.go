@@ -0,0 +1,390 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package builder implements the block builder side of the builder API, which
+// submits the payloads built by the miner to relays as signed bids.
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/rpc"
+	bls "github.com/protolambda/bls12-381-util"
+)
+
+const (
+	// secondsPerSlot is the duration of a beacon chain slot.
+	secondsPerSlot = 12
+
+	// dutiesRefreshInterval is the time between two retrievals of the upcoming
+	// proposer duties from the relays.
+	dutiesRefreshInterval = secondsPerSlot * time.Second
+
+	// relayTimeout is the maximum time allowed for a single relay request.
+	relayTimeout = 2 * time.Second
+)
+
+// Config contains the settings of the block builder.
+type Config struct {
+	SecretKey          string        `toml:"-"`          // Hex encoded BLS secret key signing the bids
+	Relays             []string      `toml:",omitempty"` // Relay endpoints to submit the bids to
+	GenesisTime        uint64        `toml:",omitempty"` // Beacon chain genesis time, for the slot calculation
+	GenesisForkVersion hexutil.Bytes `toml:",omitempty"` // Beacon chain genesis fork version, for the signature domain
+}
+
+// duty is an upcoming block proposal of a registered validator.
+type duty struct {
+	pubkey PublicKey
+	relays []*relay // Relays reporting the proposal
+}
+
+// Builder submits the improved payloads of the miner to the relays, for the
+// slots in which a validator registered at the relays proposes.
+type Builder struct {
+	miner  *miner.Miner
+	config Config
+	sk     *bls.SecretKey
+	pubkey PublicKey
+	domain common.Hash
+	relays []*relay
+
+	registrations map[PublicKey]*SignedValidatorRegistration // Latest verified registration of each validator
+	duties        map[uint64]*duty                           // Upcoming proposals by slot
+	lock          sync.RWMutex
+
+	pending     map[engine.PayloadID]miner.PayloadEvent // Latest improvement of each payload not yet submitted
+	pendingLock sync.Mutex
+	submitCh    chan struct{} // Notifies the submitter of queued payloads
+
+	payloadCh chan miner.PayloadEvent
+	sub       event.Subscription
+	quit      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// New creates a block builder submitting the payloads of the given miner.
+func New(config Config, m *miner.Miner) (*Builder, error) {
+	key, err := hexutil.Decode(config.SecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid builder secret key: %v", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid builder secret key length %d", len(key))
+	}
+	sk := new(bls.SecretKey)
+	if err := sk.Deserialize((*[32]byte)(key)); err != nil {
+		return nil, fmt.Errorf("invalid builder secret key: %v", err)
+	}
+	pk, err := bls.SkToPk(sk)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.GenesisForkVersion) != 4 {
+		return nil, fmt.Errorf("invalid genesis fork version %x", config.GenesisForkVersion)
+	}
+	if len(config.Relays) == 0 {
+		return nil, errors.New("no relays configured")
+	}
+	b := &Builder{
+		miner:         m,
+		config:        config,
+		sk:            sk,
+		pubkey:        pk.Serialize(),
+		domain:        builderDomain([4]byte(config.GenesisForkVersion)),
+		registrations: make(map[PublicKey]*SignedValidatorRegistration),
+		duties:        make(map[uint64]*duty),
+		pending:       make(map[engine.PayloadID]miner.PayloadEvent),
+		submitCh:      make(chan struct{}, 1),
+		payloadCh:     make(chan miner.PayloadEvent, 16),
+		quit:          make(chan struct{}),
+	}
+	client := &http.Client{Timeout: relayTimeout}
+	for _, url := range config.Relays {
+		b.relays = append(b.relays, newRelay(url, client))
+	}
+	return b, nil
+}
+
+// APIs returns the RPC APIs of the block builder.
+func (b *Builder) APIs() []rpc.API {
+	return []rpc.API{{
+		Namespace: "builder",
+		Service:   &API{b},
+	}}
+}
+
+// Start implements node.Lifecycle, starting the submission of the payloads.
+func (b *Builder) Start() error {
+	b.sub = b.miner.SubscribePayloads(b.payloadCh)
+	b.wg.Add(3)
+	go b.loop()
+	go b.submitLoop()
+	go b.dutiesLoop()
+
+	log.Info("Started block builder", "pubkey", hexutil.Bytes(b.pubkey[:]), "relays", len(b.relays))
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the submission of the payloads.
+func (b *Builder) Stop() error {
+	b.sub.Unsubscribe()
+	close(b.quit)
+	b.wg.Wait()
+	return nil
+}
+
+// loop queues the improved payloads for submission. It never blocks on the
+// relays, as the miner waits for the delivery of the payload events.
+func (b *Builder) loop() {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case ev := <-b.payloadCh:
+			b.queuePayload(ev)
+
+		case <-b.sub.Err():
+			return
+		case <-b.quit:
+			return
+		}
+	}
+}
+
+// submitLoop submits the queued payloads to the relays.
+func (b *Builder) submitLoop() {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-b.submitCh:
+			b.submitPending()
+
+		case <-b.quit:
+			return
+		}
+	}
+}
+
+// dutiesLoop keeps the proposer duties up to date.
+func (b *Builder) dutiesLoop() {
+	defer b.wg.Done()
+
+	refresh := time.NewTimer(0)
+	defer refresh.Stop()
+
+	for {
+		select {
+		case <-refresh.C:
+			b.updateDuties()
+			refresh.Reset(dutiesRefreshInterval)
+
+		case <-b.quit:
+			return
+		}
+	}
+}
+
+// queuePayload queues an improved payload for submission, replacing the previous
+// version of the same payload if it's not yet submitted.
+func (b *Builder) queuePayload(ev miner.PayloadEvent) {
+	b.pendingLock.Lock()
+	b.pending[ev.Args.Id()] = ev
+	b.pendingLock.Unlock()
+
+	select {
+	case b.submitCh <- struct{}{}:
+	default:
+	}
+}
+
+// submitPending submits the latest versions of the queued payloads.
+func (b *Builder) submitPending() {
+	b.pendingLock.Lock()
+	pending := b.pending
+	b.pending = make(map[engine.PayloadID]miner.PayloadEvent)
+	b.pendingLock.Unlock()
+
+	for _, ev := range pending {
+		b.submitPayload(ev)
+	}
+}
+
+// slot returns the beacon chain slot of the given timestamp.
+func (b *Builder) slot(timestamp uint64) (uint64, bool) {
+	if timestamp < b.config.GenesisTime {
+		return 0, false
+	}
+	return (timestamp - b.config.GenesisTime) / secondsPerSlot, true
+}
+
+// updateDuties retrieves the upcoming proposer duties from the relays, replacing
+// the previously known ones.
+func (b *Builder) updateDuties() {
+	duties := make(map[uint64]*duty)
+	for _, r := range b.relays {
+		ctx, cancel := context.WithTimeout(context.Background(), relayTimeout)
+		proposals, err := r.validators(ctx)
+		cancel()
+		if err != nil {
+			log.Warn("Failed to retrieve proposer duties", "relay", r.url, "err", err)
+			continue
+		}
+		for _, proposal := range proposals {
+			if proposal.Entry == nil || proposal.Entry.Message == nil {
+				continue
+			}
+			if err := b.register(proposal.Entry); err != nil {
+				log.Debug("Ignoring invalid validator registration", "relay", r.url, "slot", uint64(proposal.Slot), "err", err)
+				continue
+			}
+			slot := uint64(proposal.Slot)
+			if duties[slot] == nil {
+				duties[slot] = &duty{pubkey: proposal.Entry.Message.Pubkey}
+			}
+			if duties[slot].pubkey == proposal.Entry.Message.Pubkey {
+				duties[slot].relays = append(duties[slot].relays, r)
+			}
+		}
+	}
+	b.lock.Lock()
+	b.duties = duties
+	b.lock.Unlock()
+}
+
+// register verifies a validator registration and stores it, unless a newer one
+// is already known.
+func (b *Builder) register(reg *SignedValidatorRegistration) error {
+	pubkey := reg.Message.Pubkey
+
+	b.lock.RLock()
+	known := b.registrations[pubkey]
+	b.lock.RUnlock()
+
+	if known != nil {
+		if known.Message.Timestamp > reg.Message.Timestamp {
+			return nil // Outdated, keep the newer one
+		}
+		if *known.Message == *reg.Message && known.Signature == reg.Signature {
+			return nil // Already verified
+		}
+	}
+	if err := verify(pubkey, reg.Message.hashTreeRoot(), b.domain, reg.Signature); err != nil {
+		return err
+	}
+	b.lock.Lock()
+	b.registrations[pubkey] = reg
+	b.lock.Unlock()
+	return nil
+}
+
+// proposer returns the registration of the validator proposing in the given
+// slot, along with the relays to submit the bids to.
+func (b *Builder) proposer(slot uint64) (*SignedValidatorRegistration, []*relay) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	duty := b.duties[slot]
+	if duty == nil {
+		return nil, nil
+	}
+	return b.registrations[duty.pubkey], duty.relays
+}
+
+// submitPayload signs a bid for an improved payload and submits it to the relays
+// of the slot's proposer.
+func (b *Builder) submitPayload(ev miner.PayloadEvent) {
+	slot, ok := b.slot(ev.Args.Timestamp)
+	if !ok {
+		return
+	}
+	reg, relays := b.proposer(slot)
+	if reg == nil {
+		log.Debug("No registered proposer for slot", "slot", slot)
+		return
+	}
+	// The bid value is only paid if the proposer collects the fees
+	if ev.Args.FeeRecipient != reg.Message.FeeRecipient {
+		log.Warn("Payload fee recipient differs from proposer's", "slot", slot, "have", ev.Args.FeeRecipient, "want", reg.Message.FeeRecipient)
+		return
+	}
+	submission, err := b.newSubmission(slot, reg.Message, ev.Envelope)
+	if err != nil {
+		log.Error("Failed to create block submission", "slot", slot, "err", err)
+		return
+	}
+	var wg sync.WaitGroup
+	for _, r := range relays {
+		wg.Add(1)
+		go func(r *relay) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), relayTimeout)
+			defer cancel()
+			if err := r.submit(ctx, submission); err != nil {
+				log.Warn("Failed to submit block to relay", "relay", r.url, "slot", slot, "hash", submission.Message.BlockHash, "err", err)
+				return
+			}
+			log.Info("Submitted block to relay", "relay", r.url, "slot", slot, "hash", submission.Message.BlockHash, "value", (*big.Int)(submission.Message.Value))
+		}(r)
+	}
+	wg.Wait()
+}
+
+// newSubmission creates a signed block submission of the payload for the slot
+// of the given proposer.
+func (b *Builder) newSubmission(slot uint64, proposer *ValidatorRegistration, envelope *engine.ExecutionPayloadEnvelope) (*SubmitBlockRequest, error) {
+	payload := envelope.ExecutionPayload
+	trace := &BidTrace{
+		Slot:                 Decimal64(slot),
+		ParentHash:           payload.ParentHash,
+		BlockHash:            payload.BlockHash,
+		BuilderPubkey:        b.pubkey,
+		ProposerPubkey:       proposer.Pubkey,
+		ProposerFeeRecipient: proposer.FeeRecipient,
+		GasLimit:             Decimal64(payload.GasLimit),
+		GasUsed:              Decimal64(payload.GasUsed),
+		Value:                (*DecimalBig)(envelope.BlockValue),
+	}
+	submission := &SubmitBlockRequest{
+		Message:          trace,
+		ExecutionPayload: newExecutionPayload(payload),
+		Signature:        sign(b.sk, trace.hashTreeRoot(), b.domain),
+	}
+	if payload.ExcessBlobGas != nil {
+		submission.BlobsBundle = envelope.BlobsBundle
+	}
+	if envelope.Requests != nil {
+		requests, err := newExecutionRequests(envelope.Requests)
+		if err != nil {
+			return nil, err
+		}
+		submission.ExecutionRequests = requests
+	}
+	return submission, nil
+}
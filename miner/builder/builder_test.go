@@ -0,0 +1,243 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner"
+	bls "github.com/protolambda/bls12-381-util"
+)
+
+// testKey derives a BLS secret key from a seed.
+func testKey(t *testing.T, seed string) (*bls.SecretKey, PublicKey) {
+	raw := sha256.Sum256([]byte(seed))
+	raw[0] = 0 // Ensure the key is below the curve order
+
+	sk := new(bls.SecretKey)
+	if err := sk.Deserialize(&raw); err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+	pk, err := bls.SkToPk(sk)
+	if err != nil {
+		t.Fatalf("failed to derive public key: %v", err)
+	}
+	return sk, pk.Serialize()
+}
+
+// mockRelay is a relay serving fixed proposer duties and collecting the block
+// submissions.
+type mockRelay struct {
+	duties []*ProposerDuty
+
+	lock        sync.Mutex
+	versions    []string
+	submissions []map[string]json.RawMessage
+	raw         [][]byte
+}
+
+func (r *mockRelay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case req.Method == http.MethodGet && req.URL.Path == validatorsPath:
+		json.NewEncoder(w).Encode(r.duties)
+
+	case req.Method == http.MethodPost && req.URL.Path == blocksPath:
+		if req.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, `{"code":415,"message":"unsupported content type"}`, http.StatusUnsupportedMediaType)
+			return
+		}
+		blob, _ := io.ReadAll(req.Body)
+		var submission map[string]json.RawMessage
+		if err := json.Unmarshal(blob, &submission); err != nil {
+			http.Error(w, `{"code":400,"message":"invalid json"}`, http.StatusBadRequest)
+			return
+		}
+		r.lock.Lock()
+		r.versions = append(r.versions, req.Header.Get("Eth-Consensus-Version"))
+		r.submissions = append(r.submissions, submission)
+		r.raw = append(r.raw, blob)
+		r.lock.Unlock()
+
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func TestRelaySubmission(t *testing.T) {
+	var (
+		forkVersion = [4]byte{0x10, 0x00, 0x09, 0x10}
+		domain      = builderDomain(forkVersion)
+
+		builderKey, builderPub = testKey(t, "builder")
+		validatorKey, valPub   = testKey(t, "validator")
+		_, otherPub            = testKey(t, "other")
+		feeRecipient           = common.Address{0xfe}
+	)
+	registration := &ValidatorRegistration{
+		FeeRecipient: feeRecipient,
+		GasLimit:     36_000_000,
+		Timestamp:    1000,
+		Pubkey:       valPub,
+	}
+	forged := &ValidatorRegistration{
+		FeeRecipient: common.Address{0xba, 0xd},
+		GasLimit:     36_000_000,
+		Timestamp:    1000,
+		Pubkey:       otherPub,
+	}
+	relay := &mockRelay{
+		duties: []*ProposerDuty{
+			{Slot: 5, ValidatorIndex: 1, Entry: &SignedValidatorRegistration{
+				Message:   registration,
+				Signature: sign(validatorKey, registration.hashTreeRoot(), domain),
+			}},
+			{Slot: 6, ValidatorIndex: 2, Entry: &SignedValidatorRegistration{
+				Message:   forged,
+				Signature: sign(validatorKey, forged.hashTreeRoot(), domain), // Not signed by the validator
+			}},
+		},
+	}
+	server := httptest.NewServer(relay)
+	defer server.Close()
+
+	rawKey := builderKey.Serialize()
+	b, err := New(Config{
+		SecretKey:          hexutil.Encode(rawKey[:]),
+		Relays:             []string{server.URL},
+		GenesisTime:        10_000,
+		GenesisForkVersion: forkVersion[:],
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create builder: %v", err)
+	}
+	// Retrieve the duties and ensure only the validly registered one is known
+	b.updateDuties()
+
+	duties, _ := (&API{b}).Validators()
+	if len(duties) != 1 || duties[0].Slot != 5 || duties[0].Registration.Message.Pubkey != valPub {
+		t.Fatalf("unexpected duties: %+v", duties)
+	}
+	// Submit an improved payload for the slot and ensure the relay receives it in
+	// the expected format
+	withdrawalRequest := make([]byte, 1+withdrawalRequestSize)
+	withdrawalRequest[0] = withdrawalRequestType
+	binary.LittleEndian.PutUint64(withdrawalRequest[1+68:], 32_000_000_000)
+
+	blobGas, excessBlobGas := uint64(0), uint64(0)
+	envelope := &engine.ExecutionPayloadEnvelope{
+		ExecutionPayload: &engine.ExecutableData{
+			ParentHash:    common.Hash{0x01},
+			FeeRecipient:  feeRecipient,
+			LogsBloom:     types.Bloom{}.Bytes(),
+			Number:        100,
+			GasLimit:      36_000_000,
+			GasUsed:       21_000,
+			Timestamp:     10_000 + 5*secondsPerSlot,
+			BaseFeePerGas: big.NewInt(7),
+			BlockHash:     common.Hash{0x02},
+			Transactions:  [][]byte{{0x02, 0xc0}},
+			Withdrawals:   []*types.Withdrawal{{Index: 1, Validator: 2, Address: common.Address{0x03}, Amount: 4}},
+			BlobGasUsed:   &blobGas,
+			ExcessBlobGas: &excessBlobGas,
+		},
+		BlockValue:  big.NewInt(1_000_000_000),
+		BlobsBundle: &engine.BlobsBundleV1{Commitments: []hexutil.Bytes{}, Proofs: []hexutil.Bytes{}, Blobs: []hexutil.Bytes{}},
+		Requests:    [][]byte{withdrawalRequest},
+	}
+	args := &miner.BuildPayloadArgs{
+		Parent:       common.Hash{0x01},
+		Timestamp:    10_000 + 5*secondsPerSlot,
+		FeeRecipient: feeRecipient,
+	}
+	// Queue an outdated version of the payload first, only the latest version is
+	// expected to be submitted
+	outdated := *envelope
+	outdated.BlockValue = big.NewInt(1)
+	b.queuePayload(miner.PayloadEvent{Args: args, Envelope: &outdated})
+	b.queuePayload(miner.PayloadEvent{Args: args, Envelope: envelope})
+	b.submitPending()
+
+	// Payloads for slots without registered proposers, or not paying the proposer
+	// must not be submitted
+	b.submitPayload(miner.PayloadEvent{Args: &miner.BuildPayloadArgs{Timestamp: 10_000 + 6*secondsPerSlot, FeeRecipient: feeRecipient}, Envelope: envelope})
+	b.submitPayload(miner.PayloadEvent{Args: &miner.BuildPayloadArgs{Timestamp: args.Timestamp, FeeRecipient: common.Address{0x01}}, Envelope: envelope})
+
+	relay.lock.Lock()
+	defer relay.lock.Unlock()
+
+	if len(relay.submissions) != 1 {
+		t.Fatalf("submission count mismatch: have %d, want 1", len(relay.submissions))
+	}
+	if relay.versions[0] != "electra" {
+		t.Errorf("consensus version mismatch: have %q, want %q", relay.versions[0], "electra")
+	}
+	for _, field := range []string{"message", "execution_payload", "blobs_bundle", "execution_requests", "signature"} {
+		if _, ok := relay.submissions[0][field]; !ok {
+			t.Errorf("submission missing field %q", field)
+		}
+	}
+	var message map[string]string
+	if err := json.Unmarshal(relay.submissions[0]["message"], &message); err != nil {
+		t.Fatalf("failed to decode bid trace: %v", err)
+	}
+	want := map[string]string{
+		"slot":                   "5",
+		"parent_hash":            common.Hash{0x01}.Hex(),
+		"block_hash":             common.Hash{0x02}.Hex(),
+		"builder_pubkey":         hexutil.Encode(builderPub[:]),
+		"proposer_pubkey":        hexutil.Encode(valPub[:]),
+		"proposer_fee_recipient": hexutil.Encode(feeRecipient[:]),
+		"gas_limit":              "36000000",
+		"gas_used":               "21000",
+		"value":                  "1000000000",
+	}
+	for field, value := range want {
+		if message[field] != value {
+			t.Errorf("bid trace field %q mismatch: have %q, want %q", field, message[field], value)
+		}
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(relay.submissions[0]["execution_payload"], &payload); err != nil {
+		t.Fatalf("failed to decode execution payload: %v", err)
+	}
+	if payload["block_number"] != "100" || payload["base_fee_per_gas"] != "7" || payload["blob_gas_used"] != "0" {
+		t.Errorf("unexpected execution payload encoding: %v", payload)
+	}
+	// Ensure the submission decodes and the bid signature verifies
+	var submission SubmitBlockRequest
+	if err := json.Unmarshal(relay.raw[0], &submission); err != nil {
+		t.Fatalf("failed to decode submission: %v", err)
+	}
+	if err := verify(builderPub, submission.Message.hashTreeRoot(), domain, submission.Signature); err != nil {
+		t.Errorf("invalid bid signature: %v", err)
+	}
+	if len(submission.ExecutionRequests.Withdrawals) != 1 || submission.ExecutionRequests.Withdrawals[0].Amount != 32_000_000_000 {
+		t.Errorf("unexpected execution requests: %+v", submission.ExecutionRequests)
+	}
+}
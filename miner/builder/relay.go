@@ -0,0 +1,311 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Paths of the relay endpoints used by the builder.
+const (
+	validatorsPath = "/relay/v1/builder/validators"
+	blocksPath     = "/relay/v1/builder/blocks"
+)
+
+// ProposerDuty is an upcoming block proposal of a registered validator, as
+// reported by a relay.
+type ProposerDuty struct {
+	Slot           Decimal64                    `json:"slot"`
+	ValidatorIndex Decimal64                    `json:"validator_index"`
+	Entry          *SignedValidatorRegistration `json:"entry"`
+}
+
+// Withdrawal is a validator withdrawal in the beacon API format.
+type Withdrawal struct {
+	Index          Decimal64      `json:"index"`
+	ValidatorIndex Decimal64      `json:"validator_index"`
+	Address        common.Address `json:"address"`
+	Amount         Decimal64      `json:"amount"`
+}
+
+// ExecutionPayload is an execution payload in the beacon API format.
+type ExecutionPayload struct {
+	ParentHash    common.Hash     `json:"parent_hash"`
+	FeeRecipient  common.Address  `json:"fee_recipient"`
+	StateRoot     common.Hash     `json:"state_root"`
+	ReceiptsRoot  common.Hash     `json:"receipts_root"`
+	LogsBloom     hexutil.Bytes   `json:"logs_bloom"`
+	PrevRandao    common.Hash     `json:"prev_randao"`
+	BlockNumber   Decimal64       `json:"block_number"`
+	GasLimit      Decimal64       `json:"gas_limit"`
+	GasUsed       Decimal64       `json:"gas_used"`
+	Timestamp     Decimal64       `json:"timestamp"`
+	ExtraData     hexutil.Bytes   `json:"extra_data"`
+	BaseFeePerGas *DecimalBig     `json:"base_fee_per_gas"`
+	BlockHash     common.Hash     `json:"block_hash"`
+	Transactions  []hexutil.Bytes `json:"transactions"`
+	Withdrawals   []*Withdrawal   `json:"withdrawals"`
+	BlobGasUsed   *Decimal64      `json:"blob_gas_used,omitempty"`
+	ExcessBlobGas *Decimal64      `json:"excess_blob_gas,omitempty"`
+}
+
+// DepositRequest is a deposit request in the beacon API format.
+type DepositRequest struct {
+	Pubkey                PublicKey   `json:"pubkey"`
+	WithdrawalCredentials common.Hash `json:"withdrawal_credentials"`
+	Amount                Decimal64   `json:"amount"`
+	Signature             Signature   `json:"signature"`
+	Index                 Decimal64   `json:"index"`
+}
+
+// WithdrawalRequest is a withdrawal request in the beacon API format.
+type WithdrawalRequest struct {
+	SourceAddress   common.Address `json:"source_address"`
+	ValidatorPubkey PublicKey      `json:"validator_pubkey"`
+	Amount          Decimal64      `json:"amount"`
+}
+
+// ConsolidationRequest is a consolidation request in the beacon API format.
+type ConsolidationRequest struct {
+	SourceAddress common.Address `json:"source_address"`
+	SourcePubkey  PublicKey      `json:"source_pubkey"`
+	TargetPubkey  PublicKey      `json:"target_pubkey"`
+}
+
+// ExecutionRequests are the execution layer triggered requests of a payload in
+// the beacon API format.
+type ExecutionRequests struct {
+	Deposits       []*DepositRequest       `json:"deposits"`
+	Withdrawals    []*WithdrawalRequest    `json:"withdrawals"`
+	Consolidations []*ConsolidationRequest `json:"consolidations"`
+}
+
+// SubmitBlockRequest is a signed block submission to a relay.
+type SubmitBlockRequest struct {
+	Message           *BidTrace             `json:"message"`
+	ExecutionPayload  *ExecutionPayload     `json:"execution_payload"`
+	BlobsBundle       *engine.BlobsBundleV1 `json:"blobs_bundle,omitempty"`
+	ExecutionRequests *ExecutionRequests    `json:"execution_requests,omitempty"`
+	Signature         Signature             `json:"signature"`
+}
+
+// consensusVersion returns the name of the beacon chain fork the submission is
+// made for.
+func (r *SubmitBlockRequest) consensusVersion() string {
+	switch {
+	case r.ExecutionRequests != nil:
+		return "electra"
+	case r.ExecutionPayload.ExcessBlobGas != nil:
+		return "deneb"
+	default:
+		return "capella"
+	}
+}
+
+// newExecutionPayload converts an engine API payload into the beacon API format.
+func newExecutionPayload(data *engine.ExecutableData) *ExecutionPayload {
+	payload := &ExecutionPayload{
+		ParentHash:    data.ParentHash,
+		FeeRecipient:  data.FeeRecipient,
+		StateRoot:     data.StateRoot,
+		ReceiptsRoot:  data.ReceiptsRoot,
+		LogsBloom:     data.LogsBloom,
+		PrevRandao:    data.Random,
+		BlockNumber:   Decimal64(data.Number),
+		GasLimit:      Decimal64(data.GasLimit),
+		GasUsed:       Decimal64(data.GasUsed),
+		Timestamp:     Decimal64(data.Timestamp),
+		ExtraData:     data.ExtraData,
+		BaseFeePerGas: (*DecimalBig)(data.BaseFeePerGas),
+		BlockHash:     data.BlockHash,
+		Transactions:  make([]hexutil.Bytes, len(data.Transactions)),
+		Withdrawals:   make([]*Withdrawal, len(data.Withdrawals)),
+	}
+	for i, tx := range data.Transactions {
+		payload.Transactions[i] = tx
+	}
+	for i, w := range data.Withdrawals {
+		payload.Withdrawals[i] = &Withdrawal{
+			Index:          Decimal64(w.Index),
+			ValidatorIndex: Decimal64(w.Validator),
+			Address:        w.Address,
+			Amount:         Decimal64(w.Amount),
+		}
+	}
+	if data.BlobGasUsed != nil {
+		used := Decimal64(*data.BlobGasUsed)
+		payload.BlobGasUsed = &used
+	}
+	if data.ExcessBlobGas != nil {
+		excess := Decimal64(*data.ExcessBlobGas)
+		payload.ExcessBlobGas = &excess
+	}
+	return payload
+}
+
+// Types and sizes of the SSZ encoded execution layer requests, see EIP-7685.
+const (
+	depositRequestType       = 0x00
+	withdrawalRequestType    = 0x01
+	consolidationRequestType = 0x02
+
+	depositRequestSize       = 48 + 32 + 8 + 96 + 8
+	withdrawalRequestSize    = 20 + 48 + 8
+	consolidationRequestSize = 20 + 48 + 48
+)
+
+// newExecutionRequests converts the type prefixed execution requests of an
+// engine API payload into the beacon API format.
+func newExecutionRequests(requests [][]byte) (*ExecutionRequests, error) {
+	reqs := &ExecutionRequests{
+		Deposits:       []*DepositRequest{},
+		Withdrawals:    []*WithdrawalRequest{},
+		Consolidations: []*ConsolidationRequest{},
+	}
+	for _, request := range requests {
+		if len(request) == 0 {
+			return nil, errors.New("empty execution request")
+		}
+		typ, data := request[0], request[1:]
+		switch typ {
+		case depositRequestType:
+			if len(data)%depositRequestSize != 0 {
+				return nil, fmt.Errorf("invalid deposit requests length %d", len(data))
+			}
+			for ; len(data) > 0; data = data[depositRequestSize:] {
+				req := new(DepositRequest)
+				copy(req.Pubkey[:], data[:48])
+				copy(req.WithdrawalCredentials[:], data[48:80])
+				req.Amount = Decimal64(binary.LittleEndian.Uint64(data[80:88]))
+				copy(req.Signature[:], data[88:184])
+				req.Index = Decimal64(binary.LittleEndian.Uint64(data[184:192]))
+				reqs.Deposits = append(reqs.Deposits, req)
+			}
+		case withdrawalRequestType:
+			if len(data)%withdrawalRequestSize != 0 {
+				return nil, fmt.Errorf("invalid withdrawal requests length %d", len(data))
+			}
+			for ; len(data) > 0; data = data[withdrawalRequestSize:] {
+				req := new(WithdrawalRequest)
+				copy(req.SourceAddress[:], data[:20])
+				copy(req.ValidatorPubkey[:], data[20:68])
+				req.Amount = Decimal64(binary.LittleEndian.Uint64(data[68:76]))
+				reqs.Withdrawals = append(reqs.Withdrawals, req)
+			}
+		case consolidationRequestType:
+			if len(data)%consolidationRequestSize != 0 {
+				return nil, fmt.Errorf("invalid consolidation requests length %d", len(data))
+			}
+			for ; len(data) > 0; data = data[consolidationRequestSize:] {
+				req := new(ConsolidationRequest)
+				copy(req.SourceAddress[:], data[:20])
+				copy(req.SourcePubkey[:], data[20:68])
+				copy(req.TargetPubkey[:], data[68:116])
+				reqs.Consolidations = append(reqs.Consolidations, req)
+			}
+		default:
+			return nil, fmt.Errorf("unknown execution request type %d", typ)
+		}
+	}
+	return reqs, nil
+}
+
+// relay is a client of the builder API of a relay.
+type relay struct {
+	url    string
+	client *http.Client
+}
+
+// newRelay creates a client for the relay at the given endpoint.
+func newRelay(url string, client *http.Client) *relay {
+	return &relay{url: strings.TrimSuffix(url, "/"), client: client}
+}
+
+// validators retrieves the upcoming proposer duties of the validators registered
+// at the relay.
+func (r *relay) validators(ctx context.Context) ([]*ProposerDuty, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url+validatorsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, relayError(res)
+	}
+	var duties []*ProposerDuty
+	if err := json.NewDecoder(res.Body).Decode(&duties); err != nil {
+		return nil, err
+	}
+	return duties, nil
+}
+
+// submit sends a signed block submission to the relay.
+func (r *relay) submit(ctx context.Context, submission *SubmitBlockRequest) error {
+	blob, err := json.Marshal(submission)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url+blocksPath, bytes.NewReader(blob))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Eth-Consensus-Version", submission.consensusVersion())
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+		return relayError(res)
+	}
+	return nil
+}
+
+// relayError converts a failed relay response into an error, including the
+// message reported by the relay if any.
+func relayError(res *http.Response) error {
+	var body struct {
+		Message string `json:"message"`
+	}
+	blob, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	if err := json.Unmarshal(blob, &body); err == nil && body.Message != "" {
+		return fmt.Errorf("relay error %d: %s", res.StatusCode, body.Message)
+	}
+	return fmt.Errorf("relay error %d", res.StatusCode)
+}
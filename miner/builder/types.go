@@ -0,0 +1,256 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	bls "github.com/protolambda/bls12-381-util"
+)
+
+// domainApplicationBuilder is the signature domain type of the builder API
+// messages, see https://github.com/ethereum/builder-specs.
+var domainApplicationBuilder = [4]byte{0x00, 0x00, 0x00, 0x01}
+
+// PublicKey is a BLS public key.
+type PublicKey [48]byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (pk PublicKey) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(pk[:]).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (pk *PublicKey) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("PublicKey", input, pk[:])
+}
+
+// Signature is a BLS signature.
+type Signature [96]byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (sig Signature) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(sig[:]).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (sig *Signature) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("Signature", input, sig[:])
+}
+
+// ValidatorRegistration is the preference of a validator for the blocks built
+// for it.
+type ValidatorRegistration struct {
+	FeeRecipient common.Address `json:"fee_recipient"`
+	GasLimit     Decimal64      `json:"gas_limit"`
+	Timestamp    Decimal64      `json:"timestamp"`
+	Pubkey       PublicKey      `json:"pubkey"`
+}
+
+// hashTreeRoot returns the SSZ hash tree root of the registration.
+func (r *ValidatorRegistration) hashTreeRoot() common.Hash {
+	return merkleize([]common.Hash{
+		addressChunk(r.FeeRecipient),
+		uint64Chunk(uint64(r.GasLimit)),
+		uint64Chunk(uint64(r.Timestamp)),
+		pubkeyRoot(r.Pubkey),
+	})
+}
+
+// SignedValidatorRegistration is a validator registration signed by the
+// validator.
+type SignedValidatorRegistration struct {
+	Message   *ValidatorRegistration `json:"message"`
+	Signature Signature              `json:"signature"`
+}
+
+// BidTrace is the summary of a block submitted to a relay, which the bid
+// signature commits to.
+type BidTrace struct {
+	Slot                 Decimal64      `json:"slot"`
+	ParentHash           common.Hash    `json:"parent_hash"`
+	BlockHash            common.Hash    `json:"block_hash"`
+	BuilderPubkey        PublicKey      `json:"builder_pubkey"`
+	ProposerPubkey       PublicKey      `json:"proposer_pubkey"`
+	ProposerFeeRecipient common.Address `json:"proposer_fee_recipient"`
+	GasLimit             Decimal64      `json:"gas_limit"`
+	GasUsed              Decimal64      `json:"gas_used"`
+	Value                *DecimalBig    `json:"value"`
+}
+
+// hashTreeRoot returns the SSZ hash tree root of the bid trace.
+func (t *BidTrace) hashTreeRoot() common.Hash {
+	var value common.Hash // uint256, little endian
+	if t.Value != nil {
+		(*big.Int)(t.Value).FillBytes(value[:])
+		for i, j := 0, len(value)-1; i < j; i, j = i+1, j-1 {
+			value[i], value[j] = value[j], value[i]
+		}
+	}
+	return merkleize([]common.Hash{
+		uint64Chunk(uint64(t.Slot)),
+		t.ParentHash,
+		t.BlockHash,
+		pubkeyRoot(t.BuilderPubkey),
+		pubkeyRoot(t.ProposerPubkey),
+		addressChunk(t.ProposerFeeRecipient),
+		uint64Chunk(uint64(t.GasLimit)),
+		uint64Chunk(uint64(t.GasUsed)),
+		value,
+	})
+}
+
+// Decimal64 is an integer marshalled as a decimal string, as the beacon APIs
+// expect.
+type Decimal64 uint64
+
+// MarshalText implements encoding.TextMarshaler.
+func (i Decimal64) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(i), 10)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *Decimal64) UnmarshalText(input []byte) error {
+	n, err := strconv.ParseUint(string(input), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = Decimal64(n)
+	return nil
+}
+
+// DecimalBig is a big integer marshalled as a decimal string, as the beacon
+// APIs expect.
+type DecimalBig big.Int
+
+// MarshalText implements encoding.TextMarshaler.
+func (b *DecimalBig) MarshalText() ([]byte, error) {
+	return []byte((*big.Int)(b).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *DecimalBig) UnmarshalText(input []byte) error {
+	if _, ok := (*big.Int)(b).SetString(string(input), 10); !ok {
+		return errors.New("invalid decimal integer")
+	}
+	return nil
+}
+
+// builderDomain computes the signature domain of the builder API messages on
+// the network with the given genesis fork version. As opposed to the beacon
+// chain messages, the genesis validators root is always zero.
+func builderDomain(genesisForkVersion [4]byte) common.Hash {
+	var (
+		forkVersion   common.Hash
+		forkDataRoot  common.Hash
+		domain        common.Hash
+		hasher        = sha256.New()
+		genesisValSet common.Hash
+	)
+	copy(forkVersion[:], genesisForkVersion[:])
+	hasher.Write(forkVersion[:])
+	hasher.Write(genesisValSet[:])
+	hasher.Sum(forkDataRoot[:0])
+
+	copy(domain[:], domainApplicationBuilder[:])
+	copy(domain[4:], forkDataRoot[:28])
+	return domain
+}
+
+// signingRoot computes the root signed for an object in the given domain.
+func signingRoot(root common.Hash, domain common.Hash) common.Hash {
+	var (
+		signing common.Hash
+		hasher  = sha256.New()
+	)
+	hasher.Write(root[:])
+	hasher.Write(domain[:])
+	hasher.Sum(signing[:0])
+	return signing
+}
+
+// sign signs the object with the given root in the given domain.
+func sign(sk *bls.SecretKey, root common.Hash, domain common.Hash) Signature {
+	msg := signingRoot(root, domain)
+	return bls.Sign(sk, msg[:]).Serialize()
+}
+
+// verify checks the signature of the object with the given root in the given
+// domain.
+func verify(pubkey PublicKey, root common.Hash, domain common.Hash, signature Signature) error {
+	var (
+		pk  bls.Pubkey
+		sig bls.Signature
+	)
+	raw := [48]byte(pubkey)
+	if err := pk.Deserialize(&raw); err != nil {
+		return err
+	}
+	rawSig := [96]byte(signature)
+	if err := sig.Deserialize(&rawSig); err != nil {
+		return err
+	}
+	msg := signingRoot(root, domain)
+	if !bls.Verify(&pk, msg[:], &sig) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// uint64Chunk returns the SSZ chunk of an integer.
+func uint64Chunk(n uint64) (chunk common.Hash) {
+	binary.LittleEndian.PutUint64(chunk[:], n)
+	return chunk
+}
+
+// addressChunk returns the SSZ chunk of an address.
+func addressChunk(addr common.Address) (chunk common.Hash) {
+	copy(chunk[:], addr[:])
+	return chunk
+}
+
+// pubkeyRoot returns the SSZ hash tree root of a public key.
+func pubkeyRoot(pk PublicKey) common.Hash {
+	var chunks [64]byte
+	copy(chunks[:], pk[:])
+	return sha256.Sum256(chunks[:])
+}
+
+// merkleize returns the root of the binary merkle tree of the chunks, padded
+// with zero chunks to the next power of two.
+func merkleize(chunks []common.Hash) common.Hash {
+	size := 1
+	for size < len(chunks) {
+		size *= 2
+	}
+	layer := make([]common.Hash, size)
+	copy(layer, chunks)
+	for len(layer) > 1 {
+		next := make([]common.Hash, len(layer)/2)
+		for i := range next {
+			next[i] = sha256.Sum256(append(layer[2*i][:], layer[2*i+1][:]...))
+		}
+		layer = next
+	}
+	return layer[0]
+}
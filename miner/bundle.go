@@ -0,0 +1,361 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// maxBundles is the maximum number of bundles held by the miner for inclusion.
+	maxBundles = 1024
+
+	// maxBundleDistance is the maximum number of blocks ahead of the current head
+	// a bundle may target, so the pool can't be filled with bundles which are
+	// never pruned.
+	maxBundleDistance = 32
+)
+
+var (
+	errBundleEmpty    = errors.New("bundle has no transactions")
+	errBundleBlobTx   = errors.New("blob transactions are not supported in bundles")
+	errBundleNoTarget = errors.New("bundle has no target block")
+	errBundleStale    = errors.New("bundle targets an already mined block")
+	errBundleTooFar   = errors.New("bundle targets a block too far in the future")
+	errBundlePoolFull = errors.New("bundle pool is full")
+)
+
+// Bundle is an ordered list of transactions which is included into a block
+// atomically: either all of them execute successfully in the given order, or
+// none of them are included.
+type Bundle struct {
+	Txs          types.Transactions
+	BlockNumber  uint64 // Number of the block the bundle is valid for
+	MinTimestamp uint64 // Earliest block timestamp the bundle is valid for, zero if unlimited
+	MaxTimestamp uint64 // Latest block timestamp the bundle is valid for, zero if unlimited
+}
+
+// Hash returns the identifier of the bundle, the hash of its transaction hashes.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+// includable reports whether the bundle may be included in the block with the
+// given number and timestamp.
+func (b *Bundle) includable(number, time uint64) bool {
+	if b.BlockNumber != number {
+		return false
+	}
+	if b.MinTimestamp != 0 && time < b.MinTimestamp {
+		return false
+	}
+	if b.MaxTimestamp != 0 && time > b.MaxTimestamp {
+		return false
+	}
+	return true
+}
+
+// BundleTxResult is the outcome of executing a transaction of a bundle.
+type BundleTxResult struct {
+	Hash             common.Hash
+	From             common.Address
+	To               *common.Address
+	GasUsed          uint64
+	GasFees          *big.Int // Priority fees paid to the coinbase for the gas used
+	CoinbaseTransfer *big.Int // Value transferred to the coinbase on top of the fees
+	Err              error    // Reason the transaction failed, nil if it succeeded
+}
+
+// BundleSimulation is the outcome of executing a bundle.
+type BundleSimulation struct {
+	Results      []*BundleTxResult
+	GasUsed      uint64
+	CoinbaseDiff *big.Int // Total payment to the coinbase, fees and transfers
+}
+
+// GasPrice returns the effective price paid to the coinbase per unit of gas
+// used by the bundle.
+func (s *BundleSimulation) GasPrice() *big.Int {
+	if s.GasUsed == 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Div(s.CoinbaseDiff, new(big.Int).SetUint64(s.GasUsed))
+}
+
+// bundlePool is the set of bundles submitted to the miner for inclusion.
+type bundlePool struct {
+	bundles map[common.Hash]*Bundle
+	lock    sync.Mutex
+}
+
+func newBundlePool() *bundlePool {
+	return &bundlePool{bundles: make(map[common.Hash]*Bundle)}
+}
+
+// add inserts a bundle into the pool, dropping the ones targeting blocks older
+// than the given head to make room.
+func (p *bundlePool) add(bundle *Bundle, head uint64) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.prune(head)
+	if len(p.bundles) >= maxBundles {
+		return errBundlePoolFull
+	}
+	p.bundles[bundle.Hash()] = bundle
+	return nil
+}
+
+// pending returns the bundles which can be included in the block with the given
+// number and timestamp, in submission independent but deterministic order.
+func (p *bundlePool) pending(number, time uint64) []*Bundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.prune(number - 1)
+
+	var bundles []*Bundle
+	for _, bundle := range p.bundles {
+		if bundle.includable(number, time) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	sort.Slice(bundles, func(i, j int) bool {
+		hi, hj := bundles[i].Hash(), bundles[j].Hash()
+		return hi.Cmp(hj) < 0
+	})
+	return bundles
+}
+
+// prune drops the bundles which target blocks not after the given head. The
+// lock is assumed to be held.
+func (p *bundlePool) prune(head uint64) {
+	for hash, bundle := range p.bundles {
+		if bundle.BlockNumber <= head {
+			delete(p.bundles, hash)
+		}
+	}
+}
+
+// SendBundle adds a bundle to the set considered for inclusion in the built
+// blocks. Bundles may target one of the next maxBundleDistance blocks, and are
+// dropped once their target block is mined.
+func (miner *Miner) SendBundle(bundle *Bundle) error {
+	if len(bundle.Txs) == 0 {
+		return errBundleEmpty
+	}
+	for _, tx := range bundle.Txs {
+		if tx.Type() == types.BlobTxType {
+			return errBundleBlobTx
+		}
+	}
+	if bundle.BlockNumber == 0 {
+		return errBundleNoTarget
+	}
+	head := miner.chain.CurrentBlock().Number.Uint64()
+	if bundle.BlockNumber <= head {
+		return errBundleStale
+	}
+	if bundle.BlockNumber > head+maxBundleDistance {
+		return errBundleTooFar
+	}
+	return miner.bundles.add(bundle, head)
+}
+
+// SimulateBundle executes a bundle on top of the given parent block, in a block
+// with the given timestamp and fee recipient, without including it anywhere. If
+// the fee recipient is not specified, the pending one is used. Execution failures
+// are reported in the transaction results; an error is only returned if the
+// simulation could not be set up.
+func (miner *Miner) SimulateBundle(bundle *Bundle, parent common.Hash, timestamp uint64, coinbase *common.Address) (*BundleSimulation, error) {
+	if len(bundle.Txs) == 0 {
+		return nil, errBundleEmpty
+	}
+	if coinbase == nil {
+		miner.confMu.RLock()
+		pending := miner.config.PendingFeeRecipient
+		miner.confMu.RUnlock()
+		coinbase = &pending
+	}
+	env, err := miner.prepareWork(&generateParams{
+		timestamp:  timestamp,
+		forceTime:  true,
+		parentHash: parent,
+		coinbase:   *coinbase,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	sim, _ := miner.executeBundle(env, bundle)
+	return sim, nil
+}
+
+// executeBundle executes the transactions of a bundle on top of the environment,
+// stopping at the first failing or reverting one. The environment is left in an
+// undefined state on failure, since the state journal is discarded after each
+// transaction and can't be rolled back, so it should be a throwaway copy. The
+// simulation result covers the transactions up to the failing one.
+func (miner *Miner) executeBundle(env *environment, bundle *Bundle) (*BundleSimulation, error) {
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+	sim := &BundleSimulation{CoinbaseDiff: new(big.Int)}
+	for _, tx := range bundle.Txs {
+		result := &BundleTxResult{
+			Hash:             tx.Hash(),
+			To:               tx.To(),
+			GasFees:          new(big.Int),
+			CoinbaseTransfer: new(big.Int),
+		}
+		sim.Results = append(sim.Results, result)
+
+		from, err := types.Sender(env.signer, tx)
+		if err != nil {
+			result.Err = err
+			return sim, fmt.Errorf("bundle transaction %x invalid: %w", tx.Hash(), err)
+		}
+		result.From = from
+
+		balance := env.state.GetBalance(env.coinbase).ToBig()
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		receipt, err := core.ApplyTransaction(env.evm, env.gasPool, env.state, env.header, tx, &env.header.GasUsed)
+		if err != nil {
+			result.Err = err
+			return sim, fmt.Errorf("bundle transaction %x failed: %w", tx.Hash(), err)
+		}
+		env.txs = append(env.txs, tx)
+		env.receipts = append(env.receipts, receipt)
+		env.tcount++
+
+		tip, _ := tx.EffectiveGasTip(env.header.BaseFee)
+		diff := new(big.Int).Sub(env.state.GetBalance(env.coinbase).ToBig(), balance)
+
+		result.GasUsed = receipt.GasUsed
+		result.GasFees.Mul(tip, new(big.Int).SetUint64(receipt.GasUsed))
+		result.CoinbaseTransfer.Sub(diff, result.GasFees)
+		if receipt.Status == types.ReceiptStatusFailed {
+			result.Err = vm.ErrExecutionReverted
+			return sim, fmt.Errorf("bundle transaction %x reverted", tx.Hash())
+		}
+		sim.GasUsed += receipt.GasUsed
+		sim.CoinbaseDiff.Add(sim.CoinbaseDiff, diff)
+	}
+	return sim, nil
+}
+
+// commitBundle includes the transactions of a bundle into the environment if
+// all of them execute successfully on top of it, otherwise it's left untouched.
+//
+// The bundle is executed on a copy of the environment, which replaces the
+// original only if the entire bundle succeeded.
+func (miner *Miner) commitBundle(env *environment, bundle *Bundle) (*BundleSimulation, error) {
+	cpy := env.copy()
+	sim, err := miner.executeBundle(cpy, bundle)
+	if err != nil {
+		return sim, err
+	}
+	// The copy doesn't inherit the trie prefetcher used to build the witness,
+	// hand it over before replacing the original
+	if env.witness != nil {
+		env.state.StopPrefetcher()
+		cpy.state.StartPrefetcher("miner", cpy.witness)
+	}
+	*env = *cpy
+	return sim, nil
+}
+
+// commitBundles simulates the bundles targeting the block being built on top of
+// the environment, and commits the ones fully succeeding, ordered by the
+// effective price they pay to the coinbase per unit of gas.
+func (miner *Miner) commitBundles(env *environment, minPrice *big.Int, interrupt *atomic.Int32) error {
+	bundles := miner.bundles.pending(env.header.Number.Uint64(), env.header.Time)
+	if len(bundles) == 0 {
+		return nil
+	}
+	type candidate struct {
+		bundle *Bundle
+		price  *big.Int
+	}
+	var candidates []candidate
+	for _, bundle := range bundles {
+		if interrupt != nil {
+			if signal := interrupt.Load(); signal != commitInterruptNone {
+				return signalToErr(signal)
+			}
+		}
+		sim, err := miner.executeBundle(env.copy(), bundle)
+		if err != nil {
+			log.Trace("Skipping failing bundle", "hash", bundle.Hash(), "err", err)
+			continue
+		}
+		if price := sim.GasPrice(); minPrice == nil || price.Cmp(minPrice) >= 0 {
+			candidates = append(candidates, candidate{bundle, price})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].price.Cmp(candidates[j].price) > 0
+	})
+	// Commit the bundles in order of their price. A bundle might fail on top of
+	// the ones committed before it, e.g. when they compete for the same state,
+	// in which case it's skipped.
+	for _, c := range candidates {
+		if interrupt != nil {
+			if signal := interrupt.Load(); signal != commitInterruptNone {
+				return signalToErr(signal)
+			}
+		}
+		if _, err := miner.commitBundle(env, c.bundle); err != nil {
+			log.Trace("Skipping conflicting bundle", "hash", c.bundle.Hash(), "err", err)
+		}
+	}
+	return nil
+}
+
+// copy returns a deep copy of the environment, which can be used to execute
+// transactions without affecting the original.
+func (env *environment) copy() *environment {
+	cpy := *env
+	cpy.state = env.state.Copy()
+	cpy.witness = cpy.state.Witness()
+	cpy.evm = vm.NewEVM(env.evm.Context, cpy.state, env.evm.ChainConfig(), env.evm.Config)
+	cpy.header = types.CopyHeader(env.header)
+	if env.gasPool != nil {
+		gp := *env.gasPool
+		cpy.gasPool = &gp
+	}
+	cpy.txs = slices.Clone(env.txs)
+	cpy.receipts = slices.Clone(env.receipts)
+	cpy.sidecars = slices.Clone(env.sidecars)
+	return &cpy
+}
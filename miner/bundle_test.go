@@ -0,0 +1,155 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestBundleBuilding(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	signer := types.LatestSigner(params.TestChainConfig)
+	transfer := func(nonce uint64, value int64, price int64) *types.Transaction {
+		return types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &testUserAddress,
+			Value:    big.NewInt(value),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(price * params.InitialBaseFee),
+		})
+	}
+	var (
+		cheap   = &Bundle{Txs: types.Transactions{transfer(0, 1, 2), transfer(1, 1, 2)}, BlockNumber: 1}
+		pricey  = &Bundle{Txs: types.Transactions{transfer(0, 2, 3), transfer(1, 2, 3)}, BlockNumber: 1}
+		failing = &Bundle{Txs: types.Transactions{transfer(0, 3, 5), transfer(5, 3, 5)}, BlockNumber: 1}
+		later   = &Bundle{Txs: types.Transactions{transfer(0, 4, 10)}, BlockNumber: 2}
+	)
+	for _, bundle := range []*Bundle{cheap, pricey, failing, later} {
+		if err := w.SendBundle(bundle); err != nil {
+			t.Fatalf("failed to send bundle: %v", err)
+		}
+	}
+	if err := w.SendBundle(&Bundle{Txs: types.Transactions{transfer(0, 1, 1)}}); err == nil {
+		t.Fatal("expected error for bundle without target block")
+	}
+	if err := w.SendBundle(&Bundle{Txs: types.Transactions{transfer(0, 1, 1)}, BlockNumber: maxBundleDistance + 1}); err != errBundleTooFar {
+		t.Fatalf("bundle too far in the future: have %v, want %v", err, errBundleTooFar)
+	}
+	coinbase := common.Address{0xc0}
+
+	// Build a block and ensure only the best paying valid bundle targeting it is
+	// included, before the conflicting pool transactions
+	result := w.generateWork(&generateParams{
+		timestamp:  uint64(time.Now().Unix()),
+		parentHash: b.chain.CurrentBlock().Hash(),
+		coinbase:   coinbase,
+	}, false)
+	if result.err != nil {
+		t.Fatalf("failed to generate block: %v", result.err)
+	}
+	txs := result.block.Transactions()
+	if len(txs) != len(pricey.Txs) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(txs), len(pricey.Txs))
+	}
+	for i, tx := range txs {
+		if tx.Hash() != pricey.Txs[i].Hash() {
+			t.Errorf("transaction %d: hash mismatch: have %x, want %x", i, tx.Hash(), pricey.Txs[i].Hash())
+		}
+	}
+	// Simulate the failing bundle and ensure the failure is reported
+	sim, err := w.SimulateBundle(failing, b.chain.CurrentBlock().Hash(), uint64(time.Now().Unix()), &coinbase)
+	if err != nil {
+		t.Fatalf("failed to simulate bundle: %v", err)
+	}
+	if len(sim.Results) != 2 {
+		t.Fatalf("result count mismatch: have %d, want 2", len(sim.Results))
+	}
+	if sim.Results[0].Err != nil || sim.Results[1].Err == nil {
+		t.Errorf("unexpected results: first %v, second %v", sim.Results[0].Err, sim.Results[1].Err)
+	}
+	// Simulate the valid bundle and ensure the payment is accounted for
+	sim, err = w.SimulateBundle(pricey, b.chain.CurrentBlock().Hash(), uint64(time.Now().Unix()), &coinbase)
+	if err != nil {
+		t.Fatalf("failed to simulate bundle: %v", err)
+	}
+	if sim.GasUsed != 2*params.TxGas {
+		t.Errorf("gas used mismatch: have %d, want %d", sim.GasUsed, 2*params.TxGas)
+	}
+	if sim.GasPrice().Sign() <= 0 {
+		t.Errorf("non-positive bundle price: %v", sim.GasPrice())
+	}
+}
+
+// Tests that a bundle failing midway leaves the block being built untouched, and
+// a succeeding one is included in full.
+func TestCommitBundleAtomic(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	signer := types.LatestSigner(params.TestChainConfig)
+	transfer := func(nonce uint64) *types.Transaction {
+		return types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &testUserAddress,
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+		})
+	}
+	for _, witness := range []bool{false, true} {
+		env, err := w.prepareWork(&generateParams{
+			timestamp:  uint64(time.Now().Unix()),
+			parentHash: b.chain.CurrentBlock().Hash(),
+			coinbase:   common.Address{0xc0},
+		}, witness)
+		if err != nil {
+			t.Fatalf("witness %v: failed to prepare work: %v", witness, err)
+		}
+		failing := &Bundle{Txs: types.Transactions{transfer(0), transfer(1), transfer(5)}, BlockNumber: 1}
+		if _, err := w.commitBundle(env, failing); err == nil {
+			t.Fatalf("witness %v: expected failing bundle to be rejected", witness)
+		}
+		if len(env.txs) != 0 || env.tcount != 0 || env.header.GasUsed != 0 {
+			t.Errorf("witness %v: failing bundle partially committed: %d txs, %d gas", witness, len(env.txs), env.header.GasUsed)
+		}
+		if nonce := env.state.GetNonce(testBankAddress); nonce != 0 {
+			t.Errorf("witness %v: failing bundle modified the state: nonce %d", witness, nonce)
+		}
+		valid := &Bundle{Txs: types.Transactions{transfer(0), transfer(1)}, BlockNumber: 1}
+		if _, err := w.commitBundle(env, valid); err != nil {
+			t.Fatalf("witness %v: failed to commit bundle: %v", witness, err)
+		}
+		if len(env.txs) != 2 || env.header.GasUsed != 2*params.TxGas {
+			t.Errorf("witness %v: bundle not committed in full: %d txs, %d gas", witness, len(env.txs), env.header.GasUsed)
+		}
+		if nonce := env.state.GetNonce(testBankAddress); nonce != 2 {
+			t.Errorf("witness %v: nonce mismatch: have %d, want 2", witness, nonce)
+		}
+		if witness && env.witness != env.state.Witness() {
+			t.Errorf("witness not tracking the committed state")
+		}
+		env.state.StopPrefetcher()
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+)
+
+// BuildInclusionList assembles an inclusion list for the block on top of the
+// given parent from the pending transactions of the pool, as per EIP-7805. The
+// best paying executable transactions are picked until the size limit of the
+// list is reached.
+func (miner *Miner) BuildInclusionList(parentHash common.Hash) (types.Transactions, error) {
+	parent := miner.chain.GetHeaderByHash(parentHash)
+	if parent == nil {
+		return nil, errors.New("unknown parent block")
+	}
+	statedb, err := miner.chain.StateAt(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	// Only retrieve the transactions affording the base fee of the next block,
+	// blob transactions can't be part of inclusion lists
+	var (
+		number  = new(big.Int).Add(parent.Number, common.Big1)
+		baseFee *big.Int
+		filter  = txpool.PendingFilter{OnlyPlainTxs: true}
+	)
+	if miner.chainConfig.IsLondon(number) {
+		baseFee = eip1559.CalcBaseFee(miner.chainConfig, parent)
+		filter.BaseFee = uint256.MustFromBig(baseFee)
+	}
+	var (
+		signer = types.LatestSigner(miner.chainConfig)
+		txs    = newTransactionsByPriceAndNonce(signer, miner.txpool.Pending(filter), baseFee)
+		nonces = make(map[common.Address]uint64)
+		list   types.Transactions
+		size   int
+	)
+	for {
+		ltx, _ := txs.Peek()
+		if ltx == nil {
+			break
+		}
+		tx := ltx.Resolve()
+		if tx == nil {
+			txs.Pop()
+			continue
+		}
+		from, _ := types.Sender(signer, tx)
+
+		// The pool tracks the nonces of the current head, which might not be the
+		// requested parent, skip the senders whose transactions aren't executable
+		nonce, ok := nonces[from]
+		if !ok {
+			nonce = statedb.GetNonce(from)
+		}
+		if tx.Nonce() != nonce {
+			txs.Pop()
+			continue
+		}
+		enc, err := tx.MarshalBinary()
+		if err != nil {
+			txs.Pop()
+			continue
+		}
+		if size+len(enc) > engine.MaxBytesPerInclusionList {
+			// Smaller transactions of other senders might still fit
+			txs.Pop()
+			continue
+		}
+		list = append(list, tx)
+		size += len(enc)
+		nonces[from] = nonce + 1
+		txs.Shift()
+	}
+	return list, nil
+}
+
+// commitInclusionList adds the transactions of the inclusion list not included
+// in the block yet, as long as they fit and execute fine.
+func (miner *Miner) commitInclusionList(env *environment, list types.Transactions) {
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+	included := make(map[common.Hash]bool, len(env.txs))
+	for _, tx := range env.txs {
+		included[tx.Hash()] = true
+	}
+	for _, tx := range list {
+		if included[tx.Hash()] || tx.Type() == types.BlobTxType {
+			continue
+		}
+		if env.gasPool.Gas() < tx.Gas() {
+			continue
+		}
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		if err := miner.commitTransaction(env, tx); err != nil {
+			log.Trace("Skipping inclusion list transaction", "hash", tx.Hash(), "err", err)
+			continue
+		}
+		included[tx.Hash()] = true
+	}
+}
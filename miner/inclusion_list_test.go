@@ -0,0 +1,80 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestInclusionList(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	// Ensure the inclusion list is assembled from the pending transactions
+	head := b.chain.CurrentBlock().Hash()
+	list, err := w.BuildInclusionList(head)
+	if err != nil {
+		t.Fatalf("failed to build inclusion list: %v", err)
+	}
+	if len(list) != len(pendingTxs) || list[0].Hash() != pendingTxs[0].Hash() {
+		t.Fatalf("unexpected inclusion list: have %d txs, want %d", len(list), len(pendingTxs))
+	}
+	if _, err := w.BuildInclusionList(common.Hash{0x01}); err == nil {
+		t.Fatalf("expected error for unknown parent")
+	}
+	// Build a payload, and ensure a listed transaction unknown to the pool gets
+	// included once the inclusion list is set
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:    head,
+		Timestamp: uint64(time.Now().Unix()),
+	}, false)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	payload.UpdateInclusionList(types.Transactions{newTxs[0]})
+
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		payload.lock.Lock()
+		done := payload.fullListVersion == 1
+		payload.lock.Unlock()
+
+		if done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("payload not rebuilt with the inclusion list")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	txs := payload.Resolve().ExecutionPayload.Transactions
+	if len(txs) != 2 {
+		t.Fatalf("transaction count mismatch: have %d, want 2", len(txs))
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(txs[1]); err != nil {
+		t.Fatalf("failed to decode transaction: %v", err)
+	}
+	if tx.Hash() != newTxs[0].Hash() {
+		t.Errorf("inclusion list transaction mismatch: have %x, want %x", tx.Hash(), newTxs[0].Hash())
+	}
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -48,6 +50,7 @@ type Config struct {
 	GasCeil             uint64         // Target gas ceiling for mined blocks.
 	GasPrice            *big.Int       // Minimum gas price for mining a transaction
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
+	Strategy            string         `toml:",omitempty"` // Block building strategy, see NewStrategy
 }
 
 // DefaultConfig contains default settings for miner.
@@ -60,6 +63,8 @@ var DefaultConfig = Config{
 	// for payload generation. It should be enough for Geth to
 	// run 3 rounds.
 	Recommit: 2 * time.Second,
+
+	Strategy: StrategyGreedy,
 }
 
 // Miner is the main object which takes care of submitting new work to consensus
@@ -71,18 +76,30 @@ type Miner struct {
 	engine      consensus.Engine
 	txpool      *txpool.TxPool
 	prio        []common.Address // A list of senders to prioritize
+	strategy    Strategy         // Policy ordering the transactions in the built blocks
+	bundles     *bundlePool      // Transaction bundles to include atomically
 	chain       *core.BlockChain
 	pending     *pending
 	pendingMu   sync.Mutex // Lock protects the pending block
+
+	payloadFeed  event.Feed
+	payloadScope event.SubscriptionScope
 }
 
 // New creates a new miner with provided config.
 func New(eth Backend, config Config, engine consensus.Engine) *Miner {
+	strategy, err := NewStrategy(config.Strategy)
+	if err != nil {
+		log.Warn("Falling back to the default block building strategy", "err", err)
+		strategy, _ = NewStrategy(StrategyGreedy)
+	}
 	return &Miner{
 		config:      &config,
 		chainConfig: eth.BlockChain().Config(),
 		engine:      engine,
 		txpool:      eth.TxPool(),
+		strategy:    strategy,
+		bundles:     newBundlePool(),
 		chain:       eth.BlockChain(),
 		pending:     &pending{},
 	}
@@ -117,6 +134,13 @@ func (miner *Miner) SetPrioAddresses(prio []common.Address) {
 	miner.confMu.Unlock()
 }
 
+// SetStrategy sets the policy ordering the transactions in the built blocks.
+func (miner *Miner) SetStrategy(strategy Strategy) {
+	miner.confMu.Lock()
+	miner.strategy = strategy
+	miner.confMu.Unlock()
+}
+
 // SetGasCeil sets the gaslimit to strive for when mining blocks post 1559.
 // For pre-1559 blocks, it sets the ceiling.
 func (miner *Miner) SetGasCeil(ceil uint64) {
@@ -133,6 +157,13 @@ func (miner *Miner) SetGasTip(tip *big.Int) error {
 	return nil
 }
 
+// SubscribePayloads registers a subscription for the improvements of the
+// payloads being built. The events are delivered synchronously by the payload
+// building, so the subscribers must receive them promptly.
+func (miner *Miner) SubscribePayloads(ch chan<- PayloadEvent) event.Subscription {
+	return miner.payloadScope.Track(miner.payloadFeed.Subscribe(ch))
+}
+
 // BuildPayload builds the payload according to the provided parameters.
 func (miner *Miner) BuildPayload(args *BuildPayloadArgs, witness bool) (*Payload, error) {
 	return miner.buildPayload(args, witness)
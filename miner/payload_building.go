@@ -81,6 +81,11 @@ type Payload struct {
 	stop          chan struct{}
 	lock          sync.Mutex
 	cond          *sync.Cond
+
+	inclusionList        types.Transactions // Inclusion list the payload needs to satisfy (FOCIL)
+	inclusionListVersion uint64             // Number of inclusion list updates received
+	fullListVersion      uint64             // Inclusion list version the full block was built with
+	inclusionListCh      chan struct{}      // Notification channel for inclusion list updates
 }
 
 // newPayload initializes the payload object.
@@ -91,31 +96,50 @@ func newPayload(empty *types.Block, emptyRequests [][]byte, witness *stateless.W
 		emptyRequests: emptyRequests,
 		emptyWitness:  witness,
 		stop:          make(chan struct{}),
+
+		inclusionListCh: make(chan struct{}, 1),
 	}
 	log.Info("Starting work on payload", "id", payload.id)
 	payload.cond = sync.NewCond(&payload.lock)
 	return payload
 }
 
-// update updates the full-block with latest built version.
-func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
+// PayloadEvent is posted when a payload being built is updated with a more
+// profitable block.
+type PayloadEvent struct {
+	Args     *BuildPayloadArgs
+	Envelope *engine.ExecutionPayloadEnvelope
+}
+
+// update updates the full-block with latest built version, reporting whether
+// the new version improved the payload. The inclusion list version is the one
+// the block was built with.
+func (payload *Payload) update(r *newPayloadResult, listVersion uint64, elapsed time.Duration) bool {
 	payload.lock.Lock()
 	defer payload.lock.Unlock()
 
 	select {
 	case <-payload.stop:
-		return // reject stale update
+		return false // reject stale update
 	default:
 	}
+	if listVersion < payload.fullListVersion {
+		return false // reject block built with an outdated inclusion list
+	}
+	var improved bool
 	// Ensure the newly provided full block has a higher transaction fee.
 	// In post-merge stage, there is no uncle reward anymore and transaction
 	// fee(apart from the mev revenue) is the only indicator for comparison.
-	if payload.full == nil || r.fees.Cmp(payload.fullFees) > 0 {
+	// A block honouring a newer inclusion list is always taken however, as
+	// satisfying the list is a validity condition of the payload.
+	if payload.full == nil || listVersion > payload.fullListVersion || r.fees.Cmp(payload.fullFees) > 0 {
+		improved = true
 		payload.full = r.block
 		payload.fullFees = r.fees
 		payload.sidecars = r.sidecars
 		payload.requests = r.requests
 		payload.fullWitness = r.witness
+		payload.fullListVersion = listVersion
 
 		feesInEther := new(big.Float).Quo(new(big.Float).SetInt(r.fees), big.NewFloat(params.Ether))
 		log.Info("Updated payload",
@@ -131,6 +155,31 @@ func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
 		)
 	}
 	payload.cond.Broadcast() // fire signal for notifying full block
+	return improved
+}
+
+// UpdateInclusionList sets the inclusion list the payload needs to satisfy. The
+// payload is rebuilt right away to honour it.
+func (payload *Payload) UpdateInclusionList(txs types.Transactions) {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	payload.inclusionList = txs
+	payload.inclusionListVersion++
+
+	select {
+	case payload.inclusionListCh <- struct{}{}:
+	default:
+	}
+}
+
+// currentInclusionList returns the latest inclusion list of the payload, along
+// with its version.
+func (payload *Payload) currentInclusionList() (types.Transactions, uint64) {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	return payload.inclusionList, payload.inclusionListVersion
 }
 
 // Resolve returns the latest built payload and also terminates the background
@@ -253,11 +302,28 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 
 		for {
 			select {
+			case <-payload.inclusionListCh:
+				// Rebuild right away to satisfy the new inclusion list
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(0)
 			case <-timer.C:
 				start := time.Now()
+				list, version := payload.currentInclusionList()
+				fullParams.inclusionList = list
+
 				r := miner.generateWork(fullParams, witness)
 				if r.err == nil {
-					payload.update(r, time.Since(start))
+					if payload.update(r, version, time.Since(start)) && miner.payloadScope.Count() > 0 {
+						miner.payloadFeed.Send(PayloadEvent{
+							Args:     args,
+							Envelope: engine.BlockToExecutableData(r.block, r.fees, r.sidecars, r.requests),
+						})
+					}
 				} else {
 					log.Info("Error while generating work", "id", payload.id, "err", r.err)
 				}
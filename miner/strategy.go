@@ -0,0 +1,300 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Names of the block building strategies shipped with the miner.
+const (
+	StrategyGreedy = "greedy" // Order transactions by their offered tip
+	StrategyProfit = "profit" // Order transactions by their simulated coinbase payment
+)
+
+// Strategy is a block building policy, deciding which of the pending pool
+// transactions are included into a block and in which order.
+//
+// The miner applies the returned transactions in order, skipping the ones which
+// don't fit into the block anymore or fail, along with all the later ones of the
+// same sender. Implementations must be safe for concurrent use.
+type Strategy interface {
+	// Order returns the transactions to apply to the block being built on top
+	// of the pending state, picked from the given source. The transactions of
+	// the same sender must be returned in nonce order.
+	Order(state *PendingState, source *TxSource) []*OrderedTx
+}
+
+// NewStrategy returns the block building strategy with the given name.
+func NewStrategy(name string) (Strategy, error) {
+	switch name {
+	case "", StrategyGreedy:
+		return greedyStrategy{}, nil
+	case StrategyProfit:
+		return profitStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown block building strategy %q", name)
+	}
+}
+
+// TxSource is the set of pending pool transactions a strategy picks from, grouped
+// by sender and ordered by nonce.
+type TxSource struct {
+	Plain map[common.Address][]*txpool.LazyTransaction
+	Blob  map[common.Address][]*txpool.LazyTransaction
+	Prio  []common.Address // Senders whose transactions are to be included first
+}
+
+// split separates the transactions of the prioritized senders from the rest.
+func (s *TxSource) split() (prio, normal *TxSource) {
+	prio = &TxSource{
+		Plain: make(map[common.Address][]*txpool.LazyTransaction),
+		Blob:  make(map[common.Address][]*txpool.LazyTransaction),
+	}
+	normal = &TxSource{
+		Plain: maps.Clone(s.Plain),
+		Blob:  maps.Clone(s.Blob),
+	}
+	for _, account := range s.Prio {
+		if txs := normal.Plain[account]; len(txs) > 0 {
+			delete(normal.Plain, account)
+			prio.Plain[account] = txs
+		}
+		if txs := normal.Blob[account]; len(txs) > 0 {
+			delete(normal.Blob, account)
+			prio.Blob[account] = txs
+		}
+	}
+	return prio, normal
+}
+
+// OrderedTx is a transaction picked by a strategy for inclusion, along with its
+// sender.
+type OrderedTx struct {
+	From common.Address
+	Tx   *txpool.LazyTransaction
+}
+
+// PendingState is the state a block is being built on top of. Strategies can use
+// it to simulate transactions without affecting the block.
+type PendingState struct {
+	env       *environment  // Environment of the block being built, must not be modified
+	sim       *environment  // Private copy of the environment to simulate on, created on demand
+	interrupt *atomic.Int32 // Signal aborting the block building
+}
+
+// Header returns the header of the block being built.
+func (s *PendingState) Header() *types.Header {
+	return types.CopyHeader(s.env.header)
+}
+
+// Signer returns the signer of the block being built.
+func (s *PendingState) Signer() types.Signer {
+	return s.env.signer
+}
+
+// Interrupted reports whether building the block was aborted, in which case the
+// strategy should return as soon as possible.
+func (s *PendingState) Interrupted() bool {
+	return s.interrupt != nil && s.interrupt.Load() != commitInterruptNone
+}
+
+// Simulate executes a transaction on top of the previously simulated ones. It
+// returns the gas used and the payment made to the coinbase, including both the
+// fees and direct transfers. A failing transaction leaves the state untouched.
+func (s *PendingState) Simulate(tx *types.Transaction) (uint64, *big.Int, error) {
+	if s.sim == nil {
+		s.sim = s.env.copy()
+		if s.sim.gasPool == nil {
+			s.sim.gasPool = new(core.GasPool).AddGas(s.sim.header.GasLimit)
+		}
+	}
+	var (
+		env     = s.sim
+		snap    = env.state.Snapshot()
+		gp      = env.gasPool.Gas()
+		balance = env.state.GetBalance(env.coinbase).ToBig()
+	)
+	env.state.SetTxContext(tx.Hash(), env.tcount)
+	receipt, err := core.ApplyTransaction(env.evm, env.gasPool, env.state, env.header, tx, &env.header.GasUsed)
+	if err != nil {
+		env.state.RevertToSnapshot(snap)
+		env.gasPool.SetGas(gp)
+		return 0, nil, err
+	}
+	env.tcount++
+	return receipt.GasUsed, new(big.Int).Sub(env.state.GetBalance(env.coinbase).ToBig(), balance), nil
+}
+
+// greedyStrategy orders the transactions by the tip they offer, picking the best
+// paying one of all senders each time. The transactions of the prioritized
+// senders are ordered before all others.
+type greedyStrategy struct{}
+
+func (greedyStrategy) Order(state *PendingState, source *TxSource) []*OrderedTx {
+	prio, normal := source.split()
+
+	txs := orderByPrice(state, prio)
+	return append(txs, orderByPrice(state, normal)...)
+}
+
+// orderByPrice merges the plain and blob transactions of the source into a single
+// list ordered by the tip they offer, while honouring the nonce order.
+func orderByPrice(state *PendingState, source *TxSource) []*OrderedTx {
+	var (
+		header   = state.env.header
+		plainTxs = newTransactionsByPriceAndNonce(state.env.signer, source.Plain, header.BaseFee)
+		blobTxs  = newTransactionsByPriceAndNonce(state.env.signer, source.Blob, header.BaseFee)
+		txs      []*OrderedTx
+	)
+	for {
+		var next *transactionsByPriceAndNonce
+
+		pltx, ptip := plainTxs.Peek()
+		bltx, btip := blobTxs.Peek()
+		switch {
+		case pltx == nil && bltx == nil:
+			return txs
+		case pltx == nil:
+			next = blobTxs
+		case bltx == nil:
+			next = plainTxs
+		case ptip.Lt(btip):
+			next = blobTxs
+		default:
+			next = plainTxs
+		}
+		txs = append(txs, &OrderedTx{From: next.heads[0].from, Tx: next.heads[0].tx})
+		next.Shift()
+	}
+}
+
+// profitStrategy orders the transactions by the payment they actually make to the
+// coinbase per unit of gas used, measured by simulating them on the pending state.
+// Unlike the greedy strategy it accounts for the unused gas allowance and the
+// direct coinbase transfers, at the cost of executing every transaction twice.
+//
+// The transactions are simulated in the greedy order, which approximates the state
+// they are eventually executed on. The prioritized senders are ordered first.
+type profitStrategy struct{}
+
+func (profitStrategy) Order(state *PendingState, source *TxSource) []*OrderedTx {
+	prio, normal := source.split()
+
+	txs := orderByProfit(state, orderByPrice(state, prio))
+	return append(txs, orderByProfit(state, orderByPrice(state, normal))...)
+}
+
+// profitTx is a transaction along with its simulated coinbase payment per gas.
+type profitTx struct {
+	*OrderedTx
+	price *big.Int
+}
+
+// orderByProfit simulates the transactions in the given order and reorders them
+// by their payment per gas, while honouring the nonce order. The transactions
+// failing the simulation are dropped, along with the later ones of their sender.
+func orderByProfit(state *PendingState, txs []*OrderedTx) []*OrderedTx {
+	var (
+		senders = make(map[common.Address][]*profitTx)
+		failed  = make(map[common.Address]bool)
+	)
+	for _, otx := range txs {
+		if state.Interrupted() {
+			break
+		}
+		if failed[otx.From] {
+			continue
+		}
+		tx := otx.Tx.Resolve()
+		if tx == nil {
+			failed[otx.From] = true
+			continue
+		}
+		gas, payment, err := state.Simulate(tx)
+		if errors.Is(err, core.ErrGasLimitReached) {
+			break // The simulated block is full
+		}
+		if err != nil {
+			failed[otx.From] = true
+			continue
+		}
+		price := new(big.Int)
+		if gas > 0 && payment.Sign() > 0 {
+			price.Div(payment, new(big.Int).SetUint64(gas))
+		}
+		senders[otx.From] = append(senders[otx.From], &profitTx{otx, price})
+	}
+	// Merge the nonce ordered transactions of the senders by their price
+	heads := make(profitHeap, 0, len(senders))
+	for _, list := range senders {
+		heads = append(heads, list)
+	}
+	heap.Init(&heads)
+
+	ordered := make([]*OrderedTx, 0, len(txs))
+	for len(heads) > 0 {
+		list := heads[0]
+		ordered = append(ordered, list[0].OrderedTx)
+		if len(list) > 1 {
+			heads[0] = list[1:]
+			heap.Fix(&heads, 0)
+		} else {
+			heap.Pop(&heads)
+		}
+	}
+	return ordered
+}
+
+// profitHeap is a heap of the remaining transactions of each sender, ordered by
+// the price of their first transaction.
+type profitHeap [][]*profitTx
+
+func (h profitHeap) Len() int { return len(h) }
+func (h profitHeap) Less(i, j int) bool {
+	// If the prices are equal, use the time the transaction was first seen for
+	// deterministic sorting
+	cmp := h[i][0].price.Cmp(h[j][0].price)
+	if cmp == 0 {
+		return h[i][0].Tx.Time.Before(h[j][0].Tx.Time)
+	}
+	return cmp > 0
+}
+func (h profitHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *profitHeap) Push(x interface{}) {
+	*h = append(*h, x.([]*profitTx))
+}
+
+func (h *profitHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[0 : n-1]
+	return x
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestStrategyOrdering(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	coinbase := common.Address{0xc0}
+	env, err := w.prepareWork(&generateParams{
+		timestamp:  uint64(time.Now().Unix()),
+		parentHash: b.chain.CurrentBlock().Hash(),
+		coinbase:   coinbase,
+	}, false)
+	if err != nil {
+		t.Fatalf("failed to prepare block: %v", err)
+	}
+	env.state.AddBalance(testUserAddress, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+
+	// Create a transaction offering a high tip, and one offering a lower tip but
+	// paying the coinbase directly
+	signer := types.LatestSigner(params.TestChainConfig)
+	lazy := func(key *ecdsa.PrivateKey, to common.Address, value int64, price int64) *txpool.LazyTransaction {
+		tx := types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    0,
+			To:       &to,
+			Value:    big.NewInt(value),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(price * params.InitialBaseFee),
+		})
+		return &txpool.LazyTransaction{
+			Hash:      tx.Hash(),
+			Tx:        tx,
+			Time:      tx.Time(),
+			GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+			GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+			Gas:       tx.Gas(),
+		}
+	}
+	var (
+		tipper = lazy(testBankKey, testUserAddress, 1, 3)
+		briber = lazy(testUserKey, coinbase, params.Ether/100, 2)
+	)
+	source := func(prio ...common.Address) *TxSource {
+		return &TxSource{
+			Plain: map[common.Address][]*txpool.LazyTransaction{
+				testBankAddress: {tipper},
+				testUserAddress: {briber},
+			},
+			Prio: prio,
+		}
+	}
+	tests := []struct {
+		strategy string
+		prio     []common.Address
+		want     []*txpool.LazyTransaction
+	}{
+		{StrategyGreedy, nil, []*txpool.LazyTransaction{tipper, briber}},
+		{StrategyGreedy, []common.Address{testUserAddress}, []*txpool.LazyTransaction{briber, tipper}},
+		{StrategyProfit, nil, []*txpool.LazyTransaction{briber, tipper}},
+		{StrategyProfit, []common.Address{testBankAddress}, []*txpool.LazyTransaction{tipper, briber}},
+	}
+	for i, tt := range tests {
+		strategy, err := NewStrategy(tt.strategy)
+		if err != nil {
+			t.Fatalf("test %d: failed to create strategy: %v", i, err)
+		}
+		txs := strategy.Order(&PendingState{env: env}, source(tt.prio...))
+		if len(txs) != len(tt.want) {
+			t.Fatalf("test %d: transaction count mismatch: have %d, want %d", i, len(txs), len(tt.want))
+		}
+		for j, tx := range txs {
+			if tx.Tx.Hash != tt.want[j].Hash {
+				t.Errorf("test %d, tx %d: hash mismatch: have %x, want %x", i, j, tx.Tx.Hash, tt.want[j].Hash)
+			}
+		}
+	}
+	// Ensure the simulations didn't leak into the block being built
+	if env.tcount != 0 || env.header.GasUsed != 0 {
+		t.Errorf("block modified by strategy: %d txs, %d gas used", env.tcount, env.header.GasUsed)
+	}
+	if _, err := NewStrategy("random"); err == nil {
+		t.Errorf("expected error for unknown strategy")
+	}
+}
@@ -92,6 +92,8 @@ type generateParams struct {
 	withdrawals types.Withdrawals // List of withdrawals to include in block (shanghai field)
 	beaconRoot  *common.Hash      // The beacon root (cancun field).
 	noTxs       bool              // Flag whether an empty block without any transaction is expected
+
+	inclusionList types.Transactions // Transactions to include if the pool didn't fill them in (FOCIL)
 }
 
 // generateWork generates a sealing block based on the given parameters.
@@ -111,6 +113,11 @@ func (miner *Miner) generateWork(params *generateParams, witness bool) *newPaylo
 		if errors.Is(err, errBlockInterruptedByTimeout) {
 			log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(miner.config.Recommit))
 		}
+		// Whatever the pool left out from the inclusion list needs to be added,
+		// even if the building was interrupted
+		if len(params.inclusionList) > 0 {
+			miner.commitInclusionList(work, params.inclusionList)
+		}
 	}
 
 	body := types.Body{Transactions: work.txs, Withdrawals: params.withdrawals}
@@ -317,12 +324,16 @@ func (miner *Miner) applyTransaction(env *environment, tx *types.Transaction) (*
 	return receipt, err
 }
 
-func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, interrupt *atomic.Int32) error {
+// commitTransactions applies the transactions ordered by the block building
+// strategy to the block. The transactions which don't fit into the block or fail
+// are skipped, along with all the later ones of the same sender.
+func (miner *Miner) commitTransactions(env *environment, txs []*OrderedTx, interrupt *atomic.Int32) error {
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
 	}
-	for {
+	skipped := make(map[common.Address]bool)
+	for _, otx := range txs {
 		// Check interruption signal and abort building if it's fired.
 		if interrupt != nil {
 			if signal := interrupt.Load(); signal != commitInterruptNone {
@@ -334,40 +345,14 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 			log.Trace("Not enough gas for further transactions", "have", env.gasPool, "want", params.TxGas)
 			break
 		}
-		// If we don't have enough blob space for any further blob transactions,
-		// skip that list altogether
-		if !blobTxs.Empty() && env.blobs >= eip4844.MaxBlobsPerBlock(miner.chainConfig, env.header.Time) {
-			log.Trace("Not enough blob space for further blob transactions")
-			blobTxs.Clear()
-			// Fall though to pick up any plain txs
-		}
-		// Retrieve the next transaction and abort if all done.
-		var (
-			ltx *txpool.LazyTransaction
-			txs *transactionsByPriceAndNonce
-		)
-		pltx, ptip := plainTxs.Peek()
-		bltx, btip := blobTxs.Peek()
-
-		switch {
-		case pltx == nil:
-			txs, ltx = blobTxs, bltx
-		case bltx == nil:
-			txs, ltx = plainTxs, pltx
-		default:
-			if ptip.Lt(btip) {
-				txs, ltx = blobTxs, bltx
-			} else {
-				txs, ltx = plainTxs, pltx
-			}
-		}
-		if ltx == nil {
-			break
+		ltx, from := otx.Tx, otx.From
+		if skipped[from] {
+			continue
 		}
 		// If we don't have enough space for the next transaction, skip the account.
 		if env.gasPool.Gas() < ltx.Gas {
 			log.Trace("Not enough gas left for transaction", "hash", ltx.Hash, "left", env.gasPool.Gas(), "needed", ltx.Gas)
-			txs.Pop()
+			skipped[from] = true
 			continue
 		}
 
@@ -378,7 +363,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 			left := eip4844.MaxBlobsPerBlock(miner.chainConfig, env.header.Time) - env.blobs
 			if left < int(ltx.BlobGas/params.BlobTxBlobGasPerBlob) {
 				log.Trace("Not enough blob space left for transaction", "hash", ltx.Hash, "left", left, "needed", ltx.BlobGas/params.BlobTxBlobGasPerBlob)
-				txs.Pop()
+				skipped[from] = true
 				continue
 			}
 		}
@@ -387,7 +372,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		tx := ltx.Resolve()
 		if tx == nil {
 			log.Trace("Ignoring evicted transaction", "hash", ltx.Hash)
-			txs.Pop()
+			skipped[from] = true
 			continue
 		}
 
@@ -408,15 +393,11 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 			}
 		}
 
-		// Error may be ignored here. The error has already been checked
-		// during transaction acceptance in the transaction pool.
-		from, _ := types.Sender(env.signer, tx)
-
 		// Check whether the tx is replay protected. If we're not in the EIP155 hf
 		// phase, start ignoring the sender until we do.
 		if tx.Protected() && !miner.chainConfig.IsEIP155(env.header.Number) {
 			log.Trace("Ignoring replay protected transaction", "hash", ltx.Hash, "eip155", miner.chainConfig.EIP155Block)
-			txs.Pop()
+			skipped[from] = true
 			continue
 		}
 		// Start executing the transaction
@@ -425,33 +406,36 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		err := miner.commitTransaction(env, tx)
 		switch {
 		case errors.Is(err, core.ErrNonceTooLow):
-			// New head notification data race between the transaction pool and miner, shift
+			// New head notification data race between the transaction pool and miner, skip
 			log.Trace("Skipping transaction with low nonce", "hash", ltx.Hash, "sender", from, "nonce", tx.Nonce())
-			txs.Shift()
 
 		case errors.Is(err, nil):
-			// Everything ok, collect the logs and shift in the next transaction from the same account
-			txs.Shift()
+			// Everything ok, move on to the next transaction
 
 		default:
 			// Transaction is regarded as invalid, drop all consecutive transactions from
 			// the same sender because of `nonce-too-high` clause.
 			log.Debug("Transaction failed, account skipped", "hash", ltx.Hash, "err", err)
-			txs.Pop()
+			skipped[from] = true
 		}
 	}
 	return nil
 }
 
 // fillTransactions retrieves the pending transactions from the txpool and fills them
-// into the given sealing block. The transaction selection and ordering strategy can
-// be customized with the plugin in the future.
+// into the given sealing block, in the order decided by the block building strategy.
 func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment) error {
 	miner.confMu.RLock()
 	tip := miner.config.GasPrice
 	prio := miner.prio
+	strategy := miner.strategy
 	miner.confMu.RUnlock()
 
+	// Include the profitable bundles first, the pool transactions conflicting
+	// with them will fail the nonce checks and be skipped
+	if err := miner.commitBundles(env, tip, interrupt); err != nil {
+		return err
+	}
 	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
 	filter := txpool.PendingFilter{
 		MinTip: uint256.MustFromBig(tip),
@@ -468,36 +452,15 @@ func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment)
 	filter.OnlyPlainTxs, filter.OnlyBlobTxs = false, true
 	pendingBlobTxs := miner.txpool.Pending(filter)
 
-	// Split the pending transactions into locals and remotes.
-	prioPlainTxs, normalPlainTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingPlainTxs
-	prioBlobTxs, normalBlobTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingBlobTxs
-
-	for _, account := range prio {
-		if txs := normalPlainTxs[account]; len(txs) > 0 {
-			delete(normalPlainTxs, account)
-			prioPlainTxs[account] = txs
-		}
-		if txs := normalBlobTxs[account]; len(txs) > 0 {
-			delete(normalBlobTxs, account)
-			prioBlobTxs[account] = txs
-		}
+	// Let the strategy order the pending transactions and fill the block with them
+	source := &TxSource{
+		Plain: pendingPlainTxs,
+		Blob:  pendingBlobTxs,
+		Prio:  prio,
 	}
-	// Fill the block with all available pending transactions.
-	if len(prioPlainTxs) > 0 || len(prioBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, prioPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, prioBlobTxs, env.header.BaseFee)
-
-		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
-			return err
-		}
-	}
-	if len(normalPlainTxs) > 0 || len(normalBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, normalPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, normalBlobTxs, env.header.BaseFee)
-
-		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
-			return err
-		}
+	txs := strategy.Order(&PendingState{env: env, interrupt: interrupt}, source)
+	if err := miner.commitTransactions(env, txs, interrupt); err != nil {
+		return err
 	}
 	return nil
 }
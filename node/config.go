@@ -190,6 +190,14 @@ type Config struct {
 	// Requests using ip address directly are not affected
 	GraphQLVirtualHosts []string `toml:",omitempty"`
 
+	// GraphQLCostLimit is the maximum cost of a single GraphQL query. Each resolved
+	// field costs one unit, with expensive fields like transaction traces weighing
+	// more. As the cost is charged while the query is resolved, the work done by
+	// the fields resolved in parallel may overshoot the limit, so the default only
+	// admits about a hundred traces or ten block state diffs. The limit applies to
+	// each event delivered by a subscription as well. Zero means no limit.
+	GraphQLCostLimit uint64 `toml:",omitempty"`
+
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
 
@@ -204,6 +212,10 @@ type Config struct {
 	// BatchResponseMaxSize is the maximum number of bytes returned from a batched rpc call.
 	BatchResponseMaxSize int `toml:",omitempty"`
 
+	// RPCRateLimits are the per-client limits on the calls made to the public HTTP
+	// and WebSocket endpoints. The authenticated engine API endpoint isn't limited.
+	RPCRateLimits rpc.RateLimitConfig `toml:",omitempty"`
+
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
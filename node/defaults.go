@@ -67,6 +67,7 @@ var DefaultConfig = Config{
 	BatchRequestLimit:    1000,
 	BatchResponseMaxSize: 25 * 1000 * 1000,
 	GraphQLVirtualHosts:  []string{"localhost"},
+	GraphQLCostLimit:     10000,
 	P2P: p2p.Config{
 		ListenAddr: ":30303",
 		MaxPeers:   50,
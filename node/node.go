@@ -393,6 +393,7 @@ func (n *Node) startRPC() error {
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		rateLimits:             n.config.RPCRateLimits,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
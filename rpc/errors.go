@@ -55,12 +55,16 @@ var (
 	_ Error = new(invalidMessageError)
 	_ Error = new(invalidParamsError)
 	_ Error = new(internalServerError)
+	_ Error = new(rateLimitError)
+	_ Error = new(concurrencyLimitError)
 )
 
 const (
 	errcodeDefault          = -32000
 	errcodeTimeout          = -32002
 	errcodeResponseTooLarge = -32003
+	errcodeRateLimit        = -32005
+	errcodeConcurrencyLimit = -32007
 	errcodePanic            = -32603
 	errcodeMarshalError     = -32603
 
@@ -73,6 +77,26 @@ const (
 	errMsgBatchTooLarge    = "batch too large"
 )
 
+// rateLimitError is returned when a client exceeds the call rate allowed for a
+// namespace.
+type rateLimitError struct{ namespace string }
+
+func (e *rateLimitError) ErrorCode() int { return errcodeRateLimit }
+
+func (e *rateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s namespace", e.namespace)
+}
+
+// concurrencyLimitError is returned when a client exceeds the number of calls
+// allowed in flight for a namespace.
+type concurrencyLimitError struct{ namespace string }
+
+func (e *concurrencyLimitError) ErrorCode() int { return errcodeConcurrencyLimit }
+
+func (e *concurrencyLimitError) Error() string {
+	return fmt.Sprintf("too many concurrent calls to %s namespace", e.namespace)
+}
+
 type methodNotFoundError struct{ method string }
 
 func (e *methodNotFoundError) ErrorCode() int { return -32601 }
@@ -65,6 +65,7 @@ type handler struct {
 	allowSubscribe       bool
 	batchRequestLimit    int
 	batchResponseMaxSize int
+	rateLimiter          *rateLimiter // nil if calls aren't rate limited
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -510,6 +511,13 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if callb == nil {
 		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
 	}
+	if callb != h.unsubscribeCb {
+		release, err := h.rateLimiter.acquire(cp.ctx, msg.Method)
+		if err != nil {
+			return msg.errorResponse(err)
+		}
+		defer release()
+	}
 
 	args, err := parsePositionalArguments(msg.Params, callb.argTypes)
 	if err != nil {
@@ -555,6 +563,11 @@ func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMes
 	if callb == nil {
 		return msg.errorResponse(&subscriptionNotFoundError{namespace, name})
 	}
+	release, err := h.rateLimiter.acquire(cp.ctx, msg.Method)
+	if err != nil {
+		return msg.errorResponse(err)
+	}
+	defer release()
 
 	// Parse subscription name arg too, but remove it before calling the callback.
 	argTypes := append([]reflect.Type{stringType}, callb.argTypes...)
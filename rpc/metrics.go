@@ -32,8 +32,23 @@ var (
 	serveTimeHistName = "rpc/duration"
 
 	rpcServingTimer = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	// rateLimitMeterName is the prefix of the per-method rate limiting meters.
+	rateLimitMeterName = "rpc/ratelimit"
 )
 
+// rateLimitRejectedMeter returns the meter counting the calls to a method
+// rejected by the rate limiter.
+func rateLimitRejectedMeter(method string) *metrics.Meter {
+	return metrics.GetOrRegisterMeter(fmt.Sprintf("%s/%s/rejected", rateLimitMeterName, method), nil)
+}
+
+// rateLimitCostMeter returns the meter counting the tokens taken by the calls
+// to a method.
+func rateLimitCostMeter(method string) *metrics.Meter {
+	return metrics.GetOrRegisterMeter(fmt.Sprintf("%s/%s/cost", rateLimitMeterName, method), nil)
+}
+
 // updateServeTimeHistogram tracks the serving time of a remote RPC call.
 func updateServeTimeHistogram(method string, success bool, elapsed time.Duration) {
 	note := "success"
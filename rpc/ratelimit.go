@@ -0,0 +1,196 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"golang.org/x/time/rate"
+)
+
+// maxRateLimitedClients is the number of client and namespace pairs tracked by
+// the rate limiter. The least recently seen ones are dropped beyond it.
+const maxRateLimitedClients = 16384
+
+// RateLimit is the limit applied to the calls a single client makes to the
+// methods of a namespace.
+type RateLimit struct {
+	Rate        float64 // Tokens refilled per second, zero means unlimited
+	Burst       int     // Size of the token bucket, defaults to the rate
+	Concurrency int     // Maximum number of calls in flight, zero means unlimited
+}
+
+// RateLimitConfig configures the rate limiting of an RPC server. Clients are
+// identified by the subject of their JWT token, their API key if a header is
+// configured for it and the key is one of the accepted ones, or by their IP
+// address otherwise.
+type RateLimitConfig struct {
+	Default    RateLimit            `toml:",omitempty"` // Limit of the namespaces not listed
+	Namespaces map[string]RateLimit `toml:",omitempty"` // Limits of specific namespaces
+	Costs      map[string]int       `toml:",omitempty"` // Tokens taken by specific methods, others take one
+	KeyHeader  string               `toml:",omitempty"` // HTTP header carrying the API key of clients
+	Keys       []string             `toml:",omitempty"` // API keys accepted in the header, others are ignored
+}
+
+// enabled returns whether any limit is configured.
+func (cfg *RateLimitConfig) enabled() bool {
+	if cfg.Default.enabled() {
+		return true
+	}
+	for _, limit := range cfg.Namespaces {
+		if limit.enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+func (l RateLimit) enabled() bool {
+	return l.Rate > 0 || l.Concurrency > 0
+}
+
+// rateBucket tracks the calls of a client to a namespace.
+type rateBucket struct {
+	tokens   *rate.Limiter // nil if the rate is unlimited
+	inflight chan struct{} // nil if the concurrency is unlimited
+}
+
+// rateLimiter enforces the configured limits on the calls of clients.
+type rateLimiter struct {
+	config RateLimitConfig
+	keys   map[string]struct{} // Accepted API keys
+
+	lock    sync.Mutex
+	buckets lru.BasicLRU[string, *rateBucket]
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	keys := make(map[string]struct{}, len(config.Keys))
+	for _, key := range config.Keys {
+		keys[key] = struct{}{}
+	}
+	return &rateLimiter{
+		config:  config,
+		keys:    keys,
+		buckets: lru.NewBasicLRU[string, *rateBucket](maxRateLimitedClients),
+	}
+}
+
+// acquire charges a call to the given method against the limits of the client,
+// returning a function to invoke once the call is done. An error is returned if
+// the call exceeds the limits.
+func (l *rateLimiter) acquire(ctx context.Context, method string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	namespace, _, _ := strings.Cut(method, serviceMethodSeparator)
+	limit, ok := l.config.Namespaces[namespace]
+	if !ok {
+		limit = l.config.Default
+	}
+	if !limit.enabled() {
+		return func() {}, nil
+	}
+	bucket := l.bucket(clientKey(PeerInfoFromContext(ctx)), namespace, limit)
+
+	if bucket.inflight != nil {
+		select {
+		case bucket.inflight <- struct{}{}:
+		default:
+			rateLimitRejectedMeter(method).Mark(1)
+			return nil, &concurrencyLimitError{namespace}
+		}
+	}
+	release := func() {
+		if bucket.inflight != nil {
+			<-bucket.inflight
+		}
+	}
+	if bucket.tokens != nil {
+		cost, ok := l.config.Costs[method]
+		if !ok {
+			cost = 1
+		}
+		// Methods costing more than the bucket size drain it completely
+		if cost > bucket.tokens.Burst() {
+			cost = bucket.tokens.Burst()
+		}
+		if !bucket.tokens.AllowN(time.Now(), cost) {
+			release()
+			rateLimitRejectedMeter(method).Mark(1)
+			return nil, &rateLimitError{namespace}
+		}
+		rateLimitCostMeter(method).Mark(int64(cost))
+	}
+	return release, nil
+}
+
+// bucket returns the bucket tracking the calls of a client to a namespace.
+func (l *rateLimiter) bucket(client, namespace string, limit RateLimit) *rateBucket {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := client + "/" + namespace
+	if bucket, ok := l.buckets.Get(key); ok {
+		return bucket
+	}
+	bucket := new(rateBucket)
+	if limit.Rate > 0 {
+		burst := limit.Burst
+		if burst <= 0 {
+			burst = int(math.Max(1, math.Ceil(limit.Rate)))
+		}
+		bucket.tokens = rate.NewLimiter(rate.Limit(limit.Rate), burst)
+	}
+	if limit.Concurrency > 0 {
+		bucket.inflight = make(chan struct{}, limit.Concurrency)
+	}
+	l.buckets.Add(key, bucket)
+	return bucket
+}
+
+// clientKey returns the identity of a client for rate limiting.
+func clientKey(info PeerInfo) string {
+	if info.ClientID != "" {
+		return info.ClientID
+	}
+	if host, _, err := net.SplitHostPort(info.RemoteAddr); err == nil {
+		return host
+	}
+	return info.RemoteAddr
+}
+
+// clientID returns the identity of the client sending a request, if the client
+// provided an accepted API key. Unknown keys are ignored, so they can't be used
+// to evade the limit of the client IP.
+func (s *Server) clientID(r *http.Request) string {
+	if s.rateLimiter != nil && s.rateLimiter.config.KeyHeader != "" {
+		if key := r.Header.Get(s.rateLimiter.config.KeyHeader); key != "" {
+			if _, ok := s.rateLimiter.keys[key]; ok {
+				return "key:" + key
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+// This test checks that clients are rate limited separately, identified by their
+// API key if it's accepted or their IP otherwise, and that method costs are
+// charged.
+func TestServerRateLimit(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer()
+	s.SetRateLimits(RateLimitConfig{
+		Namespaces: map[string]RateLimit{"test": {Rate: 0.001, Burst: 3}},
+		Costs:      map[string]int{"test_repeat": 2},
+		KeyHeader:  "X-Api-Key",
+		Keys:       []string{"a", "b"},
+	})
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	dial := func(key string) *Client {
+		c, err := Dial(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.SetHeader("X-Api-Key", key)
+		return c
+	}
+	checkCode := func(err error, code int) {
+		t.Helper()
+		var rpcErr Error
+		if !errors.As(err, &rpcErr) {
+			t.Fatalf("expected RPC error, got %v", err)
+		}
+		if rpcErr.ErrorCode() != code {
+			t.Fatalf("wrong error code %d, want %d", rpcErr.ErrorCode(), code)
+		}
+	}
+	a, b := dial("a"), dial("b")
+	defer a.Close()
+	defer b.Close()
+
+	var res string
+	if err := a.Call(&res, "test_repeat", "x", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal(err)
+	}
+	// The bucket of the first client is drained, other namespaces aren't limited.
+	checkCode(a.Call(nil, "test_noArgsRets"), errcodeRateLimit)
+	if err := a.Call(nil, "nftest_echo", 1); err != nil {
+		t.Fatal(err)
+	}
+	// The second client has its own bucket.
+	if err := b.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal(err)
+	}
+	// Unknown keys share the bucket of the client IP.
+	c, d := dial("c"), dial("d")
+	defer c.Close()
+	defer d.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := c.Call(nil, "test_noArgsRets"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkCode(d.Call(nil, "test_noArgsRets"), errcodeRateLimit)
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(RateLimitConfig{Default: RateLimit{Concurrency: 2}})
+	ctx := context.WithValue(context.Background(), peerInfoContextKey{}, PeerInfo{RemoteAddr: "10.0.0.1:30303"})
+
+	release1, err := l.acquire(ctx, "eth_call")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release2, err := l.acquire(ctx, "eth_getLogs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.acquire(ctx, "eth_call"); err == nil {
+		t.Fatal("expected concurrency limit error")
+	} else if code := err.(Error).ErrorCode(); code != errcodeConcurrencyLimit {
+		t.Fatalf("wrong error code %d, want %d", code, errcodeConcurrencyLimit)
+	}
+	// Another port of the same host is the same client.
+	other := context.WithValue(context.Background(), peerInfoContextKey{}, PeerInfo{RemoteAddr: "10.0.0.1:40404"})
+	if _, err := l.acquire(other, "eth_call"); err == nil {
+		t.Fatal("expected concurrency limit error for the same host")
+	}
+	release1()
+	release3, err := l.acquire(other, "eth_call")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release2()
+	release3()
+}
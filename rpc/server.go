@@ -54,6 +54,7 @@ type Server struct {
 	batchItemLimit     int
 	batchResponseLimit int
 	httpBodyLimit      int
+	rateLimiter        *rateLimiter
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -89,6 +90,19 @@ func (s *Server) SetHTTPBodyLimit(limit int) {
 	s.httpBodyLimit = limit
 }
 
+// SetRateLimits sets the limits applied to the calls of each client. Clients
+// exceeding them get their calls rejected until the limits allow them again.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetRateLimits(config RateLimitConfig) {
+	if !config.enabled() {
+		s.rateLimiter = nil
+		return
+	}
+	s.rateLimiter = newRateLimiter(config)
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -114,6 +128,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		rateLimiter:        s.rateLimiter,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -148,6 +163,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 	}
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit)
+	h.rateLimiter = s.rateLimiter
 	h.allowSubscribe = false
 	defer h.close(io.EOF, nil)
 
@@ -235,6 +251,10 @@ type PeerInfo struct {
 		Origin    string
 		Host      string
 	}
+
+	// ClientID identifies the clients providing an accepted API key. It is used
+	// for rate limiting, falling back to the IP address if empty.
+	ClientID string
 }
 
 type peerInfoContextKey struct{}
@@ -105,10 +105,15 @@ func execBlockTest(t *testing.T, bt *testMatcher, test *BlockTest) {
 	}
 	for _, snapshot := range snapshotConf {
 		for _, dbscheme := range dbschemeConf {
-			if err := bt.checkFailure(t, test.Run(snapshot, dbscheme, true, nil, nil)); err != nil {
+			if err := bt.checkFailure(t, test.Run(snapshot, dbscheme, true, false, nil, nil)); err != nil {
 				t.Errorf("test with config {snapshotter:%v, scheme:%v} failed: %v", snapshot, dbscheme, err)
 				return
 			}
 		}
 	}
+	// Cross-check the optimistic parallel execution against the test. Witness
+	// building is disabled as it enforces the sequential execution.
+	if err := bt.checkFailure(t, test.Run(false, rawdb.HashScheme, false, true, nil, nil)); err != nil {
+		t.Errorf("test with parallel execution failed: %v", err)
+	}
 }
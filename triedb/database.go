@@ -133,11 +133,21 @@ func (db *Database) StateReader(blockRoot common.Hash) (database.StateReader, er
 func (db *Database) HistoricReader(root common.Hash) (*pathdb.HistoricalStateReader, error) {
 	pdb, ok := db.backend.(*pathdb.Database)
 	if !ok {
-		return nil, errors.New("not supported")
+		return nil, errors.New("historical state access is only supported in path scheme")
 	}
 	return pdb.HistoricReader(root)
 }
 
+// HistoricNodeDatabase constructs a node database for accessing the trie nodes
+// of the requested historic state.
+func (db *Database) HistoricNodeDatabase(root common.Hash) (*pathdb.HistoricalNodeDatabase, error) {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return nil, errors.New("historical state access is only supported in path scheme")
+	}
+	return pdb.HistoricNodeDatabase(root)
+}
+
 // Update performs a state transition by committing dirty nodes contained in the
 // given set in order to update state from the specified parent to the specified
 // root. The held pre-images accumulated up to this point will be flushed in case
@@ -0,0 +1,309 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/ethereum/go-ethereum/triedb/database"
+)
+
+// errHistoricalStateTooOld is returned if the requested historical state is
+// beyond the configured state history retention.
+var errHistoricalStateTooOld = errors.New("historical state is too old to be reconstructed")
+
+// HistoricalNodeDatabase provides access to the trie nodes of a historical state,
+// which are no longer retained by the path database.
+//
+// The tries are reconstructed in memory, by applying the reverse state diffs
+// recorded in the state histories onto the tries of the persistent disk layer.
+// Only the modified trie nodes are held in memory, the unchanged ones are still
+// resolved from the disk layer. The account trie is reconstructed upfront, while
+// the storage tries are reconstructed on demand.
+//
+// Note, the cost of reconstruction grows with the distance between the requested
+// state and the disk layer, which is bounded by the configured state history
+// retention. The database is also bound to the disk layer at the time of
+// construction and becomes unusable once the disk layer becomes stale.
+type HistoricalNodeDatabase struct {
+	db     *Database
+	root   common.Hash         // Root hash of the historical state
+	id     uint64              // State ID of the historical state
+	base   common.Hash         // Root hash of the disk layer to reconstruct from
+	baseID uint64              // State ID of the disk layer to reconstruct from
+	reader database.NodeReader // Node reader of the disk layer
+
+	// accounts are the accounts modified since the historical state, keyed by
+	// the hash of the address, along with the account data (slim format) in the
+	// historical state. Nil data means the account was not present.
+	accounts map[common.Hash]common.Address
+	data     map[common.Hash][]byte
+
+	nodes map[common.Hash]map[string]*trienode.Node // Reconstructed trie nodes, keyed by owner and path
+	lock  sync.Mutex                                // Lock for protecting the lazy reconstruction
+}
+
+// HistoricNodeDatabase constructs a node database for accessing the trie nodes
+// of the requested historical state.
+func (db *Database) HistoricNodeDatabase(root common.Hash) (*HistoricalNodeDatabase, error) {
+	if db.isVerkle {
+		return nil, errors.New("historical trie nodes are not available in verkle")
+	}
+	if db.freezer == nil {
+		return nil, errors.New("state histories are not available")
+	}
+	id := rawdb.ReadStateID(db.diskdb, root)
+	if id == nil {
+		return nil, fmt.Errorf("historical state %#x is not available", root)
+	}
+	// States at the current disk layer or above are directly accessible via
+	// the layers, reconstruct the states older than the disk layer only.
+	dl := db.tree.bottom()
+	if *id >= dl.stateID() {
+		return nil, fmt.Errorf("state %#x is not historical", root)
+	}
+	if limit := db.config.StateHistory; limit != 0 && dl.stateID()-*id > limit {
+		return nil, fmt.Errorf("%w: %d states behind, limit %d", errHistoricalStateTooOld, dl.stateID()-*id, limit)
+	}
+	reader, err := db.NodeReader(dl.rootHash())
+	if err != nil {
+		return nil, err
+	}
+	hdb := &HistoricalNodeDatabase{
+		db:       db,
+		root:     root,
+		id:       *id,
+		base:     dl.rootHash(),
+		baseID:   dl.stateID(),
+		reader:   reader,
+		accounts: make(map[common.Hash]common.Address),
+		data:     make(map[common.Hash][]byte),
+		nodes:    make(map[common.Hash]map[string]*trienode.Node),
+	}
+	if err := hdb.reconstructAccounts(); err != nil {
+		return nil, err
+	}
+	return hdb, nil
+}
+
+// iterate traverses the state histories between the historical state and the
+// disk layer in ascending order, namely from the oldest to the newest.
+func (hdb *HistoricalNodeDatabase) iterate(fn func(h *history)) error {
+	for start := hdb.id + 1; start <= hdb.baseID; start += historyReadBatch {
+		count := min(historyReadBatch, hdb.baseID-start+1)
+		histories, err := readHistories(hdb.db.freezer, start, count)
+		if err != nil {
+			return err
+		}
+		for _, h := range histories {
+			fn(h)
+		}
+	}
+	return nil
+}
+
+// reconstructAccounts reconstructs the account trie of the historical state.
+func (hdb *HistoricalNodeDatabase) reconstructAccounts() error {
+	start := time.Now()
+
+	// The original value recorded in the first history after the historical
+	// state is the value in the historical state.
+	var first *history
+	err := hdb.iterate(func(h *history) {
+		if first == nil {
+			first = h
+		}
+		for addr, blob := range h.accounts {
+			addrHash := crypto.Keccak256Hash(addr.Bytes())
+			if _, ok := hdb.accounts[addrHash]; ok {
+				continue
+			}
+			hdb.accounts[addrHash] = addr
+			hdb.data[addrHash] = blob
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if first == nil || first.meta.parent != hdb.root {
+		return fmt.Errorf("state history of %#x is not available", hdb.root)
+	}
+	tr, err := trie.New(trie.TrieID(hdb.base), hdb.db)
+	if err != nil {
+		return err
+	}
+	for addrHash, blob := range hdb.data {
+		if len(blob) == 0 {
+			err = tr.Delete(addrHash.Bytes())
+		} else {
+			var full []byte
+			full, err = types.FullAccountRLP(blob)
+			if err == nil {
+				err = tr.Update(addrHash.Bytes(), full)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	root, set := tr.Commit(false)
+	if root != hdb.root {
+		return fmt.Errorf("failed to reconstruct account trie, want %#x, got %#x", hdb.root, root)
+	}
+	hdb.nodes[common.Hash{}] = make(map[string]*trienode.Node)
+	if set != nil {
+		hdb.nodes[common.Hash{}] = set.Nodes
+	}
+	log.Debug("Reconstructed historical account trie", "root", hdb.root, "id", hdb.id, "base", hdb.baseID, "accounts", len(hdb.accounts), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// reconstructStorage reconstructs the storage trie of the specified account in
+// the historical state.
+func (hdb *HistoricalNodeDatabase) reconstructStorage(owner common.Hash) (map[string]*trienode.Node, error) {
+	start := time.Now()
+
+	// Resolve the storage root of the account in the disk layer and the one in
+	// the historical state.
+	tr, err := trie.New(trie.TrieID(hdb.base), hdb.db)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := tr.Get(owner.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	post := types.NewEmptyStateAccount()
+	if len(blob) != 0 {
+		if err := rlp.DecodeBytes(blob, post); err != nil {
+			return nil, err
+		}
+	}
+	prev := types.NewEmptyStateAccount()
+	if len(hdb.data[owner]) != 0 {
+		if prev, err = types.FullAccount(hdb.data[owner]); err != nil {
+			return nil, err
+		}
+	}
+	// Aggregate the storage changes since the historical state, keyed by the
+	// hash of the slot key.
+	var (
+		addr  = hdb.accounts[owner]
+		slots = make(map[common.Hash][]byte)
+	)
+	err = hdb.iterate(func(h *history) {
+		for key, val := range h.storages[addr] {
+			if h.meta.version != stateHistoryV0 {
+				key = crypto.Keccak256Hash(key.Bytes())
+			}
+			if _, ok := slots[key]; !ok {
+				slots[key] = val
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	st, err := trie.New(trie.StorageTrieID(hdb.base, owner, post.Root), hdb.db)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range slots {
+		if len(val) == 0 {
+			err = st.Delete(key.Bytes())
+		} else {
+			err = st.Update(key.Bytes(), val)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	root, set := st.Commit(false)
+	if root != prev.Root {
+		return nil, fmt.Errorf("failed to reconstruct storage trie of %#x, want %#x, got %#x", owner, prev.Root, root)
+	}
+	nodes := make(map[string]*trienode.Node)
+	if set != nil {
+		nodes = set.Nodes
+	}
+	log.Debug("Reconstructed historical storage trie", "root", hdb.root, "owner", owner, "slots", len(slots), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nodes, nil
+}
+
+// modified returns the reconstructed trie nodes of the specified trie. Nil is
+// returned if the trie is not modified since the historical state.
+func (hdb *HistoricalNodeDatabase) modified(owner common.Hash) (map[string]*trienode.Node, error) {
+	hdb.lock.Lock()
+	defer hdb.lock.Unlock()
+
+	if nodes, ok := hdb.nodes[owner]; ok {
+		return nodes, nil
+	}
+	// Any storage change leads to the modification of the account, the storage
+	// is untouched if the account is not modified.
+	if _, ok := hdb.accounts[owner]; !ok {
+		return nil, nil
+	}
+	nodes, err := hdb.reconstructStorage(owner)
+	if err != nil {
+		return nil, err
+	}
+	hdb.nodes[owner] = nodes
+	return nodes, nil
+}
+
+// NodeReader implements database.NodeDatabase, returning a node reader of the
+// historical state.
+func (hdb *HistoricalNodeDatabase) NodeReader(root common.Hash) (database.NodeReader, error) {
+	if root != hdb.root {
+		return nil, fmt.Errorf("state %#x is not available", root)
+	}
+	return &historicalNodeReader{db: hdb}, nil
+}
+
+// historicalNodeReader implements database.NodeReader, resolving the trie nodes
+// of the historical state.
+type historicalNodeReader struct {
+	db *HistoricalNodeDatabase
+}
+
+// Node implements database.NodeReader, retrieving the trie node with the given
+// trie identifier, node path and the node hash.
+func (r *historicalNodeReader) Node(owner common.Hash, path []byte, hash common.Hash) ([]byte, error) {
+	nodes, err := r.db.modified(owner)
+	if err != nil {
+		return nil, err
+	}
+	if n, ok := nodes[string(path)]; ok {
+		if n.IsDeleted() || n.Hash != hash {
+			return nil, &trie.MissingNodeError{Owner: owner, Path: path, NodeHash: hash}
+		}
+		return n.Blob, nil
+	}
+	return r.db.reader.Node(owner, path, hash)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestHistoricalNodeDatabase(t *testing.T) {
+	testHistoricalNodeDatabase(t, 0)  // with all histories reserved
+	testHistoricalNodeDatabase(t, 10) // with latest 10 histories reserved
+}
+
+func testHistoricalNodeDatabase(t *testing.T, historyLimit uint64) {
+	maxDiffLayers = 4
+	defer func() {
+		maxDiffLayers = 128
+	}()
+	env := newTester(t, historyLimit, false, 64, false)
+	defer env.release()
+
+	dRoot := env.db.tree.bottom().rootHash()
+	for _, root := range env.roots {
+		if root == dRoot {
+			break
+		}
+		hdb, err := env.db.HistoricNodeDatabase(root)
+		if rawdb.ReadStateID(env.db.diskdb, root) == nil {
+			if err == nil {
+				t.Fatalf("Historical state %x is pruned but available", root)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Failed to reconstruct historical state %x: %v", root, err)
+		}
+		tr, err := trie.New(trie.TrieID(root), hdb)
+		if err != nil {
+			t.Fatalf("Failed to open historical account trie: %v", err)
+		}
+		for addrHash, account := range env.snapAccounts[root] {
+			blob, err := tr.Get(addrHash.Bytes())
+			if err != nil {
+				t.Fatalf("Failed to read account %x: %v", addrHash, err)
+			}
+			want, _ := types.FullAccountRLP(account)
+			if !bytes.Equal(blob, want) {
+				t.Fatalf("Unexpected account %x, want %x, got %x", addrHash, want, blob)
+			}
+			acct, _ := types.FullAccount(account)
+			st, err := trie.New(trie.StorageTrieID(root, addrHash, acct.Root), hdb)
+			if err != nil {
+				t.Fatalf("Failed to open historical storage trie: %v", err)
+			}
+			for slotHash, slot := range env.snapStorages[root][addrHash] {
+				blob, err := st.Get(slotHash.Bytes())
+				if err != nil {
+					t.Fatalf("Failed to read storage %x %x: %v", addrHash, slotHash, err)
+				}
+				if !bytes.Equal(blob, slot) {
+					t.Fatalf("Unexpected storage %x %x, want %x, got %x", addrHash, slotHash, slot, blob)
+				}
+			}
+			if st.Hash() != acct.Root {
+				t.Fatalf("Unexpected storage root %x, want %x, got %x", addrHash, acct.Root, st.Hash())
+			}
+		}
+	}
+}
+
+func TestHistoricalNodeDatabaseDistance(t *testing.T) {
+	maxDiffLayers = 4
+	defer func() {
+		maxDiffLayers = 128
+	}()
+	env := newTester(t, 0, false, 64, false)
+	defer env.release()
+
+	// Lower the retention once the histories are written, the states beyond
+	// it must be refused even if their histories are not pruned yet.
+	env.db.config.StateHistory = 8
+	bottom := *rawdb.ReadStateID(env.db.diskdb, env.db.tree.bottom().rootHash())
+	for _, root := range env.roots {
+		id := rawdb.ReadStateID(env.db.diskdb, root)
+		if id == nil || *id >= bottom {
+			continue
+		}
+		_, err := env.db.HistoricNodeDatabase(root)
+		if bottom-*id > env.db.config.StateHistory {
+			if !errors.Is(err, errHistoricalStateTooOld) {
+				t.Fatalf("Expected distance error for state %d behind, got %v", bottom-*id, err)
+			}
+		} else if err != nil {
+			t.Fatalf("Failed to reconstruct historical state %d behind: %v", bottom-*id, err)
+		}
+	}
+}
@@ -207,12 +207,15 @@ type HistoricalStateReader struct {
 // HistoricReader constructs a reader for accessing the requested historic state.
 func (db *Database) HistoricReader(root common.Hash) (*HistoricalStateReader, error) {
 	// Bail out if the state history hasn't been fully indexed
-	if db.indexer == nil || !db.indexer.inited() {
-		return nil, errors.New("state histories haven't been fully indexed yet")
-	}
 	if db.freezer == nil {
 		return nil, errors.New("state histories are not available")
 	}
+	if db.indexer == nil {
+		return nil, errors.New("state history indexing is not enabled")
+	}
+	if !db.indexer.inited() {
+		return nil, errors.New("state histories haven't been fully indexed yet")
+	}
 	// States at the current disk layer or above are directly accessible via
 	// db.StateReader.
 	//
@@ -223,9 +226,11 @@ func (db *Database) HistoricReader(root common.Hash) (*HistoricalStateReader, er
 	// already been pruned. This function does not validate availability, as
 	// underlying states may be pruned dynamically. Validity is checked during
 	// each actual state retrieval.
+	// The state ID is deleted along with the pruned state history, the missing
+	// ID also indicates the state is too old to be served.
 	id := rawdb.ReadStateID(db.diskdb, root)
 	if id == nil {
-		return nil, fmt.Errorf("state %#x is not available", root)
+		return nil, fmt.Errorf("historical state %#x is not available", root)
 	}
 	return &HistoricalStateReader{
 		id:     *id,